@@ -654,3 +654,38 @@ func (blob *Blob) Save(ctx context.Context) (*Manifest, error) {
 	m := blob.m
 	return &m, nil
 }
+
+// Walk calls fn for every chunk persisted in the chunk store that
+// makes up the Blob, pointer chunks before the chunks they point to.
+//
+// Chunks that are not stored, such as Empty chunks and modified
+// chunks not yet saved, are skipped. If fn returns an error, Walk
+// stops and returns that error.
+func (blob *Blob) Walk(ctx context.Context, fn func(key cas.Key, level uint8) error) error {
+	return blob.walk(ctx, blob.m.Root, blob.depth, fn)
+}
+
+func (blob *Blob) walk(ctx context.Context, key cas.Key, level uint8, fn func(key cas.Key, level uint8) error) error {
+	if key.IsSpecial() {
+		return nil
+	}
+	if err := fn(key, level); err != nil {
+		return err
+	}
+	if level == 0 {
+		return nil
+	}
+	chunk, err := blob.stash.Get(ctx, key, blob.m.Type, level)
+	if err != nil {
+		return err
+	}
+	for off := 0; off < len(chunk.Buf); off += cas.KeySize {
+		// zero trimming may have cut the key off, even in the middle
+		keybuf := safeSlice(chunk.Buf, off, off+cas.KeySize)
+		child := cas.NewKeyPrivate(keybuf)
+		if err := blob.walk(ctx, child, level-1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package blobs_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"testing"
 
@@ -702,3 +703,59 @@ func BenchmarkWriteBig(b *testing.B) {
 		}
 	}
 }
+
+func TestWalk(t *testing.T) {
+	const chunkSize = 4096
+	const fanout = 2
+	chunkStore := &mock.InMemory{}
+	// just enough to need two levels of pointer chunks
+	greeting := bytes.Repeat(GREETING, 2*chunkSize/len(GREETING)+1)
+
+	ctx := context.Background()
+	blob, err := blobs.Open(chunkStore, &blobs.Manifest{
+		Type:      "footype",
+		ChunkSize: chunkSize,
+		Fanout:    fanout,
+	})
+	if err != nil {
+		t.Fatalf("cannot open blob: %v", err)
+	}
+	if _, err := blob.IO(ctx).WriteAt(greeting, 0); err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+	saved, err := blob.Save(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error from Save: %v", err)
+	}
+
+	b, err := blobs.Open(chunkStore, saved)
+	if err != nil {
+		t.Fatalf("cannot open saved blob: %v", err)
+	}
+	var levels []uint8
+	walk := func(key cas.Key, level uint8) error {
+		if _, err := chunkStore.Get(ctx, key, "footype", level); err != nil {
+			t.Errorf("walked to chunk not in store: %v@%d: %v", key, level, err)
+		}
+		levels = append(levels, level)
+		return nil
+	}
+	if err := b.Walk(ctx, walk); err != nil {
+		t.Fatalf("walk error: %v", err)
+	}
+	if g, e := fmt.Sprint(levels), "[2 1 0 0 1 0]"; g != e {
+		t.Errorf("unexpected walk order: %v != %v", g, e)
+	}
+}
+
+func TestWalkEmpty(t *testing.T) {
+	blob := emptyBlob(t, mock.NeverUsed{})
+	ctx := context.Background()
+	walk := func(key cas.Key, level uint8) error {
+		t.Errorf("unexpected chunk: %v@%d", key, level)
+		return nil
+	}
+	if err := blob.Walk(ctx, walk); err != nil {
+		t.Fatalf("walk error: %v", err)
+	}
+}
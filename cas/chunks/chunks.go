@@ -4,6 +4,7 @@ package chunks
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"bazil.org/bazil/cas"
 )
@@ -32,4 +33,9 @@ type Store interface {
 
 	// Add a chunk to the chunk store.
 	Add(ctx context.Context, chunk *Chunk) (key cas.Key, err error)
+
+	// Close releases the resources held by the store, including
+	// closing the storage it wraps. The store must not be used
+	// after Close.
+	io.Closer
 }
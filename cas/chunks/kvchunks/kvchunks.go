@@ -53,6 +53,13 @@ func (s *storeInKV) Add(ctx context.Context, chunk *chunks.Chunk) (key cas.Key,
 	return key, nil
 }
 
+// Close closes the underlying KV.
+func (s *storeInKV) Close() error {
+	return s.kv.Close()
+}
+
+// New returns a chunks.Store that keeps the chunks in keyval. The
+// store owns keyval, and closes it on Close.
 func New(keyval kv.KV) chunks.Store {
 	return &storeInKV{
 		kv: keyval,
@@ -0,0 +1,117 @@
+package tail
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/audit"
+)
+
+// How often to look for new records when following.
+const followInterval = 500 * time.Millisecond
+
+type tailCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		Lines  int
+		Follow bool
+		JSON   bool
+	}
+}
+
+func (cmd *tailCommand) print(w io.Writer, records []*audit.Record) error {
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(w)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, r := range records {
+		fields := []string{r.Time.UTC().Format(time.RFC3339)}
+		switch {
+		case r.Peer != "":
+			fields = append(fields, "peer="+r.Peer)
+		case r.UID != nil:
+			fields = append(fields, fmt.Sprintf("uid=%d", *r.UID))
+		default:
+			fields = append(fields, "-")
+		}
+		fields = append(fields, strings.TrimPrefix(r.RPC, "/bazil."))
+		for _, kv := range []struct{ k, v string }{
+			{"volume", r.Volume},
+			{"volumeID", r.VolumeID},
+			{"object", r.Object},
+			{"targetPeer", r.TargetPeer},
+		} {
+			if kv.v != "" {
+				fields = append(fields, kv.k+"="+kv.v)
+			}
+		}
+		outcome := r.Code
+		if r.Error != "" {
+			outcome += ": " + r.Error
+		}
+		fields = append(fields, outcome)
+		if _, err := fmt.Fprintln(w, strings.Join(fields, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *tailCommand) Run() error {
+	dir := server.AuditDir(clibazil.Bazil.Config.DataDir.String())
+	if !cmd.Config.Follow {
+		records, err := audit.Tail(dir, cmd.Config.Lines)
+		if err != nil {
+			return err
+		}
+		return cmd.print(os.Stdout, records)
+	}
+
+	f, err := audit.Follow(dir, cmd.Config.Lines)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for {
+		records, err := f.Poll()
+		if err != nil {
+			return err
+		}
+		if err := cmd.print(os.Stdout, records); err != nil {
+			return err
+		}
+		time.Sleep(followInterval)
+	}
+}
+
+var tail = tailCommand{
+	Description: "show the audit log",
+	Overview: `
+Show the last records of the audit log: calls made by peers, and
+administrative commands run by local users, with their outcome. The
+log is read directly from the data directory, so this works even
+when the server is not running.
+`,
+}
+
+func init() {
+	tail.IntVar(&tail.Config.Lines, "n", 20, "number of records to show")
+	tail.BoolVar(&tail.Config.Follow, "f", false, "keep showing records as they are added")
+	tail.BoolVar(&tail.Config.JSON, "json", false, "output JSON, one record per line")
+	subcommands.Register(&tail)
+}
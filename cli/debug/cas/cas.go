@@ -76,6 +76,13 @@ func (c *casCommand) Setup() (ok bool) {
 }
 
 func (c *casCommand) Teardown() (ok bool) {
+	if c.State.Store == nil {
+		return true
+	}
+	if err := c.State.Store.Close(); err != nil {
+		log.Printf("cannot close CAS: %v", err)
+		return false
+	}
 	return true
 }
 
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"context"
+	"os"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/passphrase"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type exportCommand struct {
+	subcommands.Description
+	subcommands.Synopsis
+	subcommands.Overview
+}
+
+func (cmd *exportCommand) Run() error {
+	pass, err := passphrase.Read("Passphrase to encrypt with", true)
+	if err != nil {
+		return err
+	}
+	req := &wire.IdentityExportRequest{
+		Passphrase: pass,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.IdentityExport(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	if _, err := os.Stdout.Write(resp.Armored); err != nil {
+		return err
+	}
+	return nil
+}
+
+var export = exportCommand{
+	Description: "back up the identity of this server",
+	Synopsis:    ">FILE",
+	Overview: `
+Writes the private key of this server to standard output as armored
+text, encrypted with a passphrase. Peers know this server by its
+public key; keep the backup to restore the identity with "bazil
+identity import" if the data directory is lost, or when moving to a
+new machine.
+`,
+}
+
+func init() {
+	subcommands.Register(&export)
+}
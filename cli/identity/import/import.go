@@ -0,0 +1,70 @@
+package import_
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/passphrase"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+)
+
+type importCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		Force bool
+	}
+	Arguments struct {
+		File string
+	}
+}
+
+func (cmd *importCommand) Run() error {
+	armored, err := ioutil.ReadFile(cmd.Arguments.File)
+	if err != nil {
+		return err
+	}
+	pass, err := passphrase.Read("Passphrase", false)
+	if err != nil {
+		return err
+	}
+	priv, err := server.ImportIdentity(armored, pass)
+	if err != nil {
+		return err
+	}
+
+	dataDir := clibazil.Bazil.Config.DataDir.String()
+	app, err := server.New(dataDir, server.Identity(priv, cmd.Config.Force))
+	if err == server.ErrIdentityExists {
+		return fmt.Errorf("%v; use -force to replace it", err)
+	}
+	if err != nil {
+		return err
+	}
+	defer app.Close()
+	fmt.Println((*peer.PublicKey)(app.Keys.Sign.Pub).String())
+	return nil
+}
+
+var import_ = importCommand{
+	Description: "restore the identity of this server",
+	Overview: `
+Makes this server use the identity from FILE, as written by "bazil
+identity export". The passphrase is read from standard input. The
+data directory is created if needed.
+
+The server must not be running. An existing, different identity is
+only replaced when -force is given; peers that know the old identity
+will no longer recognize this server.
+`,
+}
+
+func init() {
+	import_.BoolVar(&import_.Config.Force, "force", false, "replace an existing identity")
+	subcommands.Register(&import_)
+}
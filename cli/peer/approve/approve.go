@@ -0,0 +1,41 @@
+package approve
+
+import (
+	"context"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type approveCommand struct {
+	subcommands.Description
+	Arguments struct {
+		PubKey peer.PublicKey
+	}
+}
+
+func (cmd *approveCommand) Run() error {
+	req := &wire.PeerApproveRequest{
+		Pub: cmd.Arguments.PubKey[:],
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	if _, err := client.PeerApprove(ctx, req); err != nil {
+		// TODO unwrap error
+		return err
+	}
+	return nil
+}
+
+var approve = approveCommand{
+	Description: "add a paired peer waiting for approval",
+}
+
+func init() {
+	subcommands.Register(&approve)
+}
@@ -0,0 +1,119 @@
+package info
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type infoCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		JSON bool
+	}
+	Arguments struct {
+		PubKey peer.PublicKey
+	}
+}
+
+type volumeJSON struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name,omitempty"`
+	Paths    []string `json:"paths,omitempty"`
+	ReadOnly bool     `json:"readOnly,omitempty"`
+}
+
+type peerJSON struct {
+	Pub       string       `json:"pub"`
+	ID        uint32       `json:"id"`
+	Locations []string     `json:"locations"`
+	Storage   []string     `json:"storage"`
+	Volumes   []volumeJSON `json:"volumes"`
+}
+
+func (cmd *infoCommand) Run() error {
+	req := &wire.PeerGetRequest{
+		Pub: cmd.Arguments.PubKey[:],
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerGet(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	info := peerJSON{
+		Pub:       cmd.Arguments.PubKey.String(),
+		ID:        resp.Peer.Id,
+		Locations: append([]string{}, resp.Peer.Locations...),
+		Storage:   append([]string{}, resp.Peer.Storage...),
+		Volumes:   []volumeJSON{},
+	}
+	for _, v := range resp.Peer.Volumes {
+		var volID db.VolumeID
+		if err := volID.UnmarshalBinary(v.VolumeID); err != nil {
+			return err
+		}
+		info.Volumes = append(info.Volumes, volumeJSON{
+			ID:       volID.String(),
+			Name:     v.VolumeName,
+			Paths:    v.Paths,
+			ReadOnly: v.ReadOnly,
+		})
+	}
+
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	fmt.Printf("Pub: %s\n", info.Pub)
+	fmt.Printf("ID: %d\n", info.ID)
+	fmt.Printf("\nLocations:\n")
+	for _, loc := range info.Locations {
+		fmt.Printf("  %s\n", loc)
+	}
+	fmt.Printf("\nStorage allowed:\n")
+	for _, backend := range info.Storage {
+		fmt.Printf("  %s\n", backend)
+	}
+	fmt.Printf("\nVolumes allowed:\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, v := range info.Volumes {
+		access := "read-write"
+		if v.ReadOnly {
+			access = "read-only"
+		}
+		paths := "/"
+		if len(v.Paths) > 0 {
+			paths = "/" + strings.Join(v.Paths, " /")
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", v.Name, v.ID, access, paths)
+	}
+	return w.Flush()
+}
+
+var info = infoCommand{
+	Description: "show details of a peer",
+}
+
+func init() {
+	info.BoolVar(&info.Config.JSON, "json", false, "output JSON")
+	subcommands.Register(&info)
+}
@@ -0,0 +1,58 @@
+package join
+
+import (
+	"context"
+	"fmt"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type joinCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	Arguments struct {
+		Addr string `positional:"metavar=HOST:PORT"`
+		Code peer.PairingCode
+	}
+}
+
+func (cmd *joinCommand) Run() error {
+	req := &wire.PeerPairJoinRequest{
+		Netloc: cmd.Arguments.Addr,
+		Code:   cmd.Arguments.Code.String(),
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerPairJoin(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(resp.Pub); err != nil {
+		return err
+	}
+	fmt.Println(pub.String())
+	return nil
+}
+
+var join = joinCommand{
+	Description: "pair with a peer in pairing mode",
+	Overview: `
+Pair with the server at HOST:PORT, using the code printed by "bazil
+peer pair" there. The code also identifies that server, so its public
+key does not need to be known in advance. On success, the peer is
+added here and its public key is printed; the other side still has
+to approve this server.
+`,
+}
+
+func init() {
+	subcommands.Register(&join)
+}
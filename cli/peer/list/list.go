@@ -0,0 +1,85 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type listCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		JSON bool
+	}
+}
+
+type peerJSON struct {
+	Pub       string   `json:"pub"`
+	ID        uint32   `json:"id"`
+	Locations []string `json:"locations"`
+	Volumes   []string `json:"volumes"`
+}
+
+func (cmd *listCommand) Run() error {
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerList(ctx, &wire.PeerListRequest{})
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	list := make([]peerJSON, 0, len(resp.Peers))
+	for _, p := range resp.Peers {
+		var pub peer.PublicKey
+		if err := pub.UnmarshalBinary(p.Pub); err != nil {
+			return err
+		}
+		item := peerJSON{
+			Pub:       pub.String(),
+			ID:        p.Id,
+			Locations: append([]string{}, p.Locations...),
+			Volumes:   []string{},
+		}
+		for _, v := range p.Volumes {
+			item.Volumes = append(item.Volumes, v.VolumeName)
+		}
+		list = append(list, item)
+	}
+
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "PUB\tID\tLOCATIONS\tVOLUMES\n")
+	for _, item := range list {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", item.Pub, item.ID,
+			strings.Join(item.Locations, ","), strings.Join(item.Volumes, ","))
+	}
+	return w.Flush()
+}
+
+var list = listCommand{
+	Description: "list peers",
+}
+
+func init() {
+	list.BoolVar(&list.Config.JSON, "json", false, "output JSON")
+	subcommands.Register(&list)
+}
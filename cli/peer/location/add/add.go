@@ -0,0 +1,50 @@
+package add
+
+import (
+	"context"
+	"flag"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type addCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		Priority uint
+	}
+	Arguments struct {
+		PubKey peer.PublicKey
+		Addr   string `positional:"metavar=HOST:PORT"`
+	}
+}
+
+func (cmd *addCommand) Run() error {
+	req := &wire.PeerLocationAddRequest{
+		Pub:      cmd.Arguments.PubKey[:],
+		Netloc:   cmd.Arguments.Addr,
+		Priority: uint32(cmd.Config.Priority),
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	if _, err := client.PeerLocationAdd(ctx, req); err != nil {
+		// TODO unwrap error
+		return err
+	}
+	return nil
+}
+
+var add = addCommand{
+	Description: "add a network location for peer",
+}
+
+func init() {
+	add.UintVar(&add.Config.Priority, "priority", 0, "try locations with lower priority first")
+	subcommands.Register(&add)
+}
@@ -0,0 +1,55 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type listCommand struct {
+	subcommands.Description
+	Arguments struct {
+		PubKey peer.PublicKey
+	}
+}
+
+func (cmd *listCommand) Run() error {
+	req := &wire.PeerLocationListRequest{
+		Pub: cmd.Arguments.PubKey[:],
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerLocationList(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "LOCATION\tPRIORITY\tLAST\n")
+	for _, loc := range resp.Locations {
+		last := ""
+		if loc.Last {
+			last = "*"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", loc.Netloc, loc.Priority, last)
+	}
+	return w.Flush()
+}
+
+var list = listCommand{
+	Description: "list network locations of peer, in the order they are tried",
+}
+
+func init() {
+	subcommands.Register(&list)
+}
@@ -0,0 +1,43 @@
+package remove
+
+import (
+	"context"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type removeCommand struct {
+	subcommands.Description
+	Arguments struct {
+		PubKey peer.PublicKey
+		Addr   string `positional:"metavar=HOST:PORT"`
+	}
+}
+
+func (cmd *removeCommand) Run() error {
+	req := &wire.PeerLocationRemoveRequest{
+		Pub:    cmd.Arguments.PubKey[:],
+		Netloc: cmd.Arguments.Addr,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	if _, err := client.PeerLocationRemove(ctx, req); err != nil {
+		// TODO unwrap error
+		return err
+	}
+	return nil
+}
+
+var remove = removeCommand{
+	Description: "remove a network location of peer",
+}
+
+func init() {
+	subcommands.Register(&remove)
+}
@@ -0,0 +1,54 @@
+package pair
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type pairCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		For time.Duration
+	}
+}
+
+func (cmd *pairCommand) Run() error {
+	req := &wire.PeerPairRequest{
+		Seconds: uint32(cmd.Config.For / time.Second),
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerPair(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	fmt.Println(resp.Code)
+	return nil
+}
+
+var pair = pairCommand{
+	Description: "let new peers pair with this server",
+	Overview: `
+Enter pairing mode and print a pairing code. Until the time runs out,
+other servers can use the code with "bazil peer join" to pair with
+this one. Paired peers wait for approval with "bazil peer approve";
+see "bazil peer pending".
+`,
+}
+
+func init() {
+	pair.DurationVar(&pair.Config.For, "for", 5*time.Minute, "how long to stay in pairing mode")
+	subcommands.Register(&pair)
+}
@@ -0,0 +1,77 @@
+package pending
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type pendingCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		JSON bool
+	}
+}
+
+type pendingJSON struct {
+	Pub string `json:"pub"`
+	// RFC 3339.
+	Since string `json:"since"`
+}
+
+func (cmd *pendingCommand) Run() error {
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerPendingList(ctx, &wire.PeerPendingListRequest{})
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	list := make([]pendingJSON, 0, len(resp.Peers))
+	for _, p := range resp.Peers {
+		var pub peer.PublicKey
+		if err := pub.UnmarshalBinary(p.Pub); err != nil {
+			return err
+		}
+		list = append(list, pendingJSON{
+			Pub:   pub.String(),
+			Since: time.Unix(p.Since, 0).UTC().Format(time.RFC3339),
+		})
+	}
+
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "PUB\tSINCE\n")
+	for _, item := range list {
+		fmt.Fprintf(w, "%s\t%s\n", item.Pub, item.Since)
+	}
+	return w.Flush()
+}
+
+var pending = pendingCommand{
+	Description: "list paired peers waiting for approval",
+}
+
+func init() {
+	pending.BoolVar(&pending.Config.JSON, "json", false, "output JSON")
+	subcommands.Register(&pending)
+}
@@ -0,0 +1,41 @@
+package remove
+
+import (
+	"context"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type removeCommand struct {
+	subcommands.Description
+	Arguments struct {
+		PubKey peer.PublicKey
+	}
+}
+
+func (cmd *removeCommand) Run() error {
+	req := &wire.PeerRemoveRequest{
+		Pub: cmd.Arguments.PubKey[:],
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	if _, err := client.PeerRemove(ctx, req); err != nil {
+		// TODO unwrap error
+		return err
+	}
+	return nil
+}
+
+var remove = removeCommand{
+	Description: "remove a peer and revoke its access",
+}
+
+func init() {
+	subcommands.Register(&remove)
+}
@@ -0,0 +1,121 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/positional"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type statusCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		JSON bool
+		Ping bool
+	}
+	Arguments struct {
+		positional.Optional
+		PubKey peer.PublicKey
+	}
+}
+
+type statusJSON struct {
+	Pub       string `json:"pub"`
+	Connected bool   `json:"connected"`
+	State     string `json:"state,omitempty"`
+	InUse     uint32 `json:"inUse"`
+	// RFC 3339, or empty if never seen.
+	LastSeen      string `json:"lastSeen,omitempty"`
+	LastError     string `json:"lastError,omitempty"`
+	LatencyMicros int64  `json:"latencyMicros,omitempty"`
+}
+
+func (cmd *statusCommand) Run() error {
+	req := &wire.PeerStatusRequest{
+		Ping: cmd.Config.Ping,
+	}
+	if cmd.Arguments.PubKey != (peer.PublicKey{}) {
+		req.Pub = cmd.Arguments.PubKey[:]
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerStatus(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	list := make([]statusJSON, 0, len(resp.Peers))
+	for _, p := range resp.Peers {
+		var pub peer.PublicKey
+		if err := pub.UnmarshalBinary(p.Pub); err != nil {
+			return err
+		}
+		item := statusJSON{
+			Pub:           pub.String(),
+			Connected:     p.Connected,
+			State:         p.State,
+			InUse:         p.InUse,
+			LastError:     p.LastError,
+			LatencyMicros: p.LatencyMicros,
+		}
+		if p.LastSeen != 0 {
+			item.LastSeen = time.Unix(p.LastSeen, 0).Format(time.RFC3339)
+		}
+		list = append(list, item)
+	}
+
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "PUB\tSTATE\tIN USE\tLATENCY\tLAST SEEN\tERROR\n")
+	for _, item := range list {
+		state := item.State
+		if !item.Connected {
+			state = "-"
+		}
+		latency := "-"
+		if item.LatencyMicros != 0 {
+			latency = (time.Duration(item.LatencyMicros) * time.Microsecond).String()
+		}
+		lastSeen := item.LastSeen
+		if lastSeen == "" {
+			lastSeen = "never"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", item.Pub, state, item.InUse,
+			latency, lastSeen, item.LastError)
+	}
+	return w.Flush()
+}
+
+var status = statusCommand{
+	Description: "show connection health of peers",
+	Overview: `
+Show the state of connections to peers, as seen by recent calls.
+Use -ping to check that the peers can be reached right now.
+`,
+}
+
+func init() {
+	status.BoolVar(&status.Config.JSON, "json", false, "output JSON")
+	status.BoolVar(&status.Config.Ping, "ping", false, "ping peers before reporting")
+	subcommands.Register(&status)
+}
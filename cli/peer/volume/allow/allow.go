@@ -2,8 +2,10 @@ package allow
 
 import (
 	"context"
+	"flag"
 
 	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/flagx"
 	"bazil.org/bazil/cliutil/subcommands"
 	"bazil.org/bazil/peer"
 	"bazil.org/bazil/server/control/wire"
@@ -11,6 +13,12 @@ import (
 
 type allowCommand struct {
 	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		Paths    flagx.Strings
+		ReadOnly bool
+	}
 	Arguments struct {
 		PubKey     peer.PublicKey
 		VolumeName string
@@ -21,6 +29,8 @@ func (cmd *allowCommand) Run() error {
 	req := &wire.PeerVolumeAllowRequest{
 		Pub:        cmd.Arguments.PubKey[:],
 		VolumeName: cmd.Arguments.VolumeName,
+		Paths:      cmd.Config.Paths,
+		ReadOnly:   cmd.Config.ReadOnly,
 	}
 	ctx := context.Background()
 	client, err := clibazil.Bazil.Control()
@@ -36,8 +46,21 @@ func (cmd *allowCommand) Run() error {
 
 var allow = allowCommand{
 	Description: "allow a peer to use a volume",
+	Overview: `
+
+Without -path, the peer may sync the whole volume. Each -path limits
+the peer to that directory and everything below it, relative to the
+volume root.
+
+With -read-only, changes are never merged when syncing from the peer.
+
+Allowing a volume again replaces the earlier limits.
+
+`,
 }
 
 func init() {
+	allow.Var(&allow.Config.Paths, "path", "directory the peer may sync (may be repeated)")
+	allow.BoolVar(&allow.Config.ReadOnly, "read-only", false, "never merge changes from the peer")
 	subcommands.Register(&allow)
 }
@@ -1,16 +1,21 @@
 package run
 
 import (
+	"errors"
 	"flag"
 	"log"
 	"net"
+	"os/user"
+	"strconv"
 	"sync"
+	"time"
 
 	clibazil "bazil.org/bazil/cli"
 	"bazil.org/bazil/cliutil/flagx"
 	"bazil.org/bazil/cliutil/subcommands"
 	"bazil.org/bazil/server"
 	"bazil.org/bazil/server/control"
+	"bazil.org/bazil/server/discovery"
 	"bazil.org/bazil/server/http"
 	"bazil.org/bazil/tokens"
 	"bazil.org/bazil/util/trylisten"
@@ -28,28 +33,121 @@ func (a *tcpAddr) Set(value string) error {
 	return nil
 }
 
+type group struct {
+	gid uint32
+	set bool
+}
+
+var _ flag.Value = (*group)(nil)
+
+func (g *group) String() string {
+	if !g.set {
+		return ""
+	}
+	return strconv.FormatUint(uint64(g.gid), 10)
+}
+
+// Set accepts a group name or a numeric group ID.
+func (g *group) Set(value string) error {
+	grp, err := user.LookupGroup(value)
+	if err != nil {
+		grp, err = user.LookupGroupId(value)
+		if err != nil {
+			return err
+		}
+	}
+	gid, err := strconv.ParseUint(grp.Gid, 10, 32)
+	if err != nil {
+		return err
+	}
+	g.gid = uint32(gid)
+	g.set = true
+	return nil
+}
+
 type runCommand struct {
 	subcommands.Description
 	flag.FlagSet
 	Config struct {
-		Addr    tcpAddr
-		AnyPort bool
+		Addr           tcpAddr
+		AnyPort        bool
+		Discovery      bool
+		DiscoveryIface string
+		ScrubInterval  time.Duration
+		PeerLimits     server.PeerLimits
+		AdminGroup     group
+		MetricsAddr    flagx.TCPAddr
 	}
 }
 
 func (cmd *runCommand) Run() error {
-	var options []server.AppOption
+	options := []server.AppOption{
+		server.LimitPeers(cmd.Config.PeerLimits),
+	}
 	if clibazil.Bazil.Config.Debug {
 		options = append(options, server.Debug(clibazil.Bazil.Log.Event))
 	}
+	if cmd.Config.AdminGroup.set {
+		options = append(options, server.ControlAdminGroup(cmd.Config.AdminGroup.gid))
+	}
 	app, err := server.New(clibazil.Bazil.Config.DataDir.String(), options...)
 	if err != nil {
 		return err
 	}
 	defer app.Close()
 
+	if err := app.ResumeSharingKeyRotations(); err != nil {
+		return err
+	}
+	if cmd.Config.ScrubInterval > 0 {
+		app.StartScrubber(cmd.Config.ScrubInterval)
+	}
+
+	w, services, err := cmd.setup(app)
+	if err != nil {
+		return err
+	}
+
 	errCh := make(chan error)
 	var wg sync.WaitGroup
+	for _, s := range services {
+		wg.Add(1)
+		go func(s service) {
+			defer wg.Done()
+			defer s.Close()
+			errCh <- s.Serve()
+		}(s)
+	}
+
+	log.Printf("Listening on %s", w.Addr())
+
+	wg.Wait()
+	// We only care about the first error; the rest are likely to be
+	// about closed listeners.
+	return <-errCh
+}
+
+type service interface {
+	Serve() error
+	Close()
+}
+
+// setup creates everything the server serves, without serving yet.
+// On error, whatever was created is closed again.
+func (cmd *runCommand) setup(app *server.App) (_ *http.Web, services []service, err error) {
+	defer func() {
+		if err != nil {
+			for _, s := range services {
+				s.Close()
+			}
+		}
+	}()
+
+	metricsAddr := cmd.Config.MetricsAddr.Addr
+	// the metrics are not authenticated
+	if metricsAddr != nil && !metricsAddr.IP.IsLoopback() {
+		return nil, nil, errors.New("-metrics-addr must be a loopback address")
+	}
 
 	listenTCP := net.ListenTCP
 	if cmd.Config.AnyPort {
@@ -57,37 +155,53 @@ func (cmd *runCommand) Run() error {
 	}
 	l, err := listenTCP("tcp", cmd.Config.Addr.Addr)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-
 	w, err := http.New(app, l)
 	if err != nil {
-		return err
+		_ = l.Close()
+		return nil, nil, err
 	}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer w.Close()
-		errCh <- w.Serve()
-	}()
+	services = append(services, w)
 
 	c, err := control.New(app)
 	if err != nil {
-		return err
+		return nil, services, err
 	}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer c.Close()
-		errCh <- c.Serve()
-	}()
+	services = append(services, c)
 
-	log.Printf("Listening on %s", w.Addr())
+	if metricsAddr != nil {
+		ml, err := net.ListenTCP("tcp", metricsAddr)
+		if err != nil {
+			return nil, services, err
+		}
+		m, err := http.NewMetrics(app, ml)
+		if err != nil {
+			_ = ml.Close()
+			return nil, services, err
+		}
+		services = append(services, m)
+		log.Printf("Serving metrics on http://%s/metrics", m.Addr())
+	}
 
-	wg.Wait()
-	// We only care about the first error; the rest are likely to be
-	// about closed listeners.
-	return <-errCh
+	if cmd.Config.Discovery {
+		conf := &discovery.Config{}
+		if cmd.Config.DiscoveryIface != "" {
+			ifi, err := net.InterfaceByName(cmd.Config.DiscoveryIface)
+			if err != nil {
+				return nil, services, err
+			}
+			conf.Interface = ifi
+		}
+		port := w.Addr().(*net.TCPAddr).Port
+		d, err := discovery.New(app, port, conf)
+		if err != nil {
+			return nil, services, err
+		}
+		services = append(services, d)
+	}
+
+	return w, services, nil
 }
 
 var run = runCommand{
@@ -100,5 +214,16 @@ func init() {
 	}
 	run.Var(&run.Config.Addr, "addr", "TCP address to listen on, also sets -any-port=false")
 	run.BoolVar(&run.Config.AnyPort, "any-port", true, "find a free port if port was taken")
+	run.BoolVar(&run.Config.Discovery, "discovery", false, "announce on and discover peers from the local network")
+	run.StringVar(&run.Config.DiscoveryIface, "discovery-iface", "", "network interface to use for discovery (default system choice)")
+	run.DurationVar(&run.Config.ScrubInterval, "scrub-interval", 0, "verify and repair stored content this often (default never)")
+	run.Var(&run.Config.AdminGroup, "admin-group", "also allow members of this group to use the control socket; makes the data directory searchable by the group")
+	run.Var(&run.Config.MetricsAddr, "metrics-addr", "serve Prometheus metrics on this loopback TCP address, like localhost:9100 (default off)")
+	limits := &run.Config.PeerLimits
+	*limits = server.DefaultPeerLimits
+	run.Int64Var(&limits.MaxObjectSize, "peer-max-object-size", limits.MaxObjectSize, "largest object a peer may store, in bytes (0 for no limit)")
+	run.IntVar(&limits.MaxStreams, "peer-max-streams", limits.MaxStreams, "streaming calls a peer may have open at once (0 for no limit)")
+	run.Float64Var(&limits.Rate, "peer-rate", limits.Rate, "calls per second allowed per peer (0 for no limit)")
+	run.IntVar(&limits.Burst, "peer-burst", limits.Burst, "calls a peer may make in a burst above -peer-rate (at least 1 if the rate is limited)")
 	subcommands.Register(&run)
 }
@@ -2,11 +2,15 @@ package add
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"flag"
+	"fmt"
 	"io/ioutil"
 	"os"
 
 	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/passphrase"
 	"bazil.org/bazil/cliutil/subcommands"
 	"bazil.org/bazil/server/control/wire"
 	"golang.org/x/crypto/ssh/terminal"
@@ -15,41 +19,98 @@ import (
 type addCommand struct {
 	subcommands.Description
 	subcommands.Synopsis
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		Passphrase bool
+		Salt       string
+		LogN       uint
+		Padding    string
+	}
 	Arguments struct {
 		Name string
 	}
 }
 
 func (cmd *addCommand) Run() error {
-	if terminal.IsTerminal(int(os.Stdin.Fd())) {
-		return errors.New("refusing to read secret from a terminal")
+	req := &wire.SharingKeyAddRequest{
+		Name:    cmd.Arguments.Name,
+		Padding: cmd.Config.Padding,
 	}
-	secret, err := ioutil.ReadAll(os.Stdin)
-	if err != nil {
-		return err
+	if cmd.Config.Passphrase {
+		if cmd.Config.Salt != "" || cmd.Config.LogN != 0 {
+			salt, err := hex.DecodeString(cmd.Config.Salt)
+			if err != nil {
+				return fmt.Errorf("bad salt: %v", err)
+			}
+			if len(salt) == 0 {
+				return errors.New("-logn requires -salt")
+			}
+			req.Kdf = &wire.SharingKeyKDF{
+				Salt: salt,
+				LogN: uint32(cmd.Config.LogN),
+			}
+		}
+		pass, err := passphrase.Read("Passphrase", req.Kdf == nil)
+		if err != nil {
+			return err
+		}
+		req.Passphrase = pass
+	} else {
+		if cmd.Config.Salt != "" || cmd.Config.LogN != 0 {
+			return errors.New("-salt and -logn require -passphrase")
+		}
+		if terminal.IsTerminal(int(os.Stdin.Fd())) {
+			return errors.New("refusing to read secret from a terminal")
+		}
+		secret, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		req.Secret = secret
 	}
 
-	req := &wire.SharingKeyAddRequest{
-		Name:   cmd.Arguments.Name,
-		Secret: secret,
-	}
 	ctx := context.Background()
 	client, err := clibazil.Bazil.Control()
 	if err != nil {
 		return err
 	}
-	if _, err := client.SharingKeyAdd(ctx, req); err != nil {
+	resp, err := client.SharingKeyAdd(ctx, req)
+	if err != nil {
 		// TODO unwrap error
 		return err
 	}
+	if kdf := resp.Kdf; kdf != nil && req.Kdf == nil {
+		fmt.Printf("To derive the same sharing key elsewhere, run:\n  bazil sharing add -passphrase -salt=%x -logn=%d %s\n",
+			kdf.Salt, kdf.LogN, req.Name)
+	}
 	return nil
 }
 
 var add = addCommand{
 	Description: "add a new sharing key",
-	Synopsis:    "NAME <SECRET_FILE",
+	Synopsis:    "[OPT..] NAME <SECRET_FILE",
+	Overview: `
+The 32-byte secret is read from standard input.
+
+With -passphrase, the secret is instead derived from a passphrase.
+To derive the same secret on multiple servers, give -salt and -logn
+as printed when the sharing key was first added.
+
+With -padding, data encrypted with the sharing key is padded to hide
+its exact size from the storage. Modes are pow2 (round up to a power
+of two) and padme (at most 12% overhead). The padding mode is stored
+with the data, so servers sharing the key can read it whatever their
+own padding, but they should use the same padding to not reveal the
+exact size through the other servers. Padding can only be changed by
+rotating the sharing key.
+`,
 }
 
 func init() {
+	add.BoolVar(&add.Config.Passphrase, "passphrase", false, "derive the secret from a passphrase")
+	add.StringVar(&add.Config.Salt, "salt", "", "hex salt for deriving from passphrase (default random)")
+	add.UintVar(&add.Config.LogN, "logn", 0, "scrypt cost, as base 2 logarithm (default 16)")
+	add.StringVar(&add.Config.Padding, "padding", "", "pad data to hide its size: none, pow2 or padme")
 	subcommands.Register(&add)
 }
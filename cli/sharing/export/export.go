@@ -0,0 +1,59 @@
+package export
+
+import (
+	"context"
+	"os"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/passphrase"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type exportCommand struct {
+	subcommands.Description
+	subcommands.Synopsis
+	subcommands.Overview
+	Arguments struct {
+		Name string
+	}
+}
+
+func (cmd *exportCommand) Run() error {
+	pass, err := passphrase.Read("Passphrase to encrypt with", true)
+	if err != nil {
+		return err
+	}
+	req := &wire.SharingKeyExportRequest{
+		Name:       cmd.Arguments.Name,
+		Passphrase: pass,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.SharingKeyExport(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	if _, err := os.Stdout.Write(resp.Armored); err != nil {
+		return err
+	}
+	return nil
+}
+
+var export = exportCommand{
+	Description: "export a passphrase-encrypted sharing key",
+	Synopsis:    "NAME >FILE",
+	Overview: `
+Writes the sharing key to standard output as armored text, encrypted
+with a passphrase. Use "bazil sharing import" to add it to another
+server.
+`,
+}
+
+func init() {
+	subcommands.Register(&export)
+}
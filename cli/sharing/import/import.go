@@ -0,0 +1,66 @@
+package import_
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/passphrase"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type importCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		Name string
+	}
+	Arguments struct {
+		File string
+	}
+}
+
+func (cmd *importCommand) Run() error {
+	armored, err := ioutil.ReadFile(cmd.Arguments.File)
+	if err != nil {
+		return err
+	}
+	pass, err := passphrase.Read("Passphrase", false)
+	if err != nil {
+		return err
+	}
+	req := &wire.SharingKeyImportRequest{
+		Armored:    armored,
+		Passphrase: pass,
+		Name:       cmd.Config.Name,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.SharingKeyImport(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	fmt.Println(resp.Name)
+	return nil
+}
+
+var import_ = importCommand{
+	Description: "import a sharing key exported elsewhere",
+	Overview: `
+Adds a sharing key from FILE, as written by "bazil sharing export".
+The passphrase is read from standard input.
+`,
+}
+
+func init() {
+	import_.StringVar(&import_.Config.Name, "name", "", "store sharing key under this name (default name from FILE)")
+	subcommands.Register(&import_)
+}
@@ -0,0 +1,88 @@
+package list
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type listCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		JSON bool
+	}
+}
+
+type kdfJSON struct {
+	Salt string `json:"salt"`
+	LogN uint32 `json:"logN"`
+	R    uint32 `json:"r"`
+	P    uint32 `json:"p"`
+}
+
+type sharingKeyJSON struct {
+	Name    string   `json:"name"`
+	KDF     *kdfJSON `json:"kdf,omitempty"`
+	Padding string   `json:"padding,omitempty"`
+}
+
+func (cmd *listCommand) Run() error {
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.SharingKeyList(ctx, &wire.SharingKeyListRequest{})
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	if cmd.Config.JSON {
+		list := make([]sharingKeyJSON, 0, len(resp.SharingKeys))
+		for _, k := range resp.SharingKeys {
+			j := sharingKeyJSON{Name: k.Name, Padding: k.Padding}
+			if k.Kdf != nil {
+				j.KDF = &kdfJSON{
+					Salt: hex.EncodeToString(k.Kdf.Salt),
+					LogN: k.Kdf.LogN,
+					R:    k.Kdf.R,
+					P:    k.Kdf.P,
+				}
+			}
+			list = append(list, j)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	for _, k := range resp.SharingKeys {
+		line := k.Name
+		if k.Kdf != nil {
+			line += fmt.Sprintf("\tpassphrase salt=%x logn=%d", k.Kdf.Salt, k.Kdf.LogN)
+		}
+		if k.Padding != "" {
+			line += "\tpadding=" + k.Padding
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+var list = listCommand{
+	Description: "list sharing keys",
+}
+
+func init() {
+	list.BoolVar(&list.Config.JSON, "json", false, "output JSON")
+	subcommands.Register(&list)
+}
@@ -0,0 +1,85 @@
+package rotate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type rotateCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		Status  bool
+		Padding string
+	}
+	Arguments struct {
+		Name string
+	}
+}
+
+func (cmd *rotateCommand) Run() error {
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+
+	if cmd.Config.Status {
+		req := &wire.SharingKeyRotationRequest{
+			Name: cmd.Arguments.Name,
+		}
+		resp, err := client.SharingKeyRotation(ctx, req)
+		if err != nil {
+			// TODO unwrap error
+			return err
+		}
+		state := "in progress"
+		if resp.Done {
+			state = "done"
+		}
+		fmt.Printf("%s -> %s: %s, %d/%d volumes, %d chunks re-encrypted\n",
+			cmd.Arguments.Name, resp.NewName, state,
+			resp.VolumesDone, resp.Volumes, resp.Chunks)
+		return nil
+	}
+
+	req := &wire.SharingKeyRotateRequest{
+		Name:    cmd.Arguments.Name,
+		Padding: cmd.Config.Padding,
+	}
+	resp, err := client.SharingKeyRotate(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	fmt.Println(resp.NewName)
+	return nil
+}
+
+var rotate = rotateCommand{
+	Description: "replace a sharing key with a new generation",
+	Overview: `
+Creates a new sharing key, switches all volume storage using NAME to
+it, and re-encrypts existing chunks with it in the background. Chunks
+not yet re-encrypted remain readable meanwhile. The name of the new
+sharing key is printed.
+
+Volumes using the sharing key must not be mounted or otherwise in use
+when the rotation starts. Use -status to see the progress.
+
+The new sharing key keeps the padding of the old one, unless -padding
+is given; rotating is how existing data is re-padded.
+`,
+}
+
+func init() {
+	rotate.BoolVar(&rotate.Config.Status, "status", false, "show progress of an earlier rotation instead")
+	rotate.StringVar(&rotate.Config.Padding, "padding", "", "padding mode for the new sharing key: none, pow2 or padme (default unchanged)")
+	subcommands.Register(&rotate)
+}
@@ -0,0 +1,92 @@
+package scrub
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"bazil.org/bazil/cas"
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/positional"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type scrubCommand struct {
+	subcommands.Description
+	subcommands.Overview
+	flag.FlagSet
+	Config struct {
+		Repair bool
+	}
+	Arguments struct {
+		positional.Optional
+		VolumeName string
+	}
+}
+
+var errProblems = errors.New("scrub found unrepaired problems")
+
+func (cmd *scrubCommand) Run() error {
+	req := &wire.StorageScrubRequest{
+		VolumeName: cmd.Arguments.VolumeName,
+		Repair:     cmd.Config.Repair,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.StorageScrub(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	unrepaired := false
+	for _, p := range resp.Problems {
+		var key cas.Key
+		if err := key.UnmarshalBinary(p.Key); err != nil {
+			return err
+		}
+		storage := p.Storage
+		if storage == "" {
+			storage = "(all)"
+		}
+		state := "bad"
+		if p.Repaired {
+			state = "repaired"
+		} else {
+			unrepaired = true
+		}
+		fmt.Printf("%s\t%s\t%s\t%v\t%s\n", state, p.VolumeName, storage, key, p.Error)
+		for _, path := range p.Paths {
+			fmt.Printf("\t%s\n", path)
+		}
+	}
+	fmt.Printf("%d chunks checked, %d problems\n", resp.Chunks, len(resp.Problems))
+	if unrepaired {
+		return errProblems
+	}
+	return nil
+}
+
+var scrub = scrubCommand{
+	Description: "verify stored content",
+	Overview: `
+
+Read every chunk reachable from the volume, or all volumes if
+VOLUMENAME is not given, from each storage of the volume, and
+verify the content matches its key.
+
+With -repair, chunks that are missing or corrupt in some storage
+are rewritten from an intact copy in another storage.
+
+`,
+}
+
+func init() {
+	scrub.BoolVar(&scrub.Config.Repair, "repair", false, "rewrite bad chunks from an intact copy")
+	subcommands.Register(&scrub)
+}
@@ -0,0 +1,40 @@
+package delete
+
+import (
+	"context"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type deleteCommand struct {
+	subcommands.Description
+	Arguments struct {
+		VolumeName string
+	}
+}
+
+func (cmd *deleteCommand) Run() error {
+	req := &wire.VolumeDeleteRequest{
+		VolumeName: cmd.Arguments.VolumeName,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	if _, err := client.VolumeDelete(ctx, req); err != nil {
+		// TODO unwrap error
+		return err
+	}
+	return nil
+}
+
+var del = deleteCommand{
+	Description: "delete a volume from this node",
+}
+
+func init() {
+	subcommands.Register(&del)
+}
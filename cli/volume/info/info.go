@@ -0,0 +1,113 @@
+package info
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type infoCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		JSON bool
+	}
+	Arguments struct {
+		VolumeName string
+	}
+}
+
+type storageJSON struct {
+	Name       string `json:"name"`
+	Backend    string `json:"backend"`
+	SharingKey string `json:"sharingKey"`
+}
+
+type volumeJSON struct {
+	Name    string        `json:"name"`
+	ID      string        `json:"id"`
+	Storage []storageJSON `json:"storage"`
+	Peers   []string      `json:"peers"`
+}
+
+func (cmd *infoCommand) Run() error {
+	req := &wire.VolumeGetRequest{
+		VolumeName: cmd.Arguments.VolumeName,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.VolumeGet(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	var volID db.VolumeID
+	if err := volID.UnmarshalBinary(resp.Volume.VolumeID); err != nil {
+		return err
+	}
+	info := volumeJSON{
+		Name:    resp.Volume.VolumeName,
+		ID:      volID.String(),
+		Storage: []storageJSON{},
+		Peers:   []string{},
+	}
+	for _, s := range resp.Volume.Storage {
+		info.Storage = append(info.Storage, storageJSON{
+			Name:       s.Name,
+			Backend:    s.Backend,
+			SharingKey: s.SharingKeyName,
+		})
+	}
+	for _, buf := range resp.Volume.Peers {
+		var pub peer.PublicKey
+		if err := pub.UnmarshalBinary(buf); err != nil {
+			return err
+		}
+		info.Peers = append(info.Peers, pub.String())
+	}
+
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	fmt.Printf("Name: %s\n", info.Name)
+	fmt.Printf("ID: %s\n", info.ID)
+	fmt.Printf("\nStorage:\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  NAME\tBACKEND\tSHARING\n")
+	for _, s := range info.Storage {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", s.Name, s.Backend, s.SharingKey)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nPeers:\n")
+	for _, pub := range info.Peers {
+		fmt.Printf("  %s\n", pub)
+	}
+	return nil
+}
+
+var info = infoCommand{
+	Description: "show details of a volume",
+}
+
+func init() {
+	info.BoolVar(&info.Config.JSON, "json", false, "output JSON")
+	subcommands.Register(&info)
+}
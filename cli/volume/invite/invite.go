@@ -0,0 +1,59 @@
+package invite
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type inviteCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		Backend string
+		Valid   time.Duration
+	}
+	Arguments struct {
+		VolumeName string
+		PubKey     peer.PublicKey
+		Locations  []string `positional:"metavar=HOST:PORT"`
+	}
+}
+
+func (cmd *inviteCommand) Run() error {
+	req := &wire.VolumeInviteRequest{
+		VolumeName:   cmd.Arguments.VolumeName,
+		Pub:          cmd.Arguments.PubKey[:],
+		Locations:    cmd.Arguments.Locations,
+		Backend:      cmd.Config.Backend,
+		ValidSeconds: int64(cmd.Config.Valid / time.Second),
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.VolumeInvite(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	fmt.Println(resp.Token)
+	return nil
+}
+
+var invite = inviteCommand{
+	Description: "invite a peer to a volume, printing a token for volume join",
+}
+
+func init() {
+	invite.StringVar(&invite.Config.Backend, "backend", "local", "storage backend to offer to the peer, empty for none")
+	invite.DurationVar(&invite.Config.Valid, "valid", 24*time.Hour, "how long the invitation can be used for")
+	subcommands.Register(&invite)
+}
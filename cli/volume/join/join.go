@@ -0,0 +1,56 @@
+package join
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type joinCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		Name    string
+		Backend string
+		Sharing string
+	}
+	Arguments struct {
+		Token string
+	}
+}
+
+func (cmd *joinCommand) Run() error {
+	req := &wire.VolumeJoinRequest{
+		Token:           cmd.Arguments.Token,
+		LocalVolumeName: cmd.Config.Name,
+		Backend:         cmd.Config.Backend,
+		SharingKeyName:  cmd.Config.Sharing,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.VolumeJoin(ctx, req)
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+	fmt.Printf("Joined volume %s (sharing key %s)\n", resp.VolumeName, resp.SharingKeyName)
+	return nil
+}
+
+var join = joinCommand{
+	Description: "join a volume using a token from volume invite",
+}
+
+func init() {
+	join.StringVar(&join.Config.Name, "name", "", "local name for the volume (default same as inviter)")
+	join.StringVar(&join.Config.Backend, "backend", "local", "storage backend to use")
+	join.StringVar(&join.Config.Sharing, "sharing", "", "local name for the sharing key (default based on inviter)")
+	subcommands.Register(&join)
+}
@@ -0,0 +1,84 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type listCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		JSON bool
+	}
+}
+
+type volumeJSON struct {
+	Name    string   `json:"name"`
+	ID      string   `json:"id"`
+	Storage []string `json:"storage"`
+	Peers   int      `json:"peers"`
+}
+
+func (cmd *listCommand) Run() error {
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.VolumeList(ctx, &wire.VolumeListRequest{})
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	list := make([]volumeJSON, 0, len(resp.Volumes))
+	for _, v := range resp.Volumes {
+		var volID db.VolumeID
+		if err := volID.UnmarshalBinary(v.VolumeID); err != nil {
+			return err
+		}
+		item := volumeJSON{
+			Name:    v.VolumeName,
+			ID:      volID.String(),
+			Storage: []string{},
+			Peers:   len(v.Peers),
+		}
+		for _, s := range v.Storage {
+			item.Storage = append(item.Storage, s.Name)
+		}
+		list = append(list, item)
+	}
+
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\tSTORAGE\tPEERS\n")
+	for _, item := range list {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", item.Name, strings.Join(item.Storage, ","), item.Peers)
+	}
+	return w.Flush()
+}
+
+var list = listCommand{
+	Description: "list volumes",
+}
+
+func init() {
+	list.BoolVar(&list.Config.JSON, "json", false, "output JSON")
+	subcommands.Register(&list)
+}
@@ -0,0 +1,77 @@
+package mounts
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type mountsCommand struct {
+	subcommands.Description
+	flag.FlagSet
+	Config struct {
+		JSON bool
+	}
+}
+
+type mountJSON struct {
+	Name       string `json:"name"`
+	ID         string `json:"id"`
+	Mountpoint string `json:"mountpoint"`
+}
+
+func (cmd *mountsCommand) Run() error {
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	resp, err := client.MountList(ctx, &wire.MountListRequest{})
+	if err != nil {
+		// TODO unwrap error
+		return err
+	}
+
+	list := make([]mountJSON, 0, len(resp.Mounts))
+	for _, m := range resp.Mounts {
+		var volID db.VolumeID
+		if err := volID.UnmarshalBinary(m.VolumeID); err != nil {
+			return err
+		}
+		list = append(list, mountJSON{
+			Name:       m.VolumeName,
+			ID:         volID.String(),
+			Mountpoint: m.Mountpoint,
+		})
+	}
+
+	if cmd.Config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\tMOUNTPOINT\n")
+	for _, item := range list {
+		fmt.Fprintf(w, "%s\t%s\n", item.Name, item.Mountpoint)
+	}
+	return w.Flush()
+}
+
+var mounts = mountsCommand{
+	Description: "list mounted volumes",
+}
+
+func init() {
+	mounts.BoolVar(&mounts.Config.JSON, "json", false, "output JSON")
+	subcommands.Register(&mounts)
+}
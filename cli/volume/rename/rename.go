@@ -0,0 +1,42 @@
+package rename
+
+import (
+	"context"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type renameCommand struct {
+	subcommands.Description
+	Arguments struct {
+		VolumeName string
+		NewName    string
+	}
+}
+
+func (cmd *renameCommand) Run() error {
+	req := &wire.VolumeRenameRequest{
+		VolumeName: cmd.Arguments.VolumeName,
+		NewName:    cmd.Arguments.NewName,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	if _, err := client.VolumeRename(ctx, req); err != nil {
+		// TODO unwrap error
+		return err
+	}
+	return nil
+}
+
+var rename = renameCommand{
+	Description: "change the local name of a volume",
+}
+
+func init() {
+	subcommands.Register(&rename)
+}
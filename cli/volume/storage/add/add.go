@@ -57,6 +57,14 @@ If STORAGE is not given, it gets the same value as NAME.
 Supported STORAGE values:
   local
   ABSOLUTE_PATH
+  peerkey:PUBKEY
+  pack:ABSOLUTE_PATH
+  ec:DATA,PARITY:STORAGE,STORAGE,...
+
+With ec, content is erasure coded into DATA+PARITY shards, one per
+listed STORAGE, and can be read as long as any DATA of them are
+available. Writes fail unless at least DATA+1 shards are stored
+(all of them, if PARITY is 0).
 
 `,
 }
@@ -0,0 +1,40 @@
+package unmount
+
+import (
+	"context"
+
+	clibazil "bazil.org/bazil/cli"
+	"bazil.org/bazil/cliutil/subcommands"
+	"bazil.org/bazil/server/control/wire"
+)
+
+type unmountCommand struct {
+	subcommands.Description
+	Arguments struct {
+		VolumeName string
+	}
+}
+
+func (cmd *unmountCommand) Run() error {
+	req := &wire.VolumeUnmountRequest{
+		VolumeName: cmd.Arguments.VolumeName,
+	}
+	ctx := context.Background()
+	client, err := clibazil.Bazil.Control()
+	if err != nil {
+		return err
+	}
+	if _, err := client.VolumeUnmount(ctx, req); err != nil {
+		// TODO unwrap error
+		return err
+	}
+	return nil
+}
+
+var unmount = unmountCommand{
+	Description: "flush and unmount a volume",
+}
+
+func init() {
+	subcommands.Register(&unmount)
+}
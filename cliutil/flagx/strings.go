@@ -0,0 +1,21 @@
+package flagx
+
+import (
+	"flag"
+	"strings"
+)
+
+// Strings is a flag.Value that collects the values of a flag given
+// multiple times.
+type Strings []string
+
+var _ flag.Value = (*Strings)(nil)
+
+func (s Strings) String() string {
+	return strings.Join(s, ",")
+}
+
+func (s *Strings) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -0,0 +1,23 @@
+package flagx_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"bazil.org/bazil/cliutil/flagx"
+)
+
+func TestStrings(t *testing.T) {
+	var s flagx.Strings
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&s, "path", "")
+	if err := fs.Parse([]string{"-path=a", "-path", "b/c"}); err != nil {
+		t.Fatal(err)
+	}
+	if g, e := []string(s), []string{"a", "b/c"}; !reflect.DeepEqual(g, e) {
+		t.Errorf("wrong values: %q != %q", g, e)
+	}
+}
@@ -0,0 +1,66 @@
+// Package passphrase reads passphrases for command line tools.
+package passphrase
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	ErrEmpty    = errors.New("empty passphrase")
+	ErrMismatch = errors.New("passphrases do not match")
+)
+
+// Read reads a passphrase from standard input.
+//
+// If standard input is a terminal, the user is prompted without
+// echoing the input. If confirm is true, the passphrase is asked
+// twice. Otherwise, the first line of standard input is used.
+func Read(prompt string, confirm bool) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return readLine(os.Stdin)
+	}
+
+	ask := func(prompt string) ([]byte, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		defer fmt.Fprintln(os.Stderr)
+		return terminal.ReadPassword(fd)
+	}
+	pass, err := ask(prompt + ": ")
+	if err != nil {
+		return nil, err
+	}
+	if len(pass) == 0 {
+		return nil, ErrEmpty
+	}
+	if confirm {
+		again, err := ask(prompt + " (again): ")
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(pass, again) {
+			return nil, ErrMismatch
+		}
+	}
+	return pass, nil
+}
+
+func readLine(r io.Reader) ([]byte, error) {
+	line, err := bufio.NewReader(r).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(line) == 0 {
+		return nil, ErrEmpty
+	}
+	return line, nil
+}
@@ -0,0 +1,34 @@
+package passphrase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadLine(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"hunter2\n", "hunter2"},
+		{"hunter2\r\nignored\n", "hunter2"},
+		{"no newline", "no newline"},
+		{"  spaces kept  \n", "  spaces kept  "},
+	} {
+		got, err := readLine(strings.NewReader(tc.in))
+		if err != nil {
+			t.Errorf("readLine(%q): %v", tc.in, err)
+			continue
+		}
+		if g, e := string(got), tc.want; g != e {
+			t.Errorf("readLine(%q) = %q, want %q", tc.in, g, e)
+		}
+	}
+}
+
+func TestReadLineEmpty(t *testing.T) {
+	for _, in := range []string{"", "\n"} {
+		if _, err := readLine(strings.NewReader(in)); err != ErrEmpty {
+			t.Errorf("readLine(%q): expected ErrEmpty, got %v", in, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	_ "bazil.org/bazil/cli"
+	_ "bazil.org/bazil/cli/audit/tail"
 	_ "bazil.org/bazil/cli/create"
 	_ "bazil.org/bazil/cli/debug/cas"
 	_ "bazil.org/bazil/cli/debug/cas/chunk/add"
@@ -10,18 +11,44 @@ import (
 	_ "bazil.org/bazil/cli/debug/hash"
 	_ "bazil.org/bazil/cli/debug/peer/ping"
 	_ "bazil.org/bazil/cli/debug/pubkey"
+	_ "bazil.org/bazil/cli/identity/export"
+	_ "bazil.org/bazil/cli/identity/import"
 	_ "bazil.org/bazil/cli/peer/add"
+	_ "bazil.org/bazil/cli/peer/approve"
+	_ "bazil.org/bazil/cli/peer/info"
+	_ "bazil.org/bazil/cli/peer/join"
+	_ "bazil.org/bazil/cli/peer/list"
+	_ "bazil.org/bazil/cli/peer/location/add"
+	_ "bazil.org/bazil/cli/peer/location/list"
+	_ "bazil.org/bazil/cli/peer/location/remove"
 	_ "bazil.org/bazil/cli/peer/location/set"
+	_ "bazil.org/bazil/cli/peer/pair"
+	_ "bazil.org/bazil/cli/peer/pending"
+	_ "bazil.org/bazil/cli/peer/remove"
+	_ "bazil.org/bazil/cli/peer/status"
 	_ "bazil.org/bazil/cli/peer/storage/allow"
 	_ "bazil.org/bazil/cli/peer/volume/allow"
 	_ "bazil.org/bazil/cli/pubkey"
 	_ "bazil.org/bazil/cli/server/ping"
 	_ "bazil.org/bazil/cli/server/run"
 	_ "bazil.org/bazil/cli/sharing/add"
+	_ "bazil.org/bazil/cli/sharing/export"
+	_ "bazil.org/bazil/cli/sharing/import"
+	_ "bazil.org/bazil/cli/sharing/list"
+	_ "bazil.org/bazil/cli/sharing/rotate"
+	_ "bazil.org/bazil/cli/storage/scrub"
 	_ "bazil.org/bazil/cli/version"
 	_ "bazil.org/bazil/cli/volume/connect"
 	_ "bazil.org/bazil/cli/volume/create"
+	_ "bazil.org/bazil/cli/volume/delete"
+	_ "bazil.org/bazil/cli/volume/info"
+	_ "bazil.org/bazil/cli/volume/invite"
+	_ "bazil.org/bazil/cli/volume/join"
+	_ "bazil.org/bazil/cli/volume/list"
 	_ "bazil.org/bazil/cli/volume/mount"
+	_ "bazil.org/bazil/cli/volume/mounts"
+	_ "bazil.org/bazil/cli/volume/rename"
 	_ "bazil.org/bazil/cli/volume/storage/add"
 	_ "bazil.org/bazil/cli/volume/sync"
+	_ "bazil.org/bazil/cli/volume/unmount"
 )
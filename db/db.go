@@ -38,6 +38,9 @@ func (db *DB) init(tx *Tx) error {
 	if err := tx.initSharingKeys(); err != nil {
 		return err
 	}
+	if err := tx.initSharingKeyRotations(); err != nil {
+		return err
+	}
 	return nil
 }
 
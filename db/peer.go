@@ -1,29 +1,41 @@
 package db
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
+	"path"
+	"sort"
+	"strings"
 
+	"bazil.org/bazil/db/wire"
 	"bazil.org/bazil/kv"
 	"bazil.org/bazil/kv/kvmulti"
 	"bazil.org/bazil/peer"
 	"bazil.org/bazil/tokens"
 	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
 )
 
 var (
 	ErrPeerNotFound      = errors.New("peer not found")
 	ErrNoStorageForPeer  = errors.New("no storage offered to peer")
 	ErrNoLocationForPeer = errors.New("no network location known for peer")
+
+	ErrPeerLocationInvalid  = errors.New("invalid peer location")
+	ErrPeerLocationNotFound = errors.New("peer location not found")
+
+	ErrPeerVolumeNotAllowed = errors.New("peer is not allowed to use volume")
 )
 
 var (
-	bucketPeer        = []byte(tokens.BucketPeer)
-	bucketPeerID      = []byte(tokens.BucketPeerID)
-	peerStateID       = []byte(tokens.PeerStateID)
-	peerStateLocation = []byte(tokens.PeerStateLocation)
-	peerStateStorage  = []byte(tokens.PeerStateStorage)
-	peerStateVolume   = []byte(tokens.PeerStateVolume)
+	bucketPeer            = []byte(tokens.BucketPeer)
+	bucketPeerID          = []byte(tokens.BucketPeerID)
+	peerStateID           = []byte(tokens.PeerStateID)
+	peerStateLocation     = []byte(tokens.PeerStateLocation)
+	peerStateLocationLast = []byte(tokens.PeerStateLocationLast)
+	peerStateStorage      = []byte(tokens.PeerStateStorage)
+	peerStateVolume       = []byte(tokens.PeerStateVolume)
 )
 
 func (tx *Tx) initPeers() error {
@@ -114,6 +126,27 @@ func (b *Peers) Make(pub *peer.PublicKey) (*Peer, error) {
 	return p, nil
 }
 
+// Remove a peer, forgetting its network locations and all storage
+// and volume grants given to it. The peer ID is tombstoned and will
+// never be reused, as it may still be referenced by logical clocks.
+//
+// If the peer does not exist, returns ErrPeerNotFound.
+func (b *Peers) Remove(pub *peer.PublicKey) error {
+	p, err := b.Get(pub)
+	if err != nil {
+		return err
+	}
+	var idKey [4]byte
+	binary.BigEndian.PutUint32(idKey[:], uint32(p.ID()))
+	if err := b.ids.Put(idKey[:], nil); err != nil {
+		return err
+	}
+	if err := b.peers.DeleteBucket(pub[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (b *Peers) Cursor() *PeersCursor {
 	return &PeersCursor{b.peers.Cursor()}
 }
@@ -168,17 +201,19 @@ func (p *Peer) ID() peer.ID {
 
 func (p *Peer) Locations() *PeerLocations {
 	b := p.b.Bucket(peerStateLocation)
-	return &PeerLocations{b}
+	return &PeerLocations{
+		peer: p.b,
+		b:    b,
+	}
 }
 
 type PeerLocations struct {
-	b *bolt.Bucket
+	peer *bolt.Bucket
+	b    *bolt.Bucket
 }
 
-// Set the network location where the peer can be contacted.
-//
-// TODO support multiple addresses to attempt, with some idea of
-// preferring recent ones.
+// Set the network location where the peer can be contacted,
+// replacing all other known locations.
 func (p *PeerLocations) Set(addr string) error {
 	// remove all the other values, for "set"
 	c := p.b.Cursor()
@@ -187,21 +222,161 @@ func (p *PeerLocations) Set(addr string) error {
 			return err
 		}
 	}
-	return p.b.Put([]byte(addr), nil)
+	if err := p.forgetLast(); err != nil {
+		return err
+	}
+	return p.Add(addr, 0)
 }
 
-// Get an address to try, to connect to the peer.
+// Add a network location where the peer can be contacted. Locations
+// with lower priority values are tried first. If the location is
+// already known, its priority is updated.
+func (p *PeerLocations) Add(addr string, priority uint32) error {
+	if addr == "" {
+		return ErrPeerLocationInvalid
+	}
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], priority)
+	return p.b.Put([]byte(addr), buf[:])
+}
+
+// Remove a network location of the peer.
 //
-// Returned addr is valid after the transaction.
+// If the location is not known, returns ErrPeerLocationNotFound.
+func (p *PeerLocations) Remove(addr string) error {
+	k := []byte(addr)
+	if p.b.Get(k) == nil {
+		return ErrPeerLocationNotFound
+	}
+	if err := p.b.Delete(k); err != nil {
+		return err
+	}
+	if p.Last() == addr {
+		if err := p.forgetLast(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PeerLocations) forgetLast() error {
+	// bolt complains about deleting a missing key when it sorts right
+	// before a bucket, so only delete if present
+	if p.peer.Get(peerStateLocationLast) == nil {
+		return nil
+	}
+	return p.peer.Delete(peerStateLocationLast)
+}
+
+// Get an address to try, to connect to the peer. This is the first
+// address returned by List.
 //
-// TODO support multiple addresses to attempt, with some idea of
-// preferring recent ones.
+// Returned addr is valid after the transaction.
 func (p *PeerLocations) Get() (addr string, err error) {
-	c := p.b.Cursor()
-	for k, _ := c.First(); k != nil; k, _ = c.Next() {
-		return string(k), nil
+	addrs := p.List()
+	if len(addrs) == 0 {
+		return "", ErrNoLocationForPeer
+	}
+	return addrs[0], nil
+}
+
+// List returns the addresses to try, in order, when connecting to
+// the peer. Addresses are ordered by priority; among addresses with
+// the same priority, the one that last worked comes first.
+//
+// Returned addresses are valid after the transaction.
+func (p *PeerLocations) List() []string {
+	type loc struct {
+		addr     string
+		priority uint32
+		last     bool
+	}
+	last := string(p.peer.Get(peerStateLocationLast))
+	var locs []loc
+	c := p.Cursor()
+	for item := c.First(); item != nil; item = c.Next() {
+		addr := item.Addr()
+		locs = append(locs, loc{
+			addr:     addr,
+			priority: item.Priority(),
+			last:     addr == last,
+		})
+	}
+	// cursor gives keys in order, keep that as the tie-breaker
+	sort.SliceStable(locs, func(i, j int) bool {
+		if locs[i].priority != locs[j].priority {
+			return locs[i].priority < locs[j].priority
+		}
+		return locs[i].last && !locs[j].last
+	})
+	addrs := make([]string, 0, len(locs))
+	for _, l := range locs {
+		addrs = append(addrs, l.addr)
+	}
+	return addrs
+}
+
+// Last returns the address that was last successfully connected to,
+// or an empty string if not known.
+//
+// Returned addr is valid after the transaction.
+func (p *PeerLocations) Last() string {
+	return string(p.peer.Get(peerStateLocationLast))
+}
+
+// SetLast remembers addr as having worked, to prefer it over other
+// locations of the same priority.
+func (p *PeerLocations) SetLast(addr string) error {
+	if p.b.Get([]byte(addr)) == nil {
+		return ErrPeerLocationNotFound
 	}
-	return "", ErrNoLocationForPeer
+	return p.peer.Put(peerStateLocationLast, []byte(addr))
+}
+
+// Cursor iterates over the known locations of the peer.
+func (p *PeerLocations) Cursor() *PeerLocationsCursor {
+	return &PeerLocationsCursor{p.b.Cursor()}
+}
+
+type PeerLocationsCursor struct {
+	c *bolt.Cursor
+}
+
+func (c *PeerLocationsCursor) item(k, v []byte) *PeerLocationsItem {
+	if k == nil {
+		return nil
+	}
+	return &PeerLocationsItem{addr: k, value: v}
+}
+
+func (c *PeerLocationsCursor) First() *PeerLocationsItem {
+	return c.item(c.c.First())
+}
+
+func (c *PeerLocationsCursor) Next() *PeerLocationsItem {
+	return c.item(c.c.Next())
+}
+
+type PeerLocationsItem struct {
+	addr  []byte
+	value []byte
+}
+
+// Addr returns the network address of this location.
+//
+// Returned value is valid after the transaction.
+func (item *PeerLocationsItem) Addr() string {
+	return string(item.addr)
+}
+
+// Priority returns the priority of this location. Lower values are
+// tried first.
+func (item *PeerLocationsItem) Priority() uint32 {
+	if len(item.value) != 4 {
+		// old style entry, with no priority
+		return 0
+	}
+	return binary.BigEndian.Uint32(item.value)
 }
 
 func (p *Peer) Storage() *PeerStorage {
@@ -217,6 +392,41 @@ func (p *PeerStorage) Allow(backend string) error {
 	return p.b.Put([]byte(backend), nil)
 }
 
+// Cursor iterates over the storage backends offered to the peer.
+func (p *PeerStorage) Cursor() *PeerStorageCursor {
+	return &PeerStorageCursor{p.b.Cursor()}
+}
+
+type PeerStorageCursor struct {
+	c *bolt.Cursor
+}
+
+func (c *PeerStorageCursor) item(k, _ []byte) *PeerStorageItem {
+	if k == nil {
+		return nil
+	}
+	return &PeerStorageItem{backend: k}
+}
+
+func (c *PeerStorageCursor) First() *PeerStorageItem {
+	return c.item(c.c.First())
+}
+
+func (c *PeerStorageCursor) Next() *PeerStorageItem {
+	return c.item(c.c.Next())
+}
+
+type PeerStorageItem struct {
+	backend []byte
+}
+
+// Backend returns the storage backend offered.
+//
+// Returned value is valid after the transaction.
+func (item *PeerStorageItem) Backend() string {
+	return string(item.backend)
+}
+
 // Open key-value stores as allowed for this peer. Uses the opener
 // function for the actual open action.
 //
@@ -253,11 +463,144 @@ type PeerVolumes struct {
 	b *bolt.Bucket
 }
 
+// VolumeGrant describes what a peer may do with a volume.
+type VolumeGrant struct {
+	// Directories the peer may sync, relative to the volume root,
+	// including everything below them. Empty means the whole
+	// volume.
+	Paths []string
+	// Changes from the peer are never merged.
+	ReadOnly bool
+}
+
+// cleanVolumePath returns the path relative to the volume root, in
+// the form used by the volume lookups.
+func cleanVolumePath(p string) string {
+	return path.Clean("/" + p)[1:]
+}
+
+// AllowsPath reports whether the grant covers the directory at p.
+func (g *VolumeGrant) AllowsPath(p string) bool {
+	if len(g.Paths) == 0 {
+		return true
+	}
+	p = cleanVolumePath(p)
+	for _, prefix := range g.Paths {
+		prefix = cleanVolumePath(prefix)
+		if prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func unmarshalGrant(buf []byte) (*VolumeGrant, error) {
+	var msg wire.PeerVolumeGrant
+	if err := proto.Unmarshal(buf, &msg); err != nil {
+		return nil, err
+	}
+	grant := &VolumeGrant{
+		Paths:    msg.Paths,
+		ReadOnly: msg.ReadOnly,
+	}
+	return grant, nil
+}
+
+// Allow gives the peer full access to the volume, replacing any
+// earlier grant.
 func (p *PeerVolumes) Allow(vol *Volume) error {
 	return p.b.Put([]byte(vol.id), nil)
 }
 
+// AllowGrant gives the peer access to the volume as limited by the
+// grant, replacing any earlier grant.
+func (p *PeerVolumes) AllowGrant(vol *Volume, grant *VolumeGrant) error {
+	msg := &wire.PeerVolumeGrant{
+		ReadOnly: grant.ReadOnly,
+	}
+	for _, path := range grant.Paths {
+		msg.Paths = append(msg.Paths, cleanVolumePath(path))
+	}
+	buf, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return p.b.Put([]byte(vol.id), buf)
+}
+
+// Grant returns what the peer may do with the volume.
+//
+// If the peer is not allowed to use the volume, returns
+// ErrPeerVolumeNotAllowed.
+//
+// Returned value is valid after the transaction.
+func (p *PeerVolumes) Grant(vol *Volume) (*VolumeGrant, error) {
+	buf, found := p.get(vol)
+	if !found {
+		return nil, ErrPeerVolumeNotAllowed
+	}
+	return unmarshalGrant(buf)
+}
+
+// get returns the grant record for the volume. Full access is stored
+// as an empty value, which bolt may return as nil.
+func (p *PeerVolumes) get(vol *Volume) ([]byte, bool) {
+	k, v := p.b.Cursor().Seek([]byte(vol.id))
+	if !bytes.Equal(k, []byte(vol.id)) {
+		return nil, false
+	}
+	return v, true
+}
+
+// forget removes any grant for the given volume ID.
+func (p *PeerVolumes) forget(volID []byte) error {
+	return p.b.Delete(volID)
+}
+
 func (p *PeerVolumes) IsAllowed(vol *Volume) bool {
-	found := p.b.Get([]byte(vol.id)) != nil
+	_, found := p.get(vol)
 	return found
 }
+
+// Cursor iterates over the volumes the peer is allowed to use.
+func (p *PeerVolumes) Cursor() *PeerVolumesCursor {
+	return &PeerVolumesCursor{p.b.Cursor()}
+}
+
+type PeerVolumesCursor struct {
+	c *bolt.Cursor
+}
+
+func (c *PeerVolumesCursor) item(k, v []byte) *PeerVolumesItem {
+	if k == nil {
+		return nil
+	}
+	return &PeerVolumesItem{id: k, grant: v}
+}
+
+func (c *PeerVolumesCursor) First() *PeerVolumesItem {
+	return c.item(c.c.First())
+}
+
+func (c *PeerVolumesCursor) Next() *PeerVolumesItem {
+	return c.item(c.c.Next())
+}
+
+type PeerVolumesItem struct {
+	id    []byte
+	grant []byte
+}
+
+// VolumeID copies the volume ID to out.
+//
+// out is valid after the transaction.
+func (item *PeerVolumesItem) VolumeID(out *VolumeID) {
+	copy(out[:], item.id)
+}
+
+// Grant returns what the peer may do with the volume.
+//
+// Returned value is valid after the transaction.
+func (item *PeerVolumesItem) Grant() (*VolumeGrant, error) {
+	return unmarshalGrant(item.grant)
+}
@@ -68,3 +68,189 @@ func TestMakePeer(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRemovePeer(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	pub1 := &peer.PublicKey{0x42, 0x42, 0x42}
+	pub2 := &peer.PublicKey{0xC0, 0xFF, 0xEE}
+
+	check := func(tx *db.Tx) error {
+		if err := checkMakePeer(tx, pub1, 1); err != nil {
+			t.Error(err)
+		}
+		if err := checkMakePeer(tx, pub2, 2); err != nil {
+			t.Error(err)
+		}
+		if err := tx.Peers().Remove(pub1); err != nil {
+			t.Fatalf("unexpected peers.Remove error: %v", err)
+		}
+		if _, err := tx.Peers().Get(pub1); err != db.ErrPeerNotFound {
+			t.Errorf("expected ErrPeerNotFound after remove, got %v", err)
+		}
+		if err := tx.Peers().Remove(pub1); err != db.ErrPeerNotFound {
+			t.Errorf("expected ErrPeerNotFound from second remove, got %v", err)
+		}
+		// peer IDs are never reused
+		if err := checkMakePeer(tx, pub1, 3); err != nil {
+			t.Error(err)
+		}
+		if err := checkMakePeer(tx, pub2, 2); err != nil {
+			t.Error(err)
+		}
+		return nil
+	}
+	if err := DB.Update(check); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPeerLocations(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	pub := &peer.PublicKey{0x42, 0x42, 0x42}
+	setup := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(pub)
+		if err != nil {
+			return err
+		}
+		locs := p.Locations()
+		if err := locs.Add("c.example.com:1", 5); err != nil {
+			return err
+		}
+		if err := locs.Add("b.example.com:1", 1); err != nil {
+			return err
+		}
+		if err := locs.Add("a.example.com:1", 5); err != nil {
+			return err
+		}
+		if err := locs.SetLast("c.example.com:1"); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(pub)
+		if err != nil {
+			return err
+		}
+		g := fmt.Sprint(p.Locations().List())
+		e := fmt.Sprint([]string{"b.example.com:1", "c.example.com:1", "a.example.com:1"})
+		if g != e {
+			t.Errorf("wrong location order: %v != %v", g, e)
+		}
+		return nil
+	}
+	if err := DB.View(check); err != nil {
+		t.Fatal(err)
+	}
+
+	remove := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(pub)
+		if err != nil {
+			return err
+		}
+		locs := p.Locations()
+		if err := locs.Remove("c.example.com:1"); err != nil {
+			return err
+		}
+		if g, e := locs.Remove("c.example.com:1"), db.ErrPeerLocationNotFound; g != e {
+			t.Errorf("wrong error on second remove: %v != %v", g, e)
+		}
+		if g, e := locs.Last(), ""; g != e {
+			t.Errorf("last location not forgotten: %q", g)
+		}
+		return nil
+	}
+	if err := DB.Update(remove); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPeerVolumeGrant(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	pub := &peer.PublicKey{0x42, 0x42, 0x42}
+	check := func(tx *db.Tx) error {
+		sharingKey, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		vol, err := tx.Volumes().Create("foo", "local", sharingKey)
+		if err != nil {
+			return err
+		}
+		p, err := tx.Peers().Make(pub)
+		if err != nil {
+			return err
+		}
+		if _, err := p.Volumes().Grant(vol); err != db.ErrPeerVolumeNotAllowed {
+			t.Errorf("expected ErrPeerVolumeNotAllowed, got %v", err)
+		}
+
+		if err := p.Volumes().Allow(vol); err != nil {
+			return err
+		}
+		grant, err := p.Volumes().Grant(vol)
+		if err != nil {
+			return err
+		}
+		if len(grant.Paths) != 0 || grant.ReadOnly {
+			t.Errorf("Allow must give full access: %+v", grant)
+		}
+
+		limited := &db.VolumeGrant{
+			Paths:    []string{"/shared/clients/", "other"},
+			ReadOnly: true,
+		}
+		if err := p.Volumes().AllowGrant(vol, limited); err != nil {
+			return err
+		}
+		grant, err = p.Volumes().Grant(vol)
+		if err != nil {
+			return err
+		}
+		if g, e := fmt.Sprint(grant.Paths), "[shared/clients other]"; g != e {
+			t.Errorf("wrong paths: %v != %v", g, e)
+		}
+		if !grant.ReadOnly {
+			t.Error("grant lost read-only")
+		}
+		if !p.Volumes().IsAllowed(vol) {
+			t.Error("limited grant must still allow the volume")
+		}
+		item := p.Volumes().Cursor().First()
+		if item == nil {
+			t.Fatal("grant not listed")
+		}
+		if g, err := item.Grant(); err != nil || !g.ReadOnly {
+			t.Errorf("wrong listed grant: %+v, %v", g, err)
+		}
+
+		for path, want := range map[string]bool{
+			"":                       false,
+			"shared":                 false,
+			"shared/clients":         true,
+			"/shared/clients/acme":   true,
+			"shared/clientsX":        false,
+			"shared/clients/../x":    false,
+			"shared/clients/a/../b/": true,
+			"other":                  true,
+		} {
+			if g := grant.AllowsPath(path); g != want {
+				t.Errorf("AllowsPath(%q) = %v, want %v", path, g, want)
+			}
+		}
+		return nil
+	}
+	if err := DB.Update(check); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,9 +3,13 @@ package db
 import (
 	"crypto/rand"
 	"errors"
+	"strconv"
+	"strings"
 
+	"bazil.org/bazil/db/wire"
 	"bazil.org/bazil/tokens"
 	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
 )
 
 var (
@@ -15,10 +19,18 @@ var (
 )
 
 var (
-	bucketSharing = []byte(tokens.BucketSharing)
+	bucketSharing        = []byte(tokens.BucketSharing)
+	bucketSharingKDF     = []byte(tokens.BucketSharingKDF)
+	bucketSharingPadding = []byte(tokens.BucketSharingPadding)
 )
 
 func (tx *Tx) initSharingKeys() error {
+	if _, err := tx.CreateBucketIfNotExists(bucketSharingKDF); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucketIfNotExists(bucketSharingPadding); err != nil {
+		return err
+	}
 	if bucket := tx.Bucket(bucketSharing); bucket != nil {
 		// All done; be careful to not recreate "default" key, if
 		// removed.
@@ -45,11 +57,15 @@ func (tx *Tx) initSharingKeys() error {
 
 func (tx *Tx) SharingKeys() *SharingKeys {
 	b := tx.Bucket(bucketSharing)
-	return &SharingKeys{b}
+	kdf := tx.Bucket(bucketSharingKDF)
+	padding := tx.Bucket(bucketSharingPadding)
+	return &SharingKeys{b: b, kdf: kdf, padding: padding}
 }
 
 type SharingKeys struct {
-	b *bolt.Bucket
+	b       *bolt.Bucket
+	kdf     *bolt.Bucket
+	padding *bolt.Bucket
 }
 
 // Get a sharing key.
@@ -95,6 +111,114 @@ func (b *SharingKeys) Add(name string, key *[32]byte) (*SharingKey, error) {
 	return s, nil
 }
 
+// AddDerived adds a sharing key that was derived from a passphrase,
+// remembering the key derivation parameters so the same key can be
+// derived elsewhere.
+//
+// If name is invalid, returns ErrSharingKeyNameInvalid.
+//
+// If a sharing key by that name already exists, returns
+// ErrSharingKeyExist.
+func (b *SharingKeys) AddDerived(name string, key *[32]byte, kdf *wire.SharingKeyKDF) (*SharingKey, error) {
+	buf, err := proto.Marshal(kdf)
+	if err != nil {
+		return nil, err
+	}
+	s, err := b.Add(name, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.kdf.Put(s.name, buf); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rotate adds a new generation of the named sharing key, with a
+// freshly generated secret. The new sharing key is named NAME@N,
+// where N is the next unused generation number. The new sharing key
+// uses the same padding as the old one.
+//
+// The old sharing key is left in place.
+//
+// If the sharing key name is not found, returns
+// ErrSharingKeyNotFound.
+func (b *SharingKeys) Rotate(name string) (*SharingKey, error) {
+	if v := b.b.Get([]byte(name)); v == nil {
+		return nil, ErrSharingKeyNotFound
+	}
+	base, gen := splitGeneration(name)
+	var newName string
+	for {
+		gen++
+		newName = base + "@" + strconv.FormatUint(gen, 10)
+		if v := b.b.Get([]byte(newName)); v == nil {
+			break
+		}
+	}
+	var secret [32]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return nil, err
+	}
+	s, err := b.Add(newName, &secret)
+	if err != nil {
+		return nil, err
+	}
+	if padding := b.padding.Get([]byte(name)); padding != nil {
+		if err := s.SetPadding(string(padding)); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// splitGeneration splits a sharing key name into the base name and
+// generation number. Names without a generation suffix are
+// generation 1.
+func splitGeneration(name string) (base string, gen uint64) {
+	idx := strings.LastIndexByte(name, '@')
+	if idx == -1 {
+		return name, 1
+	}
+	n, err := strconv.ParseUint(name[idx+1:], 10, 32)
+	if err != nil || n < 2 {
+		return name, 1
+	}
+	return name[:idx], n
+}
+
+func (b *SharingKeys) Cursor() *SharingKeysCursor {
+	return &SharingKeysCursor{
+		b: b,
+		c: b.b.Cursor(),
+	}
+}
+
+type SharingKeysCursor struct {
+	b *SharingKeys
+	c *bolt.Cursor
+}
+
+func (c *SharingKeysCursor) item(k, v []byte) *SharingKey {
+	if k == nil {
+		return nil
+	}
+	s := &SharingKey{
+		b:      c.b,
+		name:   k,
+		secret: v,
+	}
+	return s
+}
+
+func (c *SharingKeysCursor) First() *SharingKey {
+	return c.item(c.c.First())
+}
+
+func (c *SharingKeysCursor) Next() *SharingKey {
+	return c.item(c.c.Next())
+}
+
 type SharingKey struct {
 	b      *SharingKeys
 	name   []byte
@@ -114,3 +238,43 @@ func (s *SharingKey) Name() string {
 func (s *SharingKey) Secret(out *[32]byte) {
 	copy(out[:], s.secret)
 }
+
+// KDF copies the key derivation parameters to out, if the sharing
+// key was derived from a passphrase. Returns false if the sharing key
+// was not derived from a passphrase.
+//
+// out is valid after the transaction.
+func (s *SharingKey) KDF(out *wire.SharingKeyKDF) (bool, error) {
+	v := s.b.kdf.Get(s.name)
+	if v == nil {
+		return false, nil
+	}
+	if err := proto.Unmarshal(v, out); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Padding returns the name of the padding mode used with this
+// sharing key, or an empty string for no padding.
+//
+// Returned value is valid after the transaction.
+func (s *SharingKey) Padding() string {
+	return string(s.b.padding.Get(s.name))
+}
+
+// SetPadding sets the padding mode used with this sharing key. An
+// empty string means no padding.
+//
+// All data encrypted with one sharing key must use the same
+// padding, so this should only be called when the sharing key is
+// added.
+func (s *SharingKey) SetPadding(padding string) error {
+	if padding == "" {
+		if s.b.padding.Get(s.name) == nil {
+			return nil
+		}
+		return s.b.padding.Delete(s.name)
+	}
+	return s.b.padding.Put(s.name, []byte(padding))
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"errors"
+
+	"bazil.org/bazil/db/wire"
+	"bazil.org/bazil/tokens"
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	ErrSharingKeyRotationNotFound = errors.New("sharing key rotation not found")
+)
+
+var (
+	bucketSharingRotation = []byte(tokens.BucketSharingRotation)
+)
+
+func (tx *Tx) initSharingKeyRotations() error {
+	if _, err := tx.CreateBucketIfNotExists(bucketSharingRotation); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tx *Tx) SharingKeyRotations() *SharingKeyRotations {
+	b := tx.Bucket(bucketSharingRotation)
+	return &SharingKeyRotations{b}
+}
+
+// SharingKeyRotations tracks the progress of replacing sharing keys
+// with newer generations.
+type SharingKeyRotations struct {
+	b *bolt.Bucket
+}
+
+// Get the state of the rotation away from the named sharing key.
+//
+// If there is no such rotation, returns
+// ErrSharingKeyRotationNotFound.
+//
+// out is valid after the transaction.
+func (b *SharingKeyRotations) Get(oldName string, out *wire.SharingKeyRotation) error {
+	v := b.b.Get([]byte(oldName))
+	if v == nil {
+		return ErrSharingKeyRotationNotFound
+	}
+	return proto.Unmarshal(v, out)
+}
+
+// Put stores the state of the rotation away from the named sharing
+// key.
+func (b *SharingKeyRotations) Put(oldName string, state *wire.SharingKeyRotation) error {
+	buf, err := proto.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return b.b.Put([]byte(oldName), buf)
+}
+
+func (b *SharingKeyRotations) Cursor() *SharingKeyRotationsCursor {
+	return &SharingKeyRotationsCursor{b.b.Cursor()}
+}
+
+type SharingKeyRotationsCursor struct {
+	c *bolt.Cursor
+}
+
+func (c *SharingKeyRotationsCursor) item(k, v []byte) *SharingKeyRotationsItem {
+	if k == nil {
+		return nil
+	}
+	return &SharingKeyRotationsItem{name: k, data: v}
+}
+
+func (c *SharingKeyRotationsCursor) First() *SharingKeyRotationsItem {
+	return c.item(c.c.First())
+}
+
+func (c *SharingKeyRotationsCursor) Next() *SharingKeyRotationsItem {
+	return c.item(c.c.Next())
+}
+
+type SharingKeyRotationsItem struct {
+	name []byte
+	data []byte
+}
+
+// OldName returns the name of the sharing key being replaced.
+//
+// Returned value is valid after the transaction.
+func (item *SharingKeyRotationsItem) OldName() string {
+	return string(item.name)
+}
+
+// State returns the state of this rotation.
+//
+// out is valid after the transaction.
+func (item *SharingKeyRotationsItem) State(out *wire.SharingKeyRotation) error {
+	return proto.Unmarshal(item.data, out)
+}
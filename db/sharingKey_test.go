@@ -0,0 +1,152 @@
+package db_test
+
+import (
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/db/wire"
+)
+
+func TestSharingKeyRotate(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	rotate := func(tx *db.Tx) error {
+		var old [32]byte
+		k, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		k.Secret(&old)
+
+		for _, want := range []string{"default@2", "default@3"} {
+			prev := k.Name()
+			k, err = tx.SharingKeys().Rotate(prev)
+			if err != nil {
+				return err
+			}
+			if g, e := k.Name(), want; g != e {
+				t.Errorf("wrong new name: %q != %q", g, e)
+			}
+			var secret [32]byte
+			k.Secret(&secret)
+			if secret == old {
+				t.Errorf("secret was not changed")
+			}
+			if _, err := tx.SharingKeys().Get(prev); err != nil {
+				t.Errorf("old key must remain: %v", err)
+			}
+		}
+
+		if _, err := tx.SharingKeys().Rotate("missing"); err != db.ErrSharingKeyNotFound {
+			t.Errorf("expected ErrSharingKeyNotFound, got %v", err)
+		}
+		return nil
+	}
+	if err := DB.Update(rotate); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSharingKeyDerived(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	update := func(tx *db.Tx) error {
+		secret := [32]byte{1, 2, 3}
+		kdf := &wire.SharingKeyKDF{
+			Salt: []byte("salty"),
+			LogN: 10,
+			R:    8,
+			P:    1,
+		}
+		if _, err := tx.SharingKeys().AddDerived("team", &secret, kdf); err != nil {
+			return err
+		}
+		if _, err := tx.SharingKeys().AddDerived("team", &secret, kdf); err != db.ErrSharingKeyExist {
+			t.Errorf("expected ErrSharingKeyExist, got %v", err)
+		}
+		return nil
+	}
+	if err := DB.Update(update); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(tx *db.Tx) error {
+		k, err := tx.SharingKeys().Get("team")
+		if err != nil {
+			return err
+		}
+		var kdf wire.SharingKeyKDF
+		ok, err := k.KDF(&kdf)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			t.Fatal("expected derived key to have KDF parameters")
+		}
+		if g, e := string(kdf.Salt), "salty"; g != e {
+			t.Errorf("wrong salt: %q != %q", g, e)
+		}
+		if g, e := kdf.LogN, uint32(10); g != e {
+			t.Errorf("wrong logN: %v != %v", g, e)
+		}
+
+		k, err = tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		ok, err = k.KDF(&kdf)
+		if err != nil {
+			return err
+		}
+		if ok {
+			t.Error("random key must not have KDF parameters")
+		}
+		return nil
+	}
+	if err := DB.View(check); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSharingKeyPadding(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	update := func(tx *db.Tx) error {
+		k, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		if g, e := k.Padding(), ""; g != e {
+			t.Errorf("wrong default padding: %q != %q", g, e)
+		}
+		if err := k.SetPadding("padme"); err != nil {
+			return err
+		}
+		if g, e := k.Padding(), "padme"; g != e {
+			t.Errorf("wrong padding: %q != %q", g, e)
+		}
+
+		// new generations keep the padding
+		k, err = tx.SharingKeys().Rotate("default")
+		if err != nil {
+			return err
+		}
+		if g, e := k.Padding(), "padme"; g != e {
+			t.Errorf("wrong padding after rotate: %q != %q", g, e)
+		}
+
+		if err := k.SetPadding(""); err != nil {
+			return err
+		}
+		if g, e := k.Padding(), ""; g != e {
+			t.Errorf("padding not cleared: %q != %q", g, e)
+		}
+		return nil
+	}
+	if err := DB.Update(update); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -42,6 +42,7 @@ func (tx *Tx) initVolumes() error {
 
 func (tx *Tx) Volumes() *Volumes {
 	p := &Volumes{
+		tx:      tx,
 		volumes: tx.Bucket(bucketVolume),
 		names:   tx.Bucket(bucketVolName),
 	}
@@ -49,6 +50,7 @@ func (tx *Tx) Volumes() *Volumes {
 }
 
 type Volumes struct {
+	tx      *Tx
 	volumes *bolt.Bucket
 	names   *bolt.Bucket
 }
@@ -78,6 +80,62 @@ func (b *Volumes) GetByVolumeID(volID *VolumeID) (*Volume, error) {
 	return v, nil
 }
 
+// Cursor iterates over all volumes, in order of their names.
+func (b *Volumes) Cursor() *VolumesCursor {
+	return &VolumesCursor{
+		c:       b.names.Cursor(),
+		volumes: b.volumes,
+	}
+}
+
+type VolumesCursor struct {
+	c       *bolt.Cursor
+	volumes *bolt.Bucket
+}
+
+func (c *VolumesCursor) item(k, v []byte) *VolumesItem {
+	if k == nil {
+		return nil
+	}
+	bv := c.volumes.Bucket(v)
+	if bv == nil {
+		panic("db volume corrupt, name refers to missing volume: " + string(k))
+	}
+	item := &VolumesItem{
+		name: k,
+		vol: &Volume{
+			b:  bv,
+			id: v,
+		},
+	}
+	return item
+}
+
+func (c *VolumesCursor) First() *VolumesItem {
+	return c.item(c.c.First())
+}
+
+func (c *VolumesCursor) Next() *VolumesItem {
+	return c.item(c.c.Next())
+}
+
+type VolumesItem struct {
+	name []byte
+	vol  *Volume
+}
+
+// Name returns the name of the volume.
+//
+// Returned value is valid after the transaction.
+func (item *VolumesItem) Name() string {
+	return string(item.name)
+}
+
+// Volume returns the volume itself.
+func (item *VolumesItem) Volume() *Volume {
+	return item.vol
+}
+
 // add a new volume.
 //
 // If the name exists already, returns ErrVolNameExist.
@@ -164,6 +222,66 @@ func (b *Volumes) Add(name string, volID *VolumeID, storage string, sharingKey *
 	return b.add(name, volID, storage, sharingKey)
 }
 
+// Delete removes a volume from this node, along with all metadata
+// kept about it and any grants given to peers to use it. The content
+// in storage is not touched, as it may be shared with other volumes
+// and peers.
+//
+// If the volume does not exist, returns ErrVolNameNotFound.
+func (b *Volumes) Delete(name string) error {
+	n := []byte(name)
+	volID := b.names.Get(n)
+	if volID == nil {
+		return ErrVolNameNotFound
+	}
+	// copy the id, it's no longer valid after the Delete below
+	volID = append([]byte(nil), volID...)
+	if err := b.names.Delete(n); err != nil {
+		return err
+	}
+	if err := b.volumes.DeleteBucket(volID); err != nil {
+		return err
+	}
+
+	c := b.tx.Peers().Cursor()
+	for p := c.First(); p != nil; p = c.Next() {
+		if err := p.Volumes().forget(volID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rename changes the local name of a volume. The volume ID, and
+// thus the identity of the volume as seen by peers, stays the same.
+//
+// If the volume does not exist, returns ErrVolNameNotFound.
+//
+// If the new name exists already, returns ErrVolNameExist.
+func (b *Volumes) Rename(oldName, newName string) error {
+	if newName == "" {
+		return ErrVolNameInvalid
+	}
+	o := []byte(oldName)
+	volID := b.names.Get(o)
+	if volID == nil {
+		return ErrVolNameNotFound
+	}
+	n := []byte(newName)
+	if v := b.names.Get(n); v != nil {
+		return ErrVolNameExist
+	}
+	// copy the id, it's no longer valid after the Delete below
+	volID = append([]byte(nil), volID...)
+	if err := b.names.Delete(o); err != nil {
+		return err
+	}
+	if err := b.names.Put(n, volID); err != nil {
+		return err
+	}
+	return nil
+}
+
 func randomVolumeID() (*VolumeID, error) {
 	var id VolumeID
 	_, err := rand.Read(id[:])
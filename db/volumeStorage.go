@@ -9,7 +9,8 @@ import (
 )
 
 var (
-	ErrVolumeStorageExist = errors.New("volume storage name exists already")
+	ErrVolumeStorageExist    = errors.New("volume storage name exists already")
+	ErrVolumeStorageNotFound = errors.New("volume storage not found")
 )
 
 type VolumeStorage struct {
@@ -38,6 +39,63 @@ func (vs *VolumeStorage) Add(name string, backend string, sharingKey *SharingKey
 	return vs.b.Put(n, buf)
 }
 
+func (vs *VolumeStorage) get(n []byte) (*wire.VolumeStorage, error) {
+	v := vs.b.Get(n)
+	if v == nil {
+		return nil, ErrVolumeStorageNotFound
+	}
+	var msg wire.VolumeStorage
+	if err := proto.Unmarshal(v, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (vs *VolumeStorage) put(n []byte, msg *wire.VolumeStorage) error {
+	buf, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return vs.b.Put(n, buf)
+}
+
+// RotateSharingKey switches the named storage to use a new sharing
+// key. The previous sharing key is remembered, so that chunks not
+// yet re-encrypted can still be read, until FinishRotation is
+// called.
+//
+// Active Volume instances are not notified.
+//
+// If volume has no storage by that name, returns
+// ErrVolumeStorageNotFound.
+func (vs *VolumeStorage) RotateSharingKey(name string, sharingKey *SharingKey) error {
+	n := []byte(name)
+	msg, err := vs.get(n)
+	if err != nil {
+		return err
+	}
+	msg.OldSharingKeyName = msg.SharingKeyName
+	msg.SharingKeyName = sharingKey.Name()
+	return vs.put(n, msg)
+}
+
+// FinishRotation forgets the previous sharing key of the named
+// storage, once all chunks have been re-encrypted.
+//
+// Active Volume instances are not notified.
+//
+// If volume has no storage by that name, returns
+// ErrVolumeStorageNotFound.
+func (vs *VolumeStorage) FinishRotation(name string) error {
+	n := []byte(name)
+	msg, err := vs.get(n)
+	if err != nil {
+		return err
+	}
+	msg.OldSharingKeyName = ""
+	return vs.put(n, msg)
+}
+
 func (vs *VolumeStorage) Cursor() *VolumeStorageCursor {
 	return &VolumeStorageCursor{vs.b.Cursor()}
 }
@@ -67,6 +125,13 @@ type VolumeStorageItem struct {
 	conf wire.VolumeStorage
 }
 
+// Name returns the name of the storage in this volume.
+//
+// Returned value is valid after the transaction.
+func (item *VolumeStorageItem) Name() string {
+	return string(item.name)
+}
+
 func (item *VolumeStorageItem) unmarshal() error {
 	return proto.Unmarshal(item.data, &item.conf)
 }
@@ -94,3 +159,17 @@ func (item *VolumeStorageItem) SharingKeyName() (string, error) {
 	}
 	return item.conf.SharingKeyName, nil
 }
+
+// OldSharingKeyName returns the name of the previous sharing key for
+// this item, while the sharing key is being rotated. Otherwise,
+// returns an empty string.
+//
+// Returned value is valid after the transaction.
+func (item *VolumeStorageItem) OldSharingKeyName() (string, error) {
+	if item.conf.Backend == "" {
+		if err := item.unmarshal(); err != nil {
+			return "", err
+		}
+	}
+	return item.conf.OldSharingKeyName, nil
+}
@@ -0,0 +1,140 @@
+package db_test
+
+import (
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+)
+
+func TestVolumeDelete(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	pub := &peer.PublicKey{0x42, 0x42, 0x42}
+	var volID db.VolumeID
+	setup := func(tx *db.Tx) error {
+		sharingKey, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		vol, err := tx.Volumes().Create("foo", "local", sharingKey)
+		if err != nil {
+			return err
+		}
+		vol.VolumeID(&volID)
+		p, err := tx.Peers().Make(pub)
+		if err != nil {
+			return err
+		}
+		if err := p.Volumes().Allow(vol); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+
+	del := func(tx *db.Tx) error {
+		return tx.Volumes().Delete("foo")
+	}
+	if err := DB.Update(del); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	check := func(tx *db.Tx) error {
+		if _, err := tx.Volumes().GetByName("foo"); err != db.ErrVolNameNotFound {
+			t.Errorf("expected ErrVolNameNotFound, got %v", err)
+		}
+		if _, err := tx.Volumes().GetByVolumeID(&volID); err != db.ErrVolumeIDNotFound {
+			t.Errorf("expected ErrVolumeIDNotFound, got %v", err)
+		}
+		p, err := tx.Peers().Get(pub)
+		if err != nil {
+			return err
+		}
+		c := p.Volumes().Cursor()
+		if item := c.First(); item != nil {
+			var id db.VolumeID
+			item.VolumeID(&id)
+			t.Errorf("peer volume grant not removed: %v", id)
+		}
+		return nil
+	}
+	if err := DB.View(check); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVolumeDeleteNotFound(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	del := func(tx *db.Tx) error {
+		return tx.Volumes().Delete("foo")
+	}
+	if g, e := DB.Update(del), db.ErrVolNameNotFound; g != e {
+		t.Fatalf("wrong error: %v != %v", g, e)
+	}
+}
+
+func TestVolumeRename(t *testing.T) {
+	DB := NewTestDB(t)
+	defer DB.Close()
+
+	var volID db.VolumeID
+	setup := func(tx *db.Tx) error {
+		sharingKey, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		vol, err := tx.Volumes().Create("foo", "local", sharingKey)
+		if err != nil {
+			return err
+		}
+		vol.VolumeID(&volID)
+		if _, err := tx.Volumes().Create("quux", "local", sharingKey); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+
+	rename := func(tx *db.Tx) error {
+		if err := tx.Volumes().Rename("foo", "quux"); err != db.ErrVolNameExist {
+			t.Errorf("expected ErrVolNameExist, got %v", err)
+		}
+		if err := tx.Volumes().Rename("foo", ""); err != db.ErrVolNameInvalid {
+			t.Errorf("expected ErrVolNameInvalid, got %v", err)
+		}
+		if err := tx.Volumes().Rename("missing", "xyzzy"); err != db.ErrVolNameNotFound {
+			t.Errorf("expected ErrVolNameNotFound, got %v", err)
+		}
+		return tx.Volumes().Rename("foo", "bar")
+	}
+	if err := DB.Update(rename); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+
+	check := func(tx *db.Tx) error {
+		if _, err := tx.Volumes().GetByName("foo"); err != db.ErrVolNameNotFound {
+			t.Errorf("expected ErrVolNameNotFound, got %v", err)
+		}
+		vol, err := tx.Volumes().GetByName("bar")
+		if err != nil {
+			return err
+		}
+		var id db.VolumeID
+		vol.VolumeID(&id)
+		if id != volID {
+			t.Errorf("wrong volume ID after rename: %v != %v", id, volID)
+		}
+		return nil
+	}
+	if err := DB.View(check); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,93 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bazil.org/bazil/db/wire/peer.proto
+
+package wire
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// PeerVolumeGrant limits what a peer may do with a volume it is
+// allowed to use. A missing grant record means full access.
+type PeerVolumeGrant struct {
+	// Directories the peer may sync, relative to the volume root,
+	// including everything below them. Empty means the whole volume.
+	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	// Changes from the peer are never merged.
+	ReadOnly             bool     `protobuf:"varint,2,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerVolumeGrant) Reset()         { *m = PeerVolumeGrant{} }
+func (m *PeerVolumeGrant) String() string { return proto.CompactTextString(m) }
+func (*PeerVolumeGrant) ProtoMessage()    {}
+func (*PeerVolumeGrant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8ea54c1a06d2b2aa, []int{0}
+}
+
+func (m *PeerVolumeGrant) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerVolumeGrant.Unmarshal(m, b)
+}
+func (m *PeerVolumeGrant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerVolumeGrant.Marshal(b, m, deterministic)
+}
+func (m *PeerVolumeGrant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerVolumeGrant.Merge(m, src)
+}
+func (m *PeerVolumeGrant) XXX_Size() int {
+	return xxx_messageInfo_PeerVolumeGrant.Size(m)
+}
+func (m *PeerVolumeGrant) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerVolumeGrant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerVolumeGrant proto.InternalMessageInfo
+
+func (m *PeerVolumeGrant) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *PeerVolumeGrant) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*PeerVolumeGrant)(nil), "bazil.db.PeerVolumeGrant")
+}
+
+func init() {
+	proto.RegisterFile("bazil.org/bazil/db/wire/peer.proto", fileDescriptor_8ea54c1a06d2b2aa)
+}
+
+var fileDescriptor_8ea54c1a06d2b2aa = []byte{
+	// 128 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4a, 0x4a, 0xac, 0xca,
+	0xcc, 0xd1, 0xcb, 0x2f, 0x4a, 0xd7, 0x07, 0xb3, 0xf4, 0x53, 0x92, 0xf4, 0xcb, 0x33, 0x8b, 0x52,
+	0xf5, 0x0b, 0x52, 0x53, 0x8b, 0xf4, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x38, 0x20, 0x6a, 0x52,
+	0x92, 0x94, 0x9c, 0xb9, 0xf8, 0x03, 0x52, 0x53, 0x8b, 0xc2, 0xf2, 0x73, 0x4a, 0x73, 0x53, 0xdd,
+	0x8b, 0x12, 0xf3, 0x4a, 0x84, 0x44, 0xb8, 0x58, 0x0b, 0x12, 0x4b, 0x32, 0x8a, 0x25, 0x18, 0x15,
+	0x98, 0x35, 0x38, 0x83, 0x20, 0x1c, 0x21, 0x29, 0x2e, 0x8e, 0xa2, 0xd4, 0xc4, 0x14, 0xff, 0xbc,
+	0x9c, 0x4a, 0x09, 0x26, 0x05, 0x46, 0x0d, 0x8e, 0x20, 0x38, 0xdf, 0x89, 0x2d, 0x8a, 0x05, 0x64,
+	0x43, 0x12, 0x1b, 0xd8, 0x74, 0x63, 0xc0, 0x00, 0xec, 0xf0, 0xdd, 0x56, 0x83, 0x00, 0x00, 0x00,
+}
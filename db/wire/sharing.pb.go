@@ -0,0 +1,192 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bazil.org/bazil/db/wire/sharing.proto
+
+package wire
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SharingKeyRotation tracks the progress of re-encrypting chunks
+// with a new generation of a sharing key.
+type SharingKeyRotation struct {
+	// Name of the sharing key replacing the old one.
+	NewName string `protobuf:"bytes,1,opt,name=newName,proto3" json:"newName,omitempty"`
+	// Number of volumes that were using the old sharing key when the
+	// rotation started, and how many of them have been completed.
+	Volumes     uint32 `protobuf:"varint,2,opt,name=volumes,proto3" json:"volumes,omitempty"`
+	VolumesDone uint32 `protobuf:"varint,3,opt,name=volumesDone,proto3" json:"volumesDone,omitempty"`
+	// Number of chunks re-encrypted so far.
+	Chunks               uint64   `protobuf:"varint,4,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Done                 bool     `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyRotation) Reset()         { *m = SharingKeyRotation{} }
+func (m *SharingKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyRotation) ProtoMessage()    {}
+func (*SharingKeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5536b593b0d7b454, []int{0}
+}
+
+func (m *SharingKeyRotation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyRotation.Unmarshal(m, b)
+}
+func (m *SharingKeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyRotation.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyRotation.Merge(m, src)
+}
+func (m *SharingKeyRotation) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyRotation.Size(m)
+}
+func (m *SharingKeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyRotation proto.InternalMessageInfo
+
+func (m *SharingKeyRotation) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+func (m *SharingKeyRotation) GetVolumes() uint32 {
+	if m != nil {
+		return m.Volumes
+	}
+	return 0
+}
+
+func (m *SharingKeyRotation) GetVolumesDone() uint32 {
+	if m != nil {
+		return m.VolumesDone
+	}
+	return 0
+}
+
+func (m *SharingKeyRotation) GetChunks() uint64 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *SharingKeyRotation) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+// SharingKeyKDF records how a sharing key was derived from a
+// passphrase, using scrypt.
+type SharingKeyKDF struct {
+	Salt []byte `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	// scrypt cost parameter N is 1<<logN.
+	LogN                 uint32   `protobuf:"varint,2,opt,name=logN,proto3" json:"logN,omitempty"`
+	R                    uint32   `protobuf:"varint,3,opt,name=r,proto3" json:"r,omitempty"`
+	P                    uint32   `protobuf:"varint,4,opt,name=p,proto3" json:"p,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyKDF) Reset()         { *m = SharingKeyKDF{} }
+func (m *SharingKeyKDF) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyKDF) ProtoMessage()    {}
+func (*SharingKeyKDF) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5536b593b0d7b454, []int{1}
+}
+
+func (m *SharingKeyKDF) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyKDF.Unmarshal(m, b)
+}
+func (m *SharingKeyKDF) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyKDF.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyKDF) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyKDF.Merge(m, src)
+}
+func (m *SharingKeyKDF) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyKDF.Size(m)
+}
+func (m *SharingKeyKDF) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyKDF.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyKDF proto.InternalMessageInfo
+
+func (m *SharingKeyKDF) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *SharingKeyKDF) GetLogN() uint32 {
+	if m != nil {
+		return m.LogN
+	}
+	return 0
+}
+
+func (m *SharingKeyKDF) GetR() uint32 {
+	if m != nil {
+		return m.R
+	}
+	return 0
+}
+
+func (m *SharingKeyKDF) GetP() uint32 {
+	if m != nil {
+		return m.P
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*SharingKeyRotation)(nil), "bazil.db.SharingKeyRotation")
+	proto.RegisterType((*SharingKeyKDF)(nil), "bazil.db.SharingKeyKDF")
+}
+
+func init() {
+	proto.RegisterFile("bazil.org/bazil/db/wire/sharing.proto", fileDescriptor_5536b593b0d7b454)
+}
+
+var fileDescriptor_5536b593b0d7b454 = []byte{
+	// 226 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0x41, 0x4b, 0xc3, 0x40,
+	0x10, 0x85, 0x19, 0x8d, 0xb1, 0x8e, 0xcd, 0x65, 0x0e, 0xb2, 0xc7, 0xa5, 0x20, 0xe4, 0xd4, 0x1c,
+	0xfc, 0x07, 0x52, 0xbc, 0x14, 0x7a, 0xd8, 0xde, 0xbc, 0x6d, 0xcc, 0x92, 0x06, 0xd3, 0x9d, 0xb0,
+	0x49, 0x2d, 0xfa, 0x43, 0xfc, 0xbd, 0xb2, 0xd3, 0x0d, 0xf6, 0xf6, 0xbd, 0x79, 0x33, 0xcc, 0xe3,
+	0xe1, 0x73, 0x6d, 0x7f, 0xba, 0x7e, 0xcd, 0xa1, 0xad, 0x84, 0xaa, 0xa6, 0xae, 0xce, 0x5d, 0x70,
+	0xd5, 0x78, 0xb0, 0xa1, 0xf3, 0xed, 0x7a, 0x08, 0x3c, 0x31, 0x2d, 0x2e, 0x6b, 0x4d, 0xbd, 0xfa,
+	0x05, 0xa4, 0xfd, 0xc5, 0xdb, 0xba, 0x6f, 0xc3, 0x93, 0x9d, 0x3a, 0xf6, 0xa4, 0xf0, 0xde, 0xbb,
+	0xf3, 0xce, 0x1e, 0x9d, 0x02, 0x0d, 0xe5, 0x83, 0x99, 0x65, 0x74, 0xbe, 0xb8, 0x3f, 0x1d, 0xdd,
+	0xa8, 0x6e, 0x34, 0x94, 0x85, 0x99, 0x25, 0x69, 0x7c, 0x4c, 0xb8, 0x61, 0xef, 0xd4, 0xad, 0xb8,
+	0xd7, 0x23, 0x7a, 0xc2, 0xfc, 0xe3, 0x70, 0xf2, 0x9f, 0xa3, 0xca, 0x34, 0x94, 0x99, 0x49, 0x8a,
+	0x08, 0xb3, 0x26, 0x9e, 0xdc, 0x69, 0x28, 0x17, 0x46, 0x78, 0xb5, 0xc7, 0xe2, 0x3f, 0xd7, 0x76,
+	0xf3, 0x16, 0x97, 0x46, 0xdb, 0x4f, 0x92, 0x67, 0x69, 0x84, 0xe3, 0xac, 0xe7, 0x76, 0x97, 0x92,
+	0x08, 0xd3, 0x12, 0x21, 0xa4, 0xe7, 0x10, 0xa2, 0x1a, 0xe4, 0x5b, 0x61, 0x60, 0x78, 0xcd, 0xdf,
+	0xb3, 0xd8, 0x46, 0x9d, 0x4b, 0x0d, 0x2f, 0x7f, 0x03, 0x00, 0x53, 0xff, 0x2d, 0x7d, 0x2f, 0x01,
+	0x00, 0x00,
+}
@@ -21,8 +21,11 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type VolumeStorage struct {
-	Backend              string   `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
-	SharingKeyName       string   `protobuf:"bytes,2,opt,name=sharingKeyName,proto3" json:"sharingKeyName,omitempty"`
+	Backend        string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	SharingKeyName string `protobuf:"bytes,2,opt,name=sharingKeyName,proto3" json:"sharingKeyName,omitempty"`
+	// If set, the sharing key is being rotated, and chunks may still
+	// be stored encrypted with this older sharing key.
+	OldSharingKeyName    string   `protobuf:"bytes,3,opt,name=oldSharingKeyName,proto3" json:"oldSharingKeyName,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -67,6 +70,13 @@ func (m *VolumeStorage) GetSharingKeyName() string {
 	return ""
 }
 
+func (m *VolumeStorage) GetOldSharingKeyName() string {
+	if m != nil {
+		return m.OldSharingKeyName
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*VolumeStorage)(nil), "bazil.db.VolumeStorage")
 }
@@ -76,14 +86,15 @@ func init() {
 }
 
 var fileDescriptor_b52f12a963a22720 = []byte{
-	// 134 bytes of a gzipped FileDescriptorProto
+	// 146 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x49, 0x4a, 0xac, 0xca,
 	0xcc, 0xd1, 0xcb, 0x2f, 0x4a, 0xd7, 0x07, 0xb3, 0xf4, 0x53, 0x92, 0xf4, 0xcb, 0x33, 0x8b, 0x52,
 	0xf5, 0xcb, 0xf2, 0x73, 0x4a, 0x73, 0x53, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x38, 0x20,
-	0xaa, 0x52, 0x92, 0x94, 0x02, 0xb9, 0x78, 0xc3, 0xc0, 0x32, 0xc1, 0x25, 0xf9, 0x45, 0x89, 0xe9,
+	0xaa, 0x52, 0x92, 0x94, 0xea, 0xb9, 0x78, 0xc3, 0xc0, 0x32, 0xc1, 0x25, 0xf9, 0x45, 0x89, 0xe9,
 	0xa9, 0x42, 0x12, 0x5c, 0xec, 0x49, 0x89, 0xc9, 0xd9, 0xa9, 0x79, 0x29, 0x12, 0x8c, 0x0a, 0x8c,
 	0x1a, 0x9c, 0x41, 0x30, 0xae, 0x90, 0x1a, 0x17, 0x5f, 0x71, 0x46, 0x62, 0x51, 0x66, 0x5e, 0xba,
-	0x77, 0x6a, 0xa5, 0x5f, 0x62, 0x6e, 0xaa, 0x04, 0x13, 0x58, 0x01, 0x9a, 0xa8, 0x13, 0x5b, 0x14,
-	0x0b, 0xc8, 0xc6, 0x24, 0x36, 0xb0, 0x5d, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x29, 0x6e,
-	0x7e, 0xa7, 0x93, 0x00, 0x00, 0x00,
+	0x77, 0x6a, 0xa5, 0x5f, 0x62, 0x6e, 0xaa, 0x04, 0x13, 0x58, 0x01, 0x9a, 0xa8, 0x90, 0x0e, 0x97,
+	0x60, 0x7e, 0x4e, 0x4a, 0x30, 0xaa, 0x52, 0x66, 0xb0, 0x52, 0x4c, 0x09, 0x27, 0xb6, 0x28, 0x16,
+	0x90, 0xfb, 0x92, 0xd8, 0xc0, 0x2e, 0x33, 0x06, 0x0c, 0x00, 0x79, 0x2f, 0x79, 0xb3, 0xc1, 0x00,
+	0x00, 0x00,
 }
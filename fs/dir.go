@@ -20,6 +20,7 @@ import (
 	"bazil.org/bazil/fs/wire"
 	"bazil.org/bazil/peer"
 	wirepeer "bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/tokens"
 	"bazil.org/bazil/util/env"
 	"bazil.org/fuse"
 	"bazil.org/fuse/fs"
@@ -425,6 +426,29 @@ func (d *dir) Forget() {
 	d.parent.forgetChild(name, d)
 }
 
+// walkActive calls fn for every in-memory descendant of d, depth
+// first. fn is called without holding any dir.mu.
+func (d *dir) walkActive(fn func(node) error) error {
+	d.mu.Lock()
+	children := make([]node, 0, len(d.active))
+	for _, a := range d.active {
+		children = append(children, a.node)
+	}
+	d.mu.Unlock()
+
+	for _, child := range children {
+		if err := fn(child); err != nil {
+			return err
+		}
+		if sub, ok := child.(*dir); ok {
+			if err := sub.walkActive(fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 const debugMkdirExisting = true
 
 func (d *dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error) {
@@ -718,7 +742,7 @@ func (d *dir) syncToMissing(ctx context.Context, tx *db.Tx, volume *db.Volume, w
 	case clock.Nothing:
 		// they lose, do nothing
 	case clock.Conflict:
-		if err := volume.Conflicts().Add(d.inode, theirs, wde); err != nil {
+		if err := d.fs.addConflict(tx, volume, d.inode, theirs, wde); err != nil {
 			return err
 		}
 	case clock.Copy:
@@ -781,7 +805,7 @@ func (d *dir) syncToNode(ctx context.Context, tx *db.Tx, volume *db.Volume, chil
 	case clock.Nothing:
 		// they lose, do nothing
 	case clock.Conflict:
-		if err := volume.Conflicts().Add(d.inode, theirs, wde); err != nil {
+		if err := d.fs.addConflict(tx, volume, d.inode, theirs, wde); err != nil {
 			return err
 		}
 	case clock.Copy:
@@ -876,6 +900,7 @@ func (d *dir) syncReceive(ctx context.Context, peers map[uint32][]byte, dirClock
 
 	oursPrev := ""
 	oursEOF := false
+	validator := syncValidator{root: d.inode == tokens.InodeRoot}
 	for {
 		dirents, err := recv()
 		if err == io.EOF {
@@ -884,6 +909,9 @@ func (d *dir) syncReceive(ctx context.Context, peers map[uint32][]byte, dirClock
 		if err != nil {
 			return err
 		}
+		if err := validator.check(dirents); err != nil {
+			return err
+		}
 
 		sync := func(tx *db.Tx) error {
 			d.mu.Lock()
@@ -968,7 +996,7 @@ func (d *dir) syncReceive(ctx context.Context, peers map[uint32][]byte, dirClock
 					if busy {
 						// clocks strictly greater than local are also stored as
 						// conflicts if the file is currently open.
-						if err := bucket.Conflicts().Add(d.inode, &theirs, wde); err != nil {
+						if err := d.fs.addConflict(tx, bucket, d.inode, &theirs, wde); err != nil {
 							return err
 						}
 						continue loop
@@ -1073,7 +1101,7 @@ func (d *dir) tryResolveConflicts(name string) {
 				if busy {
 					// clocks strictly greater than local are also stored as
 					// conflicts if the file is currently open.
-					if err := bucket.Conflicts().Add(d.inode, theirs, &wde); err != nil {
+					if err := d.fs.addConflict(tx, bucket, d.inode, theirs, &wde); err != nil {
 						return err
 					}
 					continue loop
@@ -32,6 +32,8 @@ type Volume struct {
 
 	// Only set while the Volume is mounted.
 	fuse atomic.Value
+	// Called for every conflict recorded by sync, if set.
+	conflictHook atomic.Value
 
 	epoch struct {
 		mu sync.Mutex
@@ -422,10 +424,50 @@ func (v *Volume) SyncReceive(ctx context.Context, dirPath string, peers map[uint
 	return nil
 }
 
+// Flush writes out all dirty files currently known to the kernel.
+// It returns the number of file handles that remain open.
+func (v *Volume) Flush(ctx context.Context) (openHandles uint64, err error) {
+	flush := func(n node) error {
+		f, ok := n.(*file)
+		if !ok {
+			return nil
+		}
+		if err := f.flush(ctx); err != nil {
+			return err
+		}
+		f.mu.Lock()
+		openHandles += uint64(f.handles)
+		f.mu.Unlock()
+		return nil
+	}
+	if err := v.root.walkActive(flush); err != nil {
+		return 0, err
+	}
+	return openHandles, nil
+}
+
 func (v *Volume) SetFUSE(srv *fs.Server) {
 	v.fuse.Store(srv)
 }
 
+// SetConflictHook makes the volume call fn each time sync records a
+// conflict, once the conflict is committed to the database.
+func (v *Volume) SetConflictHook(fn func()) {
+	v.conflictHook.Store(fn)
+}
+
+// addConflict records a conflict with the dirent, in the directory
+// parent.
+func (v *Volume) addConflict(tx *db.Tx, bucket *db.Volume, parent uint64, theirs *clock.Clock, de *wirepeer.Dirent) error {
+	if err := bucket.Conflicts().Add(parent, theirs, de); err != nil {
+		return err
+	}
+	if fn, _ := v.conflictHook.Load().(func()); fn != nil {
+		tx.OnCommit(fn)
+	}
+	return nil
+}
+
 func (v *Volume) invalidateEntry(d node, name string) error {
 	i := v.fuse.Load()
 	if i == nil {
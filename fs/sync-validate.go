@@ -0,0 +1,119 @@
+package fs
+
+import (
+	"fmt"
+	"strings"
+
+	"bazil.org/bazil/cas"
+	"bazil.org/bazil/cas/blobs"
+	wirecas "bazil.org/bazil/cas/wire"
+	wirepeer "bazil.org/bazil/peer/wire"
+)
+
+// Largest chunk size accepted in manifests received from peers.
+// Manifests with larger chunks could make us allocate arbitrary
+// amounts of memory when reading the file.
+const maxSyncChunkSize = 64 << 20
+
+// InvalidSyncError is returned when a peer sends directory entries
+// that break the sync protocol rules. The sync is aborted, with the
+// entries before the bad one already applied.
+type InvalidSyncError struct {
+	// Name of the offending entry, as sent by the peer.
+	Name   string
+	Reason string
+}
+
+var _ error = (*InvalidSyncError)(nil)
+
+func (e *InvalidSyncError) Error() string {
+	return fmt.Sprintf("invalid sync entry %q: %s", e.Name, e.Reason)
+}
+
+// syncValidator checks directory entries streamed from a peer. The
+// entries must be in strictly increasing bytewise order across all
+// messages.
+type syncValidator struct {
+	// Whether the directory is the volume root, which has more
+	// reserved names.
+	root bool
+	prev string
+	seen bool
+}
+
+func (v *syncValidator) fail(name string, format string, args ...interface{}) error {
+	return &InvalidSyncError{
+		Name:   name,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// validName checks that name can be used as a directory entry.
+func (v *syncValidator) validName(name string) error {
+	switch {
+	case name == "":
+		return v.fail(name, "empty name")
+	case name == "." || name == "..":
+		return v.fail(name, "reserved name")
+	case strings.ContainsAny(name, "/\x00"):
+		return v.fail(name, "name contains a slash or NUL")
+	case name == ".bazil":
+		return v.fail(name, "reserved name")
+	case v.root && name == ".snap":
+		return v.fail(name, "reserved name")
+	}
+	return nil
+}
+
+func (v *syncValidator) validManifest(name string, m *wirecas.Manifest) error {
+	if m == nil {
+		return v.fail(name, "file without manifest")
+	}
+	// rejects private and reserved keys
+	var k cas.Key
+	if err := k.UnmarshalBinary(m.Root); err != nil {
+		return v.fail(name, "bad manifest root: %v", err)
+	}
+	if m.ChunkSize < blobs.MinChunkSize || m.ChunkSize > maxSyncChunkSize {
+		return v.fail(name, "chunk size out of range: %d", m.ChunkSize)
+	}
+	if m.Fanout < 2 || m.Fanout > maxSyncChunkSize/cas.KeySize {
+		return v.fail(name, "fanout out of range: %d", m.Fanout)
+	}
+	return nil
+}
+
+// check validates the next batch of entries.
+func (v *syncValidator) check(dirents []*wirepeer.Dirent) error {
+	for _, de := range dirents {
+		if de == nil {
+			return v.fail("", "missing entry")
+		}
+		if err := v.validName(de.Name); err != nil {
+			return err
+		}
+		if v.seen && de.Name <= v.prev {
+			if de.Name == v.prev {
+				return v.fail(de.Name, "duplicate entry")
+			}
+			return v.fail(de.Name, "entry out of order, after %q", v.prev)
+		}
+		v.prev = de.Name
+		v.seen = true
+
+		switch t := de.Type.(type) {
+		case *wirepeer.Dirent_File:
+			if t.File == nil {
+				return v.fail(de.Name, "file without manifest")
+			}
+			if err := v.validManifest(de.Name, t.File.Manifest); err != nil {
+				return err
+			}
+		case *wirepeer.Dirent_Dir, *wirepeer.Dirent_Tombstone:
+			// nothing to check
+		default:
+			return v.fail(de.Name, "unknown entry type")
+		}
+	}
+	return nil
+}
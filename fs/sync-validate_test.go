@@ -0,0 +1,163 @@
+package fs
+
+import (
+	"path"
+	"strings"
+	"syscall"
+	"testing"
+
+	"bazil.org/bazil/cas"
+	"bazil.org/bazil/cas/blobs"
+	wirecas "bazil.org/bazil/cas/wire"
+	wirepeer "bazil.org/bazil/peer/wire"
+	"github.com/golang/protobuf/proto"
+)
+
+func syncFile(name string, chunkSize, fanout uint32) *wirepeer.Dirent {
+	return &wirepeer.Dirent{
+		Name: name,
+		Type: &wirepeer.Dirent_File{
+			File: &wirepeer.File{
+				Manifest: &wirecas.Manifest{
+					Root:      cas.Empty.Bytes(),
+					ChunkSize: chunkSize,
+					Fanout:    fanout,
+				},
+			},
+		},
+	}
+}
+
+func syncDir(name string) *wirepeer.Dirent {
+	return &wirepeer.Dirent{
+		Name: name,
+		Type: &wirepeer.Dirent_Dir{Dir: &wirepeer.Dir{}},
+	}
+}
+
+func TestSyncValidate(t *testing.T) {
+	private := cas.NewKeyPrivateNum(42)
+	privateFile := syncFile("a", blobs.MinChunkSize, 64)
+	privateFile.GetFile().Manifest.Root = private.Bytes()
+
+	tests := []struct {
+		name    string
+		root    bool
+		batches [][]*wirepeer.Dirent
+		// substring of the error, or empty if valid
+		err string
+	}{
+		{"ok", true, [][]*wirepeer.Dirent{
+			{syncDir("a"), syncFile("b", blobs.MinChunkSize, 64)},
+			{syncDir("c")},
+		}, ""},
+		{"snap below root", false, [][]*wirepeer.Dirent{{syncDir(".snap")}}, ""},
+		{"empty", false, [][]*wirepeer.Dirent{{syncDir("")}}, "empty name"},
+		{"dot", false, [][]*wirepeer.Dirent{{syncDir(".")}}, "reserved name"},
+		{"dotdot", false, [][]*wirepeer.Dirent{{syncDir("..")}}, "reserved name"},
+		{"slash", false, [][]*wirepeer.Dirent{{syncDir("a/b")}}, "slash"},
+		{"nul", false, [][]*wirepeer.Dirent{{syncDir("a\x00")}}, "NUL"},
+		{"bazil", false, [][]*wirepeer.Dirent{{syncDir(".bazil")}}, "reserved name"},
+		{"snap in root", true, [][]*wirepeer.Dirent{{syncDir(".snap")}}, "reserved name"},
+		{"duplicate", false, [][]*wirepeer.Dirent{{syncDir("a"), syncDir("a")}}, "duplicate"},
+		{"unsorted", false, [][]*wirepeer.Dirent{{syncDir("b"), syncDir("a")}}, "out of order"},
+		{"unsorted across batches", false, [][]*wirepeer.Dirent{{syncDir("b")}, {syncDir("a")}}, "out of order"},
+		{"no type", false, [][]*wirepeer.Dirent{{{Name: "a"}}}, "unknown entry type"},
+		{"no manifest", false, [][]*wirepeer.Dirent{{{Name: "a", Type: &wirepeer.Dirent_File{File: &wirepeer.File{}}}}}, "without manifest"},
+		{"small chunks", false, [][]*wirepeer.Dirent{{syncFile("a", 1, 64)}}, "chunk size"},
+		{"huge chunks", false, [][]*wirepeer.Dirent{{syncFile("a", 1<<30, 64)}}, "chunk size"},
+		{"small fanout", false, [][]*wirepeer.Dirent{{syncFile("a", blobs.MinChunkSize, 1)}}, "fanout"},
+		{"huge fanout", false, [][]*wirepeer.Dirent{{syncFile("a", blobs.MinChunkSize, 1<<30)}}, "fanout"},
+		{"private root", false, [][]*wirepeer.Dirent{{privateFile}}, "bad manifest root"},
+	}
+	for _, test := range tests {
+		v := syncValidator{root: test.root}
+		var err error
+		for _, batch := range test.batches {
+			if err = v.check(batch); err != nil {
+				break
+			}
+		}
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		case test.err != "" && err == nil:
+			t.Errorf("%s: expected error", test.name)
+		case test.err != "" && !strings.Contains(err.Error(), test.err):
+			t.Errorf("%s: wrong error: %v", test.name, err)
+		}
+		if err != nil {
+			if _, ok := err.(*InvalidSyncError); !ok {
+				t.Errorf("%s: wrong error type: %T", test.name, err)
+			}
+		}
+	}
+}
+
+func FuzzSyncValidateName(f *testing.F) {
+	for _, name := range []string{"a", "", ".", "..", "a/b", ".bazil", ".snap", "\x00"} {
+		f.Add(name, false)
+		f.Add(name, true)
+	}
+	f.Fuzz(func(t *testing.T, name string, root bool) {
+		v := syncValidator{root: root}
+		if err := v.validName(name); err != nil {
+			if _, ok := err.(*InvalidSyncError); !ok {
+				t.Errorf("wrong error type: %T", err)
+			}
+			return
+		}
+		// an accepted name is exactly one element of a path
+		p := path.Join("dir", name)
+		if g, e := path.Dir(p), "dir"; g != e {
+			t.Errorf("accepted name %q leaves its directory: %q", name, p)
+		}
+		if g, e := path.Base(p), name; g != e {
+			t.Errorf("accepted name %q does not round trip: %q", name, g)
+		}
+		if _, err := syscall.ByteSliceFromString(name); err != nil {
+			t.Errorf("accepted name %q is not usable as a path: %v", name, err)
+		}
+	})
+}
+
+func FuzzSyncValidateItems(f *testing.F) {
+	item := &wirepeer.VolumeSyncPullItem{
+		Children: []*wirepeer.Dirent{
+			syncDir("a"),
+			syncFile("b", blobs.MinChunkSize, 64),
+			{Name: "c", Type: &wirepeer.Dirent_Tombstone{Tombstone: &wirepeer.Tombstone{}}},
+		},
+	}
+	buf, err := proto.Marshal(item)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(buf, buf)
+	f.Fuzz(func(t *testing.T, first, second []byte) {
+		v := syncValidator{}
+		var names []string
+		for _, data := range [][]byte{first, second} {
+			var item wirepeer.VolumeSyncPullItem
+			if err := proto.Unmarshal(data, &item); err != nil {
+				return
+			}
+			if err := v.check(item.Children); err != nil {
+				return
+			}
+			for _, de := range item.Children {
+				names = append(names, de.Name)
+				if m := de.GetFile().GetManifest(); m != nil {
+					if m.ChunkSize < blobs.MinChunkSize || m.Fanout < 2 {
+						t.Errorf("accepted bad manifest: %v", m)
+					}
+				}
+			}
+		}
+		for i := 1; i < len(names); i++ {
+			if names[i-1] >= names[i] {
+				t.Errorf("accepted unsorted names: %q", names)
+			}
+		}
+	})
+}
@@ -2,9 +2,15 @@ package kv
 
 import (
 	"context"
+	"io"
 )
 
 type KV interface {
 	Get(ctx context.Context, key []byte) ([]byte, error)
 	Put(ctx context.Context, key, value []byte) error
+
+	// Close releases the resources held by the store. Stores that
+	// wrap other stores close them too. The store must not be used
+	// after Close.
+	io.Closer
 }
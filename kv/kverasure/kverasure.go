@@ -0,0 +1,324 @@
+// Package kverasure stores values erasure coded across multiple
+// key-value stores.
+//
+// Each value is split into data shards, and parity shards are
+// computed with Reed-Solomon coding. Every shard is stored in a
+// different underlying store, under the same key. The value can be
+// read back as long as any data shards worth of shards are
+// available, at a storage cost of (data+parity)/data times the value
+// size.
+//
+// A Put succeeds once at least WriteQuorum shards are stored, by
+// default one more than the data shards, so that a value just written
+// survives losing any one store.
+package kverasure
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sync"
+
+	"bazil.org/bazil/kv"
+	"bazil.org/bazil/util/reedsolomon"
+)
+
+// TooFewShardsError is returned when a value exists, but not enough
+// of its shards can be read to reconstruct it.
+type TooFewShardsError struct {
+	Key       []byte
+	Available int
+	Needed    int
+}
+
+var _ error = TooFewShardsError{}
+
+func (e TooFewShardsError) Error() string {
+	return fmt.Sprintf("too few shards for %x: %d available, %d needed", e.Key, e.Available, e.Needed)
+}
+
+// WriteQuorumError is returned when a Put stored fewer shards than
+// the write quorum. If at least data shards were stored, the value
+// can still be read, but with less redundancy than asked for.
+type WriteQuorumError struct {
+	Key    []byte
+	Stored int
+	Needed int
+	// Error from the first store that failed.
+	Err error
+}
+
+var _ error = WriteQuorumError{}
+
+func (e WriteQuorumError) Error() string {
+	return fmt.Sprintf("too few shards stored for %x: %d stored, %d needed: %v", e.Key, e.Stored, e.Needed, e.Err)
+}
+
+var errCorruptShard = errors.New("corrupt shard")
+
+// Shard header: data count, parity count, shard index, CRC-32 of
+// the rest of the shard, and uvarint length of the value.
+const (
+	shardFixedHeader = 3 + 4
+	shardMaxHeader   = shardFixedHeader + binary.MaxVarintLen64
+)
+
+type Erasure struct {
+	code   *reedsolomon.Code
+	stores []kv.KV
+
+	// Degraded is called when a value was read or written, but some
+	// of its shards were missing, corrupt or failed to store. The
+	// shards are identified by their index in the list of stores.
+	//
+	// Set Degraded before using the store. Degraded may be called
+	// concurrently.
+	Degraded func(key []byte, shards []int)
+
+	// WriteQuorum is the number of shards that must be stored for
+	// Put to succeed. It is set by New to one more than the data
+	// shards, or all shards if there is no parity. Values less than
+	// the data shards are treated as the data shards.
+	//
+	// Set WriteQuorum before using the store.
+	WriteQuorum int
+}
+
+var _ kv.KV = (*Erasure)(nil)
+
+// Validate checks that New accepts the data and parity shard
+// counts, without needing the stores.
+func Validate(data, parity int) error {
+	_, err := reedsolomon.New(data, parity)
+	return err
+}
+
+// New returns a store that spreads values over stores, as data
+// shards and parity shards. There must be exactly data+parity
+// stores. The stores are owned by the returned store, and closed
+// with it.
+//
+// Every shard of a value is stored under the value's key, so the
+// stores must not share their contents; two shards written to the
+// same place overwrite each other, and the value is lost.
+func New(data, parity int, stores ...kv.KV) (*Erasure, error) {
+	if len(stores) != data+parity {
+		return nil, fmt.Errorf("erasure coding %d+%d needs %d stores, got %d", data, parity, data+parity, len(stores))
+	}
+	code, err := reedsolomon.New(data, parity)
+	if err != nil {
+		return nil, err
+	}
+	quorum := data + 1
+	if quorum > len(stores) {
+		quorum = len(stores)
+	}
+	e := &Erasure{
+		code:        code,
+		stores:      stores,
+		WriteQuorum: quorum,
+	}
+	return e, nil
+}
+
+// Close closes all the stores, returning the first error.
+func (e *Erasure) Close() error {
+	var firstErr error
+	for _, s := range e.stores {
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (e *Erasure) degraded(key []byte, shards []int) {
+	if e.Degraded != nil && len(shards) > 0 {
+		e.Degraded(key, shards)
+	}
+}
+
+// shardSize returns the size of each shard, for a value of the given
+// size.
+func (e *Erasure) shardSize(size uint64) uint64 {
+	data := uint64(e.code.DataShards())
+	return (size + data - 1) / data
+}
+
+func (e *Erasure) encodeShard(idx int, size uint64, shard []byte) []byte {
+	buf := make([]byte, shardMaxHeader+len(shard))
+	buf[0] = byte(e.code.DataShards())
+	buf[1] = byte(e.code.ParityShards())
+	buf[2] = byte(idx)
+	n := shardFixedHeader
+	n += binary.PutUvarint(buf[n:], size)
+	n += copy(buf[n:], shard)
+	buf = buf[:n]
+	binary.BigEndian.PutUint32(buf[3:], crc32.ChecksumIEEE(buf[shardFixedHeader:]))
+	return buf
+}
+
+func (e *Erasure) decodeShard(idx int, buf []byte) (size uint64, shard []byte, err error) {
+	if len(buf) < shardFixedHeader ||
+		int(buf[0]) != e.code.DataShards() ||
+		int(buf[1]) != e.code.ParityShards() ||
+		int(buf[2]) != idx {
+		return 0, nil, errCorruptShard
+	}
+	if binary.BigEndian.Uint32(buf[3:]) != crc32.ChecksumIEEE(buf[shardFixedHeader:]) {
+		return 0, nil, errCorruptShard
+	}
+	size, n := binary.Uvarint(buf[shardFixedHeader:])
+	if n <= 0 {
+		return 0, nil, errCorruptShard
+	}
+	shard = buf[shardFixedHeader+n:]
+	if uint64(len(shard)) != e.shardSize(size) {
+		return 0, nil, errCorruptShard
+	}
+	return size, shard, nil
+}
+
+func (e *Erasure) Put(ctx context.Context, key, value []byte) error {
+	data := e.code.DataShards()
+	shardSize := int(e.shardSize(uint64(len(value))))
+	padded := make([]byte, shardSize*data)
+	copy(padded, value)
+	shards := make([][]byte, len(e.stores))
+	for i := 0; i < data; i++ {
+		shards[i] = padded[i*shardSize : (i+1)*shardSize]
+	}
+	if err := e.code.Encode(shards); err != nil {
+		return err
+	}
+
+	errs := make([]error, len(e.stores))
+	var wg sync.WaitGroup
+	for i, s := range e.stores {
+		wg.Add(1)
+		go func(i int, s kv.KV) {
+			defer wg.Done()
+			buf := e.encodeShard(i, uint64(len(value)), shards[i])
+			errs[i] = s.Put(ctx, key, buf)
+		}(i, s)
+	}
+	wg.Wait()
+
+	var failed []int
+	var firstErr error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, i)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	stored := len(e.stores) - len(failed)
+	if stored < data {
+		// not recoverable
+		return firstErr
+	}
+	e.degraded(key, failed)
+	quorum := e.WriteQuorum
+	if quorum < data {
+		quorum = data
+	}
+	if stored < quorum {
+		return WriteQuorumError{Key: key, Stored: stored, Needed: quorum, Err: firstErr}
+	}
+	return nil
+}
+
+type fetched struct {
+	size uint64
+	// nil if missing or corrupt
+	shards [][]byte
+	// indexes of missing or corrupt shards
+	bad      []int
+	notFound int
+	firstErr error
+}
+
+func (e *Erasure) fetch(ctx context.Context, key []byte) *fetched {
+	bufs := make([][]byte, len(e.stores))
+	errs := make([]error, len(e.stores))
+	var wg sync.WaitGroup
+	for i, s := range e.stores {
+		wg.Add(1)
+		go func(i int, s kv.KV) {
+			defer wg.Done()
+			bufs[i], errs[i] = s.Get(ctx, key)
+		}(i, s)
+	}
+	wg.Wait()
+
+	f := &fetched{
+		shards: make([][]byte, len(e.stores)),
+	}
+	haveSize := false
+	for i, err := range errs {
+		if err != nil {
+			if _, ok := err.(kv.NotFoundError); ok {
+				f.notFound++
+			} else if f.firstErr == nil {
+				f.firstErr = err
+			}
+			f.bad = append(f.bad, i)
+			continue
+		}
+		size, shard, err := e.decodeShard(i, bufs[i])
+		if err == nil && haveSize && size != f.size {
+			err = errCorruptShard
+		}
+		if err != nil {
+			f.bad = append(f.bad, i)
+			continue
+		}
+		f.size = size
+		haveSize = true
+		f.shards[i] = shard
+	}
+	return f
+}
+
+func (e *Erasure) Get(ctx context.Context, key []byte) ([]byte, error) {
+	f := e.fetch(ctx, key)
+	if f.notFound == len(e.stores) {
+		return nil, kv.NotFoundError{Key: key}
+	}
+	data := e.code.DataShards()
+	if available := len(e.stores) - len(f.bad); available < data {
+		if f.firstErr != nil {
+			return nil, f.firstErr
+		}
+		return nil, TooFewShardsError{Key: key, Available: available, Needed: data}
+	}
+	if err := e.code.Reconstruct(f.shards); err != nil {
+		return nil, err
+	}
+	e.degraded(key, f.bad)
+
+	value := make([]byte, 0, data*len(f.shards[0]))
+	for _, shard := range f.shards[:data] {
+		value = append(value, shard...)
+	}
+	return value[:f.size], nil
+}
+
+// Check reads all shards of the value, and returns the indexes of
+// the ones that are missing or corrupt. It does not call Degraded.
+//
+// If the value does not exist at all, returns kv.NotFoundError.
+func (e *Erasure) Check(ctx context.Context, key []byte) (bad []int, err error) {
+	f := e.fetch(ctx, key)
+	if f.notFound == len(e.stores) {
+		return nil, kv.NotFoundError{Key: key}
+	}
+	if f.firstErr != nil {
+		return f.bad, f.firstErr
+	}
+	return f.bad, nil
+}
@@ -0,0 +1,185 @@
+package kverasure_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/kv"
+	"bazil.org/bazil/kv/kverasure"
+	"bazil.org/bazil/kv/kvmock"
+)
+
+type failing struct{}
+
+var errFail = errors.New("store is down")
+
+func (failing) Get(ctx context.Context, key []byte) ([]byte, error) {
+	return nil, errFail
+}
+
+func (failing) Put(ctx context.Context, key, value []byte) error {
+	return errFail
+}
+
+func (failing) Close() error {
+	return nil
+}
+
+func setup(t testing.TB, data, parity int) (*kverasure.Erasure, []*kvmock.InMemory, *[][]int) {
+	var mems []*kvmock.InMemory
+	var stores []kv.KV
+	for i := 0; i < data+parity; i++ {
+		m := &kvmock.InMemory{}
+		mems = append(mems, m)
+		stores = append(stores, m)
+	}
+	e, err := kverasure.New(data, parity, stores...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var degraded [][]int
+	e.Degraded = func(key []byte, shards []int) {
+		mu.Lock()
+		defer mu.Unlock()
+		degraded = append(degraded, shards)
+	}
+	return e, mems, &degraded
+}
+
+func TestRoundtrip(t *testing.T) {
+	e, mems, degraded := setup(t, 3, 2)
+	ctx := context.Background()
+	for _, value := range []string{"", "x", "hello, world", string(bytes.Repeat([]byte("abcdefg"), 1000))} {
+		if err := e.Put(ctx, []byte("k"), []byte(value)); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		for _, m := range mems {
+			if len(m.Data) != 1 {
+				t.Fatalf("every store must hold one shard: %v", m.Data)
+			}
+		}
+		got, err := e.Get(ctx, []byte("k"))
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if string(got) != value {
+			t.Errorf("wrong value: %q != %q", got, value)
+		}
+	}
+	if len(*degraded) != 0 {
+		t.Errorf("unexpected degraded reports: %v", *degraded)
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	e, _, _ := setup(t, 2, 1)
+	ctx := context.Background()
+	_, err := e.Get(ctx, []byte("missing"))
+	if _, ok := err.(kv.NotFoundError); !ok {
+		t.Errorf("expected NotFoundError, got %T: %v", err, err)
+	}
+}
+
+func TestGetDegraded(t *testing.T) {
+	e, mems, degraded := setup(t, 3, 2)
+	ctx := context.Background()
+	value := bytes.Repeat([]byte("greetings"), 100)
+	if err := e.Put(ctx, []byte("k"), value); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// lose one shard, corrupt another
+	delete(mems[0].Data, "k")
+	buf := []byte(mems[3].Data["k"])
+	buf[len(buf)-1] ^= 0xFF
+	mems[3].Data["k"] = string(buf)
+
+	got, err := e.Get(ctx, []byte("k"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !bytes.Equal(got, value) {
+		t.Errorf("wrong value after reconstruction")
+	}
+	if g, e := *degraded, [][]int{{0, 3}}; !reflect.DeepEqual(g, e) {
+		t.Errorf("wrong degraded report: %v != %v", g, e)
+	}
+
+	bad, err := e.Check(ctx, []byte("k"))
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if g, e := bad, []int{0, 3}; !reflect.DeepEqual(g, e) {
+		t.Errorf("wrong bad shards: %v != %v", g, e)
+	}
+
+	// one more loss is too many
+	delete(mems[1].Data, "k")
+	_, err = e.Get(ctx, []byte("k"))
+	switch err := err.(type) {
+	case kverasure.TooFewShardsError:
+		if err.Available != 2 || err.Needed != 3 {
+			t.Errorf("wrong shard counts: %v", err)
+		}
+	default:
+		t.Errorf("expected TooFewShardsError, got %T: %v", err, err)
+	}
+}
+
+func TestPutPartial(t *testing.T) {
+	a := &kvmock.InMemory{}
+	b := &kvmock.InMemory{}
+	e, err := kverasure.New(2, 1, a, failing{}, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var degraded [][]int
+	e.Degraded = func(key []byte, shards []int) {
+		degraded = append(degraded, shards)
+	}
+	ctx := context.Background()
+	e.WriteQuorum = 2
+	if err := e.Put(ctx, []byte("k"), []byte("hello")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if g, e := degraded, [][]int{{1}}; !reflect.DeepEqual(g, e) {
+		t.Errorf("wrong degraded report: %v != %v", g, e)
+	}
+	got, err := e.Get(ctx, []byte("k"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if g, e := string(got), "hello"; g != e {
+		t.Errorf("wrong value: %q != %q", g, e)
+	}
+
+	// below the write quorum, but readable
+	e.WriteQuorum = 3
+	err = e.Put(ctx, []byte("k3"), []byte("hello"))
+	if qe, ok := err.(kverasure.WriteQuorumError); !ok {
+		t.Errorf("expected WriteQuorumError, got %T: %v", err, err)
+	} else if qe.Stored != 2 || qe.Needed != 3 || qe.Err != errFail {
+		t.Errorf("wrong WriteQuorumError: %+v", qe)
+	}
+	if g, e := degraded, [][]int{{1}, {1}, {1}}; !reflect.DeepEqual(g, e) {
+		t.Errorf("wrong degraded report: %v != %v", g, e)
+	}
+
+	e2, err := kverasure.New(2, 1, a, failing{}, failing{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e2.Put(ctx, []byte("k2"), []byte("hello")); err != errFail {
+		t.Errorf("expected Put to fail, got %v", err)
+	}
+}
+
+func TestNewWrongCount(t *testing.T) {
+	if _, err := kverasure.New(2, 1, &kvmock.InMemory{}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -2,21 +2,140 @@ package kvfiles
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
+	"fmt"
+	"hash/crc32"
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 
 	"bazil.org/bazil/kv"
 )
 
+// Objects are stored in two levels of subdirectories named by the
+// first hex digits of the key, as in "ab/cd/abcdef....data", to keep
+// directories reasonably small.
+//
+// Every object ends in a trailer of the data length, its CRC-32, and
+// a magic marker, so truncated objects can be told apart from valid
+// ones.
+//
+// Objects from the old flat layout have no trailer, and cannot be
+// verified. They are moved into the legacy directory once, and the
+// marker file records that this has been done.
+const (
+	trailerSize  = 8 + 4 + 4
+	trailerMagic = "bzK1"
+	dataSuffix   = ".data"
+	legacyDir    = "legacy"
+	layoutMarker = "layout-v2"
+)
+
+// CorruptError is returned when an object exists but is truncated or
+// otherwise damaged, for example by a crash during Put.
+type CorruptError struct {
+	Key []byte
+}
+
+var _ error = CorruptError{}
+
+func (c CorruptError) Error() string {
+	return fmt.Sprintf("corrupt object: %x", c.Key)
+}
+
 type KVFiles struct {
 	path string
+	// Whether objects from the old layout exist.
+	legacy bool
 }
 
 var _ kv.KV = (*KVFiles)(nil)
 
-func (k *KVFiles) Put(ctx context.Context, key, value []byte) error {
+// shardDir returns the directory, relative to the store, for objects
+// with this hex key.
+func shardDir(safe string) string {
+	for len(safe) < 4 {
+		safe += "0"
+	}
+	return path.Join(safe[0:2], safe[2:4])
+}
+
+func (k *KVFiles) objectPath(key []byte) string {
+	safe := hex.EncodeToString(key)
+	return path.Join(k.path, shardDir(safe), safe+dataSuffix)
+}
+
+func (k *KVFiles) legacyPath(key []byte) string {
+	return path.Join(k.path, legacyDir, hex.EncodeToString(key)+dataSuffix)
+}
+
+// syncDir makes changes to the directory entries durable.
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
+
+// makeDir creates the directory and any missing parents below the
+// store root, making the new entries durable.
+func (k *KVFiles) makeDir(dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return nil
+	}
+	parent := path.Dir(dir)
+	if parent != k.path {
+		if err := k.makeDir(parent); err != nil {
+			return err
+		}
+	}
+	if err := os.Mkdir(dir, 0700); err != nil && !os.IsExist(err) {
+		return err
+	}
+	return syncDir(parent)
+}
+
+func appendTrailer(value []byte) []byte {
+	buf := make([]byte, len(value)+trailerSize)
+	n := copy(buf, value)
+	binary.BigEndian.PutUint64(buf[n:], uint64(len(value)))
+	binary.BigEndian.PutUint32(buf[n+8:], crc32.ChecksumIEEE(value))
+	copy(buf[n+12:], trailerMagic)
+	return buf
+}
+
+// checkTrailer returns the data without the trailer, or false if
+// the trailer is missing or does not match the data.
+func checkTrailer(buf []byte) ([]byte, bool) {
+	if len(buf) < trailerSize {
+		return nil, false
+	}
+	n := len(buf) - trailerSize
+	trailer := buf[n:]
+	if string(trailer[12:]) != trailerMagic {
+		return nil, false
+	}
+	if binary.BigEndian.Uint64(trailer[0:8]) != uint64(n) {
+		return nil, false
+	}
+	if binary.BigEndian.Uint32(trailer[8:12]) != crc32.ChecksumIEEE(buf[:n]) {
+		return nil, false
+	}
+	return buf[:n], true
+}
+
+// writeObject durably stores the object at path, unless an intact
+// copy exists already. A corrupt existing object is replaced.
+func (k *KVFiles) writeObject(p string, buf []byte) error {
+	dir := path.Dir(p)
+	if err := k.makeDir(dir); err != nil {
+		return err
+	}
+
 	tmp, err := ioutil.TempFile(k.path, "put-")
 	if err != nil {
 		return err
@@ -27,26 +146,72 @@ func (k *KVFiles) Put(ctx context.Context, key, value []byte) error {
 		_ = os.Remove(tmp.Name())
 	}()
 
-	_, err = tmp.Write(value)
+	_, err = tmp.Write(buf)
 	if err != nil {
 		return err
 	}
-	path := path.Join(k.path, hex.EncodeToString(key)+".data")
-	err = os.Link(tmp.Name(), path)
+	// the content must be on disk before it is visible under its
+	// final name
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	err = os.Link(tmp.Name(), p)
 	if err != nil {
 		// EEXIST is safe to ignore here, that just means we
 		// successfully de-duplicated content
 		if !os.IsExist(err) {
 			return err
 		}
+		if intact(p, buf) {
+			return nil
+		}
+		if err := os.Rename(tmp.Name(), p); err != nil {
+			return err
+		}
+	}
+	return syncDir(dir)
+}
+
+// intact reports whether the object at path has the size and
+// trailer of buf. Objects are only visible once fully written, so
+// this catches truncation without reading the whole object; other
+// damage is found by Get.
+func intact(p string, buf []byte) bool {
+	f, err := os.Open(p)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil || fi.Size() != int64(len(buf)) {
+		return false
+	}
+	want := buf[len(buf)-trailerSize:]
+	trailer := make([]byte, trailerSize)
+	if _, err := f.ReadAt(trailer, int64(len(buf)-trailerSize)); err != nil {
+		return false
+	}
+	return string(trailer) == string(want)
+}
+
+func (k *KVFiles) Put(ctx context.Context, key, value []byte) error {
+	if err := k.writeObject(k.objectPath(key), appendTrailer(value)); err != nil {
+		return err
+	}
+	if k.legacy {
+		// superseded by the verifiable copy
+		if err := os.Remove(k.legacyPath(key)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
 	return nil
 }
 
 func (k *KVFiles) Get(ctx context.Context, key []byte) ([]byte, error) {
-	safe := hex.EncodeToString(key)
-	path := path.Join(k.path, safe+".data")
-	data, err := ioutil.ReadFile(path)
+	data, err := ioutil.ReadFile(k.objectPath(key))
+	if os.IsNotExist(err) && k.legacy {
+		return k.getLegacy(key)
+	}
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, kv.NotFoundError{
@@ -56,21 +221,130 @@ func (k *KVFiles) Get(ctx context.Context, key []byte) ([]byte, error) {
 		// no specific error to return, so just pass it through
 		return nil, err
 	}
+	value, ok := checkTrailer(data)
+	if !ok {
+		return nil, CorruptError{Key: key}
+	}
+	return value, nil
+}
+
+// Close does nothing, as no resources are held between calls.
+func (k *KVFiles) Close() error {
+	return nil
+}
+
+// getLegacy returns an object from the old layout. Nothing can tell
+// whether the object is complete, except that an empty one is left
+// by a crash; callers verify the content as before the migration.
+func (k *KVFiles) getLegacy(key []byte) ([]byte, error) {
+	data, err := ioutil.ReadFile(k.legacyPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, kv.NotFoundError{
+				Key: key,
+			}
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, CorruptError{Key: key}
+	}
 	return data, nil
 }
 
-func Open(path string) (*KVFiles, error) {
-	return &KVFiles{
-		path: path,
-	}, nil
+// migrate moves objects from the old layout, where all objects were
+// directly in the top directory without a trailer, into the legacy
+// directory. This is done once; the marker file records it.
+func (k *KVFiles) migrate() error {
+	marker := path.Join(k.path, layoutMarker)
+	if _, err := os.Stat(marker); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	fis, err := ioutil.ReadDir(k.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// nothing to migrate
+			return nil
+		}
+		return err
+	}
+	legacy := path.Join(k.path, legacyDir)
+	moved := false
+	for _, fi := range fis {
+		name := fi.Name()
+		if !fi.Mode().IsRegular() || !strings.HasSuffix(name, dataSuffix) {
+			continue
+		}
+		if _, err := hex.DecodeString(strings.TrimSuffix(name, dataSuffix)); err != nil {
+			continue
+		}
+		if err := k.makeDir(legacy); err != nil {
+			return err
+		}
+		if err := os.Rename(path.Join(k.path, name), path.Join(legacy, name)); err != nil {
+			if os.IsNotExist(err) {
+				// migrated concurrently
+				continue
+			}
+			return err
+		}
+		moved = true
+	}
+	if moved {
+		if err := syncDir(legacy); err != nil {
+			return err
+		}
+	}
+	return writeMarker(k.path)
 }
 
-func Create(path string) error {
-	// this may later include more
+// writeMarker durably records that the store in dir uses the current
+// layout.
+func writeMarker(dir string) error {
+	f, err := os.OpenFile(path.Join(dir, layoutMarker), os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// Open opens the store in the directory. Objects stored in the old
+// flat layout are moved aside the first time, and are still readable.
+func Open(p string) (*KVFiles, error) {
+	k := &KVFiles{
+		path: p,
+	}
+	if err := k.migrate(); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path.Join(p, legacyDir)); err == nil {
+		k.legacy = true
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return k, nil
+}
 
+func Create(path string) error {
 	err := os.Mkdir(path, 0700)
-	if err != nil && !os.IsExist(err) {
+	if err != nil {
+		if os.IsExist(err) {
+			// may hold objects in the old layout; Open migrates
+			// them
+			return nil
+		}
 		return err
 	}
-	return nil
+	// nothing to migrate
+	return writeMarker(path)
 }
@@ -2,6 +2,9 @@ package kvfiles_test
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"bazil.org/bazil/kv"
@@ -94,3 +97,159 @@ func TestGetNotFoundError(t *testing.T) {
 		t.Errorf("NotFoundError Key is wrong: %x != %x", g, w)
 	}
 }
+
+func TestLayout(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	k, err := kvfiles.Open(temp.Path)
+	if err != nil {
+		t.Fatalf("kvfiles.Open fail: %v\n", err)
+	}
+	ctx := context.Background()
+	if err := k.Put(ctx, []byte("\xab\xcd\xef"), []byte("foobar")); err != nil {
+		t.Fatalf("k.Put fail: %v\n", err)
+	}
+	if _, err := os.Stat(filepath.Join(temp.Path, "ab", "cd", "abcdef.data")); err != nil {
+		t.Errorf("object not in sharded directory: %v", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	// objects in the old flat layout
+	if err := ioutil.WriteFile(filepath.Join(temp.Path, "71757578.data"), []byte("foobar"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// left empty by a crash
+	if err := ioutil.WriteFile(filepath.Join(temp.Path, "656d707479.data"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := kvfiles.Open(temp.Path)
+	if err != nil {
+		t.Fatalf("kvfiles.Open fail: %v\n", err)
+	}
+	ctx := context.Background()
+	data, err := k.Get(ctx, []byte("quux"))
+	if err != nil {
+		t.Fatalf("k.Get failed: %v", err)
+	}
+	if g, e := string(data), "foobar"; g != e {
+		t.Errorf("wrong content after migration: %q != %q", g, e)
+	}
+	if _, err := k.Get(ctx, []byte("empty")); err == nil {
+		t.Errorf("empty old object must not be returned")
+	} else if _, ok := err.(kvfiles.CorruptError); !ok {
+		t.Errorf("wrong error for empty old object: %T: %v", err, err)
+	}
+
+	fis, err := ioutil.ReadDir(temp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range fis {
+		if !fi.IsDir() && fi.Name() != "layout-v2" {
+			t.Errorf("file left in top directory: %s", fi.Name())
+		}
+	}
+	// old objects cannot be verified, so they must not gain a
+	// trailer
+	for _, name := range []string{"71757578.data", "656d707479.data"} {
+		if _, err := os.Stat(filepath.Join(temp.Path, "legacy", name)); err != nil {
+			t.Errorf("old object not moved aside: %v", err)
+		}
+	}
+
+	// a verifiable copy replaces the old one
+	if err := k.Put(ctx, []byte("empty"), []byte("again")); err != nil {
+		t.Fatalf("k.Put fail: %v\n", err)
+	}
+	data, err = k.Get(ctx, []byte("empty"))
+	if err != nil {
+		t.Fatalf("k.Get failed: %v", err)
+	}
+	if g, e := string(data), "again"; g != e {
+		t.Errorf("wrong content after Put: %q != %q", g, e)
+	}
+	if _, err := os.Stat(filepath.Join(temp.Path, "legacy", "656d707479.data")); !os.IsNotExist(err) {
+		t.Errorf("replaced old object not removed: %v", err)
+	}
+}
+
+func TestMigrateOnce(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	if err := kvfiles.Create(temp.Path); err != nil {
+		t.Fatalf("kvfiles.Create fail: %v\n", err)
+	}
+	if _, err := kvfiles.Open(temp.Path); err != nil {
+		t.Fatalf("kvfiles.Open fail: %v\n", err)
+	}
+	// not from the old layout, as the store was created new
+	p := filepath.Join(temp.Path, "71757578.data")
+	if err := ioutil.WriteFile(p, []byte("foobar"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kvfiles.Open(temp.Path); err != nil {
+		t.Fatalf("kvfiles.Open fail: %v\n", err)
+	}
+	if _, err := os.Stat(p); err != nil {
+		t.Errorf("migrated more than once: %v", err)
+	}
+}
+
+func TestGetTruncated(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	k, err := kvfiles.Open(temp.Path)
+	if err != nil {
+		t.Fatalf("kvfiles.Open fail: %v\n", err)
+	}
+	ctx := context.Background()
+	if err := k.Put(ctx, []byte("quux"), []byte("foobar")); err != nil {
+		t.Fatalf("k.Put fail: %v\n", err)
+	}
+	p := filepath.Join(temp.Path, "71", "75", "71757578.data")
+	for _, size := range []int64{0, 5} {
+		if err := os.Truncate(p, size); err != nil {
+			t.Fatal(err)
+		}
+		_, err := k.Get(ctx, []byte("quux"))
+		if _, ok := err.(kvfiles.CorruptError); !ok {
+			t.Errorf("truncated to %d: expected CorruptError, got %T: %v", size, err, err)
+		}
+	}
+}
+
+func TestPutRepairsCorrupt(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	k, err := kvfiles.Open(temp.Path)
+	if err != nil {
+		t.Fatalf("kvfiles.Open fail: %v\n", err)
+	}
+	ctx := context.Background()
+	if err := k.Put(ctx, []byte("quux"), []byte("foobar")); err != nil {
+		t.Fatalf("k.Put fail: %v\n", err)
+	}
+	p := filepath.Join(temp.Path, "71", "75", "71757578.data")
+	if err := os.Truncate(p, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Put(ctx, []byte("quux"), []byte("foobar")); err != nil {
+		t.Fatalf("k.Put fail: %v\n", err)
+	}
+	got, err := k.Get(ctx, []byte("quux"))
+	if err != nil {
+		t.Fatalf("k.Get fail: %v\n", err)
+	}
+	if g, e := string(got), "foobar"; g != e {
+		t.Errorf("wrong value: %q != %q", g, e)
+	}
+}
@@ -27,3 +27,8 @@ func (m *InMemory) Put(ctx context.Context, key, value []byte) error {
 	m.Data[string(key)] = string(value)
 	return nil
 }
+
+// Close does nothing; the data stays available.
+func (m *InMemory) Close() error {
+	return nil
+}
@@ -11,6 +11,9 @@ type Multi struct {
 	list []kv.KV
 }
 
+// New returns a store that puts values in all the stores, and gets
+// them from the first one that has the key. The stores are owned by
+// the returned store, and closed with it.
 func New(k ...kv.KV) *Multi {
 	return &Multi{list: k}
 }
@@ -58,3 +61,14 @@ func (m *Multi) Put(ctx context.Context, key, value []byte) error {
 	}
 	return nil
 }
+
+// Close closes all the stores, returning the first error.
+func (m *Multi) Close() error {
+	var firstErr error
+	for _, k := range m.list {
+		if err := k.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,615 @@
+// Package kvpack stores values appended to large segment files,
+// instead of one file per value.
+//
+// Each record in a segment holds the key, the value and a checksum.
+// A bolt database indexes keys to their records. Records are written
+// to the segment before they are indexed; concurrent writes share
+// one sync of the segment and one index transaction. After a crash,
+// records past the indexed end of the segments are indexed on open,
+// and a partially written record at the end of the last segment is
+// cut off. Damaged records found elsewhere are logged and skipped.
+//
+// As kv.KV has no way to remove values, segments never contain
+// garbage, and are never compacted.
+package kvpack
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"bazil.org/bazil/kv"
+	"github.com/boltdb/bolt"
+)
+
+// Start a new segment when the current one would grow past this.
+const defaultSegmentSize = 64 << 20
+
+const (
+	indexName     = "index.db"
+	segmentSuffix = ".seg"
+)
+
+var (
+	// Key is the value key, value is location.
+	bucketObjects = []byte("objects")
+	// Key is segment number, value is the offset up to which the
+	// segment has been indexed.
+	bucketSegments = []byte("segments")
+)
+
+// Record header: key length, value length, CRC-32 of key and value.
+const recordHeader = 4 + 4 + 4
+
+// Keys are at least one byte, and at most this long. This keeps the
+// search for intact records in damaged segments cheap.
+const maxKeySize = 1024
+
+// How much of a damaged segment to read at once, when searching for
+// the next intact record.
+const scanWindow = 64 << 10
+
+// location of a record, as stored in the index: segment number,
+// offset of the record, and value length.
+const locationSize = 4 + 8 + 4
+
+type location struct {
+	segment uint32
+	offset  int64
+	length  uint32
+}
+
+func (l *location) marshal() []byte {
+	buf := make([]byte, locationSize)
+	binary.BigEndian.PutUint32(buf[0:4], l.segment)
+	binary.BigEndian.PutUint64(buf[4:12], uint64(l.offset))
+	binary.BigEndian.PutUint32(buf[12:16], l.length)
+	return buf
+}
+
+// end returns the offset just past the record.
+func (l *location) end(keyLen int) int64 {
+	return l.offset + recordHeader + int64(keyLen) + int64(l.length)
+}
+
+func (l *location) unmarshal(buf []byte) error {
+	if len(buf) != locationSize {
+		return fmt.Errorf("kvpack: corrupt index entry")
+	}
+	l.segment = binary.BigEndian.Uint32(buf[0:4])
+	l.offset = int64(binary.BigEndian.Uint64(buf[4:12]))
+	l.length = binary.BigEndian.Uint32(buf[12:16])
+	return nil
+}
+
+func segmentKey(id uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], id)
+	return buf[:]
+}
+
+// CorruptError is returned when the record for a key cannot be read
+// back intact.
+type CorruptError struct {
+	Key []byte
+}
+
+var _ error = CorruptError{}
+
+func (c CorruptError) Error() string {
+	return fmt.Sprintf("kvpack: corrupt record for %x", c.Key)
+}
+
+type Pack struct {
+	dir         string
+	index       *bolt.DB
+	segmentSize int64
+
+	// Held while appending.
+	writeMu sync.Mutex
+	// Segment being appended to, and its size.
+	active     uint32
+	activeSize int64
+
+	// Held while making appended records durable and indexing them.
+	flushMu sync.Mutex
+	// Sequence number of the last record indexed.
+	flushed uint64
+
+	mu sync.RWMutex
+	// Open segments. Segments are only opened once.
+	segments map[uint32]*os.File
+	// Records appended but not yet indexed, by key.
+	pending map[string]pendingRecord
+	// Sequence number of the last record appended.
+	written uint64
+}
+
+type pendingRecord struct {
+	loc location
+	seq uint64
+}
+
+var _ kv.KV = (*Pack)(nil)
+
+// Open opens the pack in the directory, creating it if needed.
+func Open(dir string) (*Pack, error) {
+	return open(dir, defaultSegmentSize)
+}
+
+func open(dir string, segmentSize int64) (*Pack, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	index, err := bolt.Open(filepath.Join(dir, indexName), 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	p := &Pack{
+		dir:         dir,
+		index:       index,
+		segmentSize: segmentSize,
+		segments:    make(map[uint32]*os.File),
+		pending:     make(map[string]pendingRecord),
+	}
+	create := func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(bucketObjects); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(bucketSegments); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := index.Update(create); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+	if err := p.recover(); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Close closes the pack. It must not be used after Close. Records
+// written but not indexed yet are indexed on next open.
+func (p *Pack) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var firstErr error
+	for id, f := range p.segments {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(p.segments, id)
+	}
+	if err := p.index.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+func (p *Pack) segmentPath(id uint32) string {
+	return filepath.Join(p.dir, fmt.Sprintf("%08x%s", id, segmentSuffix))
+}
+
+// listSegments returns the numbers of the existing segments, in
+// order.
+func (p *Pack) listSegments() ([]uint32, error) {
+	fis, err := ioutil.ReadDir(p.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint32
+	for _, fi := range fis {
+		name := fi.Name()
+		if !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 16, 32)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint32(id))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// segment returns the segment file, opening it if needed.
+func (p *Pack) segment(id uint32) (*os.File, error) {
+	p.mu.RLock()
+	f, ok := p.segments[id]
+	p.mu.RUnlock()
+	if ok {
+		return f, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if f, ok := p.segments[id]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(p.segmentPath(id), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	p.segments[id] = f
+	return f, nil
+}
+
+// recover indexes records written after the last indexed offset of
+// each segment, and cuts off a partial record at the end of the
+// last segment.
+//
+// Only the last segment is ever appended to, so only it can end in a
+// torn write. Damage anywhere else is corruption, and cutting it off
+// would lose the intact records after it.
+func (p *Pack) recover() error {
+	ids, err := p.listSegments()
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		ids = []uint32{0}
+	}
+	for i, id := range ids {
+		last := i == len(ids)-1
+		if err := p.recoverSegment(id, last); err != nil {
+			return fmt.Errorf("kvpack: recovering segment %d: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (p *Pack) recoverSegment(id uint32, last bool) error {
+	var start int64
+	var indexed bool
+	view := func(tx *bolt.Tx) error {
+		if v := tx.Bucket(bucketSegments).Get(segmentKey(id)); v != nil {
+			if len(v) != 8 {
+				return fmt.Errorf("corrupt segment entry")
+			}
+			start = int64(binary.BigEndian.Uint64(v))
+			indexed = true
+		}
+		return nil
+	}
+	if err := p.index.View(view); err != nil {
+		return err
+	}
+
+	f, err := p.segment(id)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := fi.Size()
+	if indexed && start == size {
+		if last {
+			p.active = id
+			p.activeSize = size
+		}
+		return nil
+	}
+
+	// index any records not yet indexed
+	end := start
+	torn := false
+	update := func(tx *bolt.Tx) error {
+		objects := tx.Bucket(bucketObjects)
+		for end < size {
+			key, length, ok := readRecord(f, end, size)
+			if !ok {
+				next, err := nextRecord(f, end, size)
+				if err != nil {
+					return err
+				}
+				if next == size && last {
+					// partial record from an interrupted write
+					torn = true
+					break
+				}
+				log.Printf("kvpack: %s: skipping %d corrupt bytes at offset %d", p.segmentPath(id), next-end, end)
+				end = next
+				continue
+			}
+			if objects.Get(key) == nil {
+				loc := location{segment: id, offset: end, length: length}
+				if err := objects.Put(key, loc.marshal()); err != nil {
+					return err
+				}
+			}
+			end += recordHeader + int64(len(key)) + int64(length)
+		}
+		segments := tx.Bucket(bucketSegments)
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(end))
+		return segments.Put(segmentKey(id), buf[:])
+	}
+	if err := p.index.Update(update); err != nil {
+		return err
+	}
+	if torn {
+		if err := f.Truncate(end); err != nil {
+			return err
+		}
+	}
+	if last {
+		p.active = id
+		p.activeSize = end
+	}
+	return nil
+}
+
+// nextRecord returns the offset of the first intact record after the
+// damaged one at off, or size if there is none.
+func nextRecord(f *os.File, off int64, size int64) (int64, error) {
+	// if only the contents are damaged, the header says where the
+	// next record is
+	var hdr [recordHeader]byte
+	if _, err := f.ReadAt(hdr[:], off); err == nil {
+		next := off + recordHeader + int64(binary.BigEndian.Uint32(hdr[0:4])) + int64(binary.BigEndian.Uint32(hdr[4:8]))
+		if next < size {
+			if _, _, ok := readRecord(f, next, size); ok {
+				return next, nil
+			}
+		}
+	}
+
+	// otherwise, look for one at every offset; only headers that
+	// could be right are checked further, so this reads the segment
+	// about once
+	buf := make([]byte, scanWindow+recordHeader)
+	for base := off + 1; base+recordHeader <= size; base += scanWindow {
+		n, err := f.ReadAt(buf, base)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		for i := 0; i < scanWindow && i+recordHeader <= n; i++ {
+			at := base + int64(i)
+			if !plausibleHeader(buf[i:i+recordHeader], at, size) {
+				continue
+			}
+			if _, _, ok := readRecord(f, at, size); ok {
+				return at, nil
+			}
+		}
+	}
+	return size, nil
+}
+
+// plausibleHeader reports whether hdr could start a record at off.
+func plausibleHeader(hdr []byte, off int64, size int64) bool {
+	keyLen := binary.BigEndian.Uint32(hdr[0:4])
+	length := binary.BigEndian.Uint32(hdr[4:8])
+	return keyLen > 0 && keyLen <= maxKeySize &&
+		off+recordHeader+int64(keyLen)+int64(length) <= size
+}
+
+// readRecord reads the record header and key at off, and verifies
+// the checksum. Returns false if there is no intact record there.
+func readRecord(f io.ReaderAt, off int64, size int64) (key []byte, length uint32, ok bool) {
+	var hdr [recordHeader]byte
+	if _, err := f.ReadAt(hdr[:], off); err != nil {
+		return nil, 0, false
+	}
+	if !plausibleHeader(hdr[:], off, size) {
+		return nil, 0, false
+	}
+	keyLen := binary.BigEndian.Uint32(hdr[0:4])
+	length = binary.BigEndian.Uint32(hdr[4:8])
+	buf := make([]byte, int(keyLen)+int(length))
+	if _, err := f.ReadAt(buf, off+recordHeader); err != nil {
+		return nil, 0, false
+	}
+	if crc32.ChecksumIEEE(buf) != binary.BigEndian.Uint32(hdr[8:12]) {
+		return nil, 0, false
+	}
+	return buf[:keyLen], length, true
+}
+
+// lookup finds the record for the key, whether indexed yet or not.
+// The sequence number is 0 if the record is indexed.
+func (p *Pack) lookup(key []byte) (loc location, seq uint64, found bool, err error) {
+	p.mu.RLock()
+	r, ok := p.pending[string(key)]
+	p.mu.RUnlock()
+	if ok {
+		return r.loc, r.seq, true, nil
+	}
+	view := func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketObjects).Get(key)
+		if v == nil {
+			return nil
+		}
+		found = true
+		return loc.unmarshal(v)
+	}
+	err = p.index.View(view)
+	return loc, 0, found, err
+}
+
+func (p *Pack) Get(ctx context.Context, key []byte) ([]byte, error) {
+	loc, _, found, err := p.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, kv.NotFoundError{Key: key}
+	}
+	return p.read(key, loc)
+}
+
+// read reads and verifies the record at loc.
+func (p *Pack) read(key []byte, loc location) ([]byte, error) {
+	f, err := p.segment(loc.segment)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, recordHeader+len(key)+int(loc.length))
+	if _, err := f.ReadAt(buf, loc.offset); err != nil {
+		if err == io.EOF {
+			return nil, CorruptError{Key: key}
+		}
+		return nil, err
+	}
+	if binary.BigEndian.Uint32(buf[0:4]) != uint32(len(key)) ||
+		binary.BigEndian.Uint32(buf[4:8]) != loc.length ||
+		crc32.ChecksumIEEE(buf[recordHeader:]) != binary.BigEndian.Uint32(buf[8:12]) ||
+		string(buf[recordHeader:recordHeader+len(key)]) != string(key) {
+		return nil, CorruptError{Key: key}
+	}
+	return buf[recordHeader+len(key):], nil
+}
+
+// Put stores the value. It returns once the value is durable.
+func (p *Pack) Put(ctx context.Context, key, value []byte) error {
+	if len(key) == 0 || len(key) > maxKeySize {
+		return fmt.Errorf("kvpack: key must be 1 to %d bytes: %d", maxKeySize, len(key))
+	}
+	seq, err := p.append(key, value)
+	if err != nil {
+		return err
+	}
+	return p.flush(seq)
+}
+
+// append writes a record for the key to the active segment, unless
+// an intact one exists. Returns the sequence number to flush before
+// the record is durable, or 0 if it already is.
+func (p *Pack) append(key, value []byte) (uint64, error) {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	if loc, seq, found, err := p.lookup(key); err != nil {
+		return 0, err
+	} else if found {
+		_, err := p.read(key, loc)
+		if _, ok := err.(CorruptError); !ok {
+			// content is immutable; already stored
+			return seq, err
+		}
+		// append a new copy, and point the index to it
+	}
+
+	recordSize := recordHeader + int64(len(key)) + int64(len(value))
+	if p.activeSize > 0 && p.activeSize+recordSize > p.segmentSize {
+		p.active++
+		p.activeSize = 0
+	}
+	f, err := p.segment(p.active)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, recordSize)
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(key)))
+	binary.BigEndian.PutUint32(buf[4:8], uint32(len(value)))
+	copy(buf[recordHeader:], key)
+	copy(buf[recordHeader+len(key):], value)
+	binary.BigEndian.PutUint32(buf[8:12], crc32.ChecksumIEEE(buf[recordHeader:]))
+
+	off := p.activeSize
+	if _, err := f.WriteAt(buf, off); err != nil {
+		// cut off whatever was written, to not leave a partial
+		// record in the middle of the segment
+		_ = f.Truncate(off)
+		return 0, err
+	}
+	p.activeSize = off + recordSize
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.written++
+	p.pending[string(key)] = pendingRecord{
+		loc: location{segment: p.active, offset: off, length: uint32(len(value))},
+		seq: p.written,
+	}
+	return p.written, nil
+}
+
+// flush makes the records appended so far durable and indexes them,
+// unless that has been done up to seq already. Records appended
+// meanwhile by others are flushed along, so concurrent writers
+// share the cost.
+func (p *Pack) flush(seq uint64) error {
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+	if seq <= p.flushed {
+		return nil
+	}
+
+	p.mu.RLock()
+	target := p.written
+	batch := make(map[string]pendingRecord, len(p.pending))
+	for k, r := range p.pending {
+		batch[k] = r
+	}
+	p.mu.RUnlock()
+
+	ends := make(map[uint32]int64)
+	for k, r := range batch {
+		if end := r.loc.end(len(k)); end > ends[r.loc.segment] {
+			ends[r.loc.segment] = end
+		}
+	}
+	// the records must be durable before the index points to them
+	for id := range ends {
+		f, err := p.segment(id)
+		if err != nil {
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	}
+
+	update := func(tx *bolt.Tx) error {
+		objects := tx.Bucket(bucketObjects)
+		for k, r := range batch {
+			if err := objects.Put([]byte(k), r.loc.marshal()); err != nil {
+				return err
+			}
+		}
+		segments := tx.Bucket(bucketSegments)
+		for id, end := range ends {
+			var buf [8]byte
+			binary.BigEndian.PutUint64(buf[:], uint64(end))
+			if err := segments.Put(segmentKey(id), buf[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := p.index.Update(update); err != nil {
+		// the records stay pending, and are indexed by the next
+		// flush or open
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for k, r := range batch {
+		if cur, ok := p.pending[k]; ok && cur.seq == r.seq {
+			delete(p.pending, k)
+		}
+	}
+	p.flushed = target
+	return nil
+}
@@ -0,0 +1,377 @@
+package kvpack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bazil.org/bazil/kv"
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestPutGet(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	p, err := Open(temp.Path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+
+	ctx := context.Background()
+	if err := p.Put(ctx, []byte("quux"), []byte("foobar")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// storing the same key again is deduplicated
+	if err := p.Put(ctx, []byte("quux"), []byte("foobar")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	got, err := p.Get(ctx, []byte("quux"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if g, e := string(got), "foobar"; g != e {
+		t.Errorf("wrong value: %q != %q", g, e)
+	}
+	fi, err := os.Stat(p.segmentPath(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := fi.Size(), int64(recordHeader+len("quux")+len("foobar")); g != e {
+		t.Errorf("duplicate put was appended: size %d != %d", g, e)
+	}
+
+	if _, err := p.Get(ctx, []byte("missing")); err == nil {
+		t.Error("expected error")
+	} else if _, ok := err.(kv.NotFoundError); !ok {
+		t.Errorf("expected NotFoundError, got %T: %v", err, err)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	p, err := open(temp.Path, 120)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	ctx := context.Background()
+	value := bytes.Repeat([]byte("x"), 40)
+	for i := 0; i < 10; i++ {
+		if err := p.Put(ctx, []byte(fmt.Sprintf("key%d", i)), value); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	p, err = open(temp.Path, 120)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer p.Close()
+	ids, err := p.listSegments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := len(ids), 5; g != e {
+		t.Errorf("wrong number of segments: %d != %d", g, e)
+	}
+	for i := 0; i < 10; i++ {
+		got, err := p.Get(ctx, []byte(fmt.Sprintf("key%d", i)))
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if !bytes.Equal(got, value) {
+			t.Errorf("wrong value for key%d", i)
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	p, err := Open(temp.Path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	ctx := context.Background()
+	if err := p.Put(ctx, []byte("k1"), []byte("indexed")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Simulate a crash: a record that made it to the segment but not
+	// the index, followed by a partial record.
+	other, err := Open(filepath.Join(temp.Path, "other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Put(ctx, []byte("k2"), []byte("unindexed")); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Put(ctx, []byte("k3"), []byte("partial")); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Close(); err != nil {
+		t.Fatal(err)
+	}
+	tail, err := ioutil.ReadFile(filepath.Join(temp.Path, "other", "00000000.seg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := filepath.Join(temp.Path, "00000000.seg")
+	before, err := os.Stat(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(seg, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write(tail[:len(tail)-3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = Open(temp.Path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer p.Close()
+	for key, want := range map[string]string{"k1": "indexed", "k2": "unindexed"} {
+		got, err := p.Get(ctx, []byte(key))
+		if err != nil {
+			t.Fatalf("Get %q: %v", key, err)
+		}
+		if g, e := string(got), want; g != e {
+			t.Errorf("wrong value for %q: %q != %q", key, g, e)
+		}
+	}
+	if _, err := p.Get(ctx, []byte("k3")); err == nil {
+		t.Error("partial record must not be indexed")
+	}
+	after, err := os.Stat(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := after.Size(), before.Size()+int64(recordHeader+len("k2")+len("unindexed")); g != e {
+		t.Errorf("partial record not cut off: size %d != %d", g, e)
+	}
+
+	// appending continues after the recovered records
+	if err := p.Put(ctx, []byte("k3"), []byte("again")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	got, err := p.Get(ctx, []byte("k3"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if g, e := string(got), "again"; g != e {
+		t.Errorf("wrong value: %q != %q", g, e)
+	}
+}
+
+func TestCorrupt(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	p, err := Open(temp.Path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+	ctx := context.Background()
+	if err := p.Put(ctx, []byte("k"), []byte("value")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	f, err := p.segment(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("V"), recordHeader+1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Get(ctx, []byte("k")); err == nil {
+		t.Error("expected error")
+	} else if _, ok := err.(CorruptError); !ok {
+		t.Errorf("expected CorruptError, got %T: %v", err, err)
+	}
+}
+
+func TestPutRepairsCorrupt(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	p, err := Open(temp.Path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+	ctx := context.Background()
+	if err := p.Put(ctx, []byte("k"), []byte("value")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	f, err := p.segment(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("V"), recordHeader+1); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Put(ctx, []byte("k"), []byte("value")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	got, err := p.Get(ctx, []byte("k"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if g, e := string(got), "value"; g != e {
+		t.Errorf("wrong value: %q != %q", g, e)
+	}
+}
+
+func TestRecoverCorruptSealed(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	// two records per segment
+	p, err := open(temp.Path, 120)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	ctx := context.Background()
+	value := bytes.Repeat([]byte("x"), 40)
+	for i := 0; i < 6; i++ {
+		if err := p.Put(ctx, []byte(fmt.Sprintf("key%d", i)), value); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	corrupt := func(id uint32, off int64) {
+		f, err := os.OpenFile(p.segmentPath(id), os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteAt([]byte{0xff}, off); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// value of the first record in segment 0
+	corrupt(0, recordHeader+10)
+	// length of the first record in segment 1
+	corrupt(1, 4)
+	// and the index is lost, so everything is scanned again
+	if err := os.Remove(filepath.Join(temp.Path, indexName)); err != nil {
+		t.Fatal(err)
+	}
+	sizes := map[uint32]int64{}
+	for id := uint32(0); id < 3; id++ {
+		fi, err := os.Stat(p.segmentPath(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes[id] = fi.Size()
+	}
+
+	p, err = open(temp.Path, 120)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer p.Close()
+	for id, size := range sizes {
+		fi, err := os.Stat(p.segmentPath(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g, e := fi.Size(), size; g != e {
+			t.Errorf("segment %d was cut: size %d != %d", id, g, e)
+		}
+	}
+	for i := 0; i < 6; i++ {
+		key := fmt.Sprintf("key%d", i)
+		got, err := p.Get(ctx, []byte(key))
+		if i == 0 || i == 2 {
+			if err == nil {
+				t.Errorf("expected error for corrupt %s", key)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Get %s: %v", key, err)
+			continue
+		}
+		if !bytes.Equal(got, value) {
+			t.Errorf("wrong value for %s", key)
+		}
+	}
+}
+
+func TestPutConcurrent(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	p, err := open(temp.Path, 200)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer p.Close()
+
+	ctx := context.Background()
+	const n = 50
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			key := []byte(fmt.Sprintf("key%d", i))
+			value := []byte(fmt.Sprintf("value%d", i))
+			if err := p.Put(ctx, key, value); err != nil {
+				errs <- err
+				return
+			}
+			got, err := p.Get(ctx, key)
+			if err == nil && !bytes.Equal(got, value) {
+				err = fmt.Errorf("wrong value for %q: %q", key, got)
+			}
+			errs <- err
+		}(i)
+	}
+	for i := 0; i < n; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+	if len(p.pending) != 0 {
+		t.Errorf("records left unindexed: %d", len(p.pending))
+	}
+}
+
+func TestPutEmptyKey(t *testing.T) {
+	temp := tempdir.New(t)
+	defer temp.Cleanup()
+
+	p, err := Open(temp.Path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer p.Close()
+
+	if err := p.Put(context.Background(), nil, []byte("value")); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"bazil.org/bazil/kv"
+	"bazil.org/bazil/peer"
 	"bazil.org/bazil/peer/wire"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,7 +17,29 @@ type KVPeer struct {
 
 var _ kv.KV = (*KVPeer)(nil)
 
+// protocolClient is implemented by peer clients that know what the
+// peer supports.
+type protocolClient interface {
+	Protocol(ctx context.Context) (*peer.Protocol, error)
+}
+
+// checkProtocol refuses to use peers that cannot store objects.
+func (k *KVPeer) checkProtocol(ctx context.Context) error {
+	pc, ok := k.peer.(protocolClient)
+	if !ok {
+		return nil
+	}
+	proto, err := pc.Protocol(ctx)
+	if err != nil {
+		return err
+	}
+	return proto.Require(peer.CapObjects)
+}
+
 func (k *KVPeer) Put(ctx context.Context, key, value []byte) error {
+	if err := k.checkProtocol(ctx); err != nil {
+		return err
+	}
 	stream, err := k.peer.ObjectPut(ctx)
 	if err != nil {
 		return err
@@ -51,6 +74,9 @@ func (k *KVPeer) Put(ctx context.Context, key, value []byte) error {
 }
 
 func (k *KVPeer) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if err := k.checkProtocol(ctx); err != nil {
+		return nil, err
+	}
 	stream, err := k.peer.ObjectGet(ctx, &wire.ObjectGetRequest{
 		Key: key,
 	})
@@ -75,6 +101,16 @@ func (k *KVPeer) Get(ctx context.Context, key []byte) ([]byte, error) {
 	return data, nil
 }
 
+// Close closes the peer client, if it is an io.Closer.
+func (k *KVPeer) Close() error {
+	if c, ok := k.peer.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Open returns a store that keeps values on a peer. The store owns
+// the client, and closes it on Close.
 func Open(peer wire.PeerClient) (*KVPeer, error) {
 	return &KVPeer{
 		peer: peer,
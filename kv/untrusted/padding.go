@@ -0,0 +1,114 @@
+package untrusted
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Padding hides the exact size of stored data from the untrusted
+// storage, by rounding sizes up to a limited set of buckets.
+//
+// Padding is added inside the encrypted box, as a 0x80 byte followed
+// by zero bytes. Padded boxes start with a byte naming the padding
+// mode, and are sealed with a nonce that depends on it, so Get
+// removes the padding whatever padding the reader is configured
+// with. Unpadded boxes are stored as before padding existed.
+type Padding uint8
+
+const (
+	// No padding; the stored size reveals the exact plaintext size.
+	PadNone Padding = iota
+	// Pad to the next power of two. Leaks O(log log n) bits of the
+	// size, with up to 100% overhead.
+	PadPow2
+	// Padmé, as in "Reducing Metadata Leakage from Encrypted Files
+	// and Communication with PURBs". Leaks O(log log n) bits of the
+	// size, with at most 12% overhead.
+	PadPadme
+)
+
+var paddingNames = [...]string{
+	PadNone:  "none",
+	PadPow2:  "pow2",
+	PadPadme: "padme",
+}
+
+func (p Padding) String() string {
+	if int(p) < len(paddingNames) {
+		return paddingNames[p]
+	}
+	return fmt.Sprintf("Padding(%d)", uint8(p))
+}
+
+// ParsePadding returns the padding mode with the given name. The
+// empty string means PadNone.
+func ParsePadding(s string) (Padding, error) {
+	if s == "" {
+		return PadNone, nil
+	}
+	for i, name := range paddingNames {
+		if name == s {
+			return Padding(i), nil
+		}
+	}
+	return PadNone, fmt.Errorf("unknown padding: %q", s)
+}
+
+// Size returns the padded size for n bytes of data. The result
+// includes room for the padding marker, and is thus always greater
+// than n when padding is used.
+func (p Padding) Size(n int) int {
+	// room for the marker byte
+	l := uint64(n) + 1
+	switch p {
+	case PadPow2:
+		if l&(l-1) == 0 {
+			return int(l)
+		}
+		return int(1 << uint(bits.Len64(l)))
+	case PadPadme:
+		if l < 2 {
+			return int(l)
+		}
+		e := uint(bits.Len64(l)) - 1
+		s := uint(bits.Len64(uint64(e)))
+		lastBits := e - s
+		mask := uint64(1)<<lastBits - 1
+		return int((l + mask) &^ mask)
+	}
+	return n
+}
+
+const padMarker = 0x80
+
+// pad returns value with the mode byte and padding added.
+func (p Padding) pad(value []byte) []byte {
+	if p == PadNone {
+		return value
+	}
+	buf := make([]byte, p.Size(1+len(value)))
+	buf[0] = byte(p)
+	n := 1 + copy(buf[1:], value)
+	buf[n] = padMarker
+	return buf
+}
+
+// unpad returns plain without the mode byte and padding. Returns
+// false if they are not valid for p.
+func (p Padding) unpad(plain []byte) ([]byte, bool) {
+	if p == PadNone {
+		return plain, true
+	}
+	if len(plain) == 0 || Padding(plain[0]) != p {
+		return nil, false
+	}
+	plain = plain[1:]
+	end := len(plain)
+	for end > 0 && plain[end-1] == 0x00 {
+		end--
+	}
+	if end == 0 || plain[end-1] != padMarker {
+		return nil, false
+	}
+	return plain[:end-1], true
+}
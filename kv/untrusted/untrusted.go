@@ -16,6 +16,7 @@ type Convergent struct {
 	// used like a Fixed Content Storage (FCS)
 	untrusted kv.KV
 	secret    *[32]byte
+	padding   Padding
 }
 
 var _ kv.KV = (*Convergent)(nil)
@@ -39,12 +40,17 @@ const nonceSize = 24
 var personalizeNonce = []byte(tokens.Blake2bPersonalizationConvergentNonce)
 
 // Nonce summarizes key, type and level so mismatch of e.g. type can
-// be detected.
-func (s *Convergent) makeNonce(key []byte) *[nonceSize]byte {
+// be detected. Padded boxes use a different nonce for each padding
+// mode, as the same data padded differently must not be sealed with
+// the same nonce.
+func makeNonce(key []byte, padding Padding) *[nonceSize]byte {
 	conf := blake2.Config{
 		Size:     nonceSize,
 		Personal: personalizeNonce,
 	}
+	if padding != PadNone {
+		conf.Salt = []byte{byte(padding)}
+	}
 	h := blake2.New(&conf)
 	// hash.Hash docs say it never fails
 	_, _ = h.Write(key)
@@ -61,23 +67,49 @@ func (s *Convergent) Get(ctx context.Context, key []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	nonce := s.makeNonce(key)
-	plain, ok := secretbox.Open(nil, box, nonce, s.secret)
+	// try the configured padding first, as that is what is usually
+	// stored
+	if plain, ok := s.open(key, box, s.padding); ok {
+		return plain, nil
+	}
+	for i := range paddingNames {
+		p := Padding(i)
+		if p == s.padding {
+			continue
+		}
+		if plain, ok := s.open(key, box, p); ok {
+			return plain, nil
+		}
+	}
+	return nil, CorruptError{Key: key}
+}
+
+// open opens a box sealed with the given padding.
+func (s *Convergent) open(key []byte, box []byte, padding Padding) ([]byte, bool) {
+	plain, ok := secretbox.Open(nil, box, makeNonce(key, padding), s.secret)
 	if !ok {
-		return nil, CorruptError{Key: key}
+		return nil, false
 	}
-	return plain, nil
+	return padding.unpad(plain)
 }
 
 func (s *Convergent) Put(ctx context.Context, key []byte, value []byte) error {
-	nonce := s.makeNonce(key)
-	box := secretbox.Seal(nil, value, nonce, s.secret)
+	nonce := makeNonce(key, s.padding)
+	box := secretbox.Seal(nil, s.padding.pad(value), nonce, s.secret)
 
 	boxedkey := s.computeBoxedKey(key)
 	err := s.untrusted.Put(ctx, boxedkey, box)
 	return err
 }
 
+// Close closes the underlying store.
+func (s *Convergent) Close() error {
+	return s.untrusted.Close()
+}
+
+// New returns a store that encrypts values before passing them to
+// store. The store is owned by the returned store, and closed with
+// it.
 func New(store kv.KV, secret *[32]byte) *Convergent {
 	return &Convergent{
 		untrusted: store,
@@ -85,6 +117,53 @@ func New(store kv.KV, secret *[32]byte) *Convergent {
 	}
 }
 
+// NewPadded is like New, but hides the exact size of the stored
+// data with padding.
+func NewPadded(store kv.KV, secret *[32]byte, padding Padding) *Convergent {
+	return &Convergent{
+		untrusted: store,
+		secret:    secret,
+		padding:   padding,
+	}
+}
+
+// Rotating is a Convergent store in the middle of changing its
+// secret. Reads fall back to data encrypted with the old secret,
+// writes only use the new secret.
+type Rotating struct {
+	cur *Convergent
+	old *Convergent
+}
+
+var _ kv.KV = (*Rotating)(nil)
+
+func (s *Rotating) Get(ctx context.Context, key []byte) ([]byte, error) {
+	plain, err := s.cur.Get(ctx, key)
+	if _, isNotFound := err.(kv.NotFoundError); isNotFound {
+		plain, err = s.old.Get(ctx, key)
+	}
+	return plain, err
+}
+
+func (s *Rotating) Put(ctx context.Context, key []byte, value []byte) error {
+	return s.cur.Put(ctx, key, value)
+}
+
+// Close closes the underlying store, through cur; old is expected to
+// share it.
+func (s *Rotating) Close() error {
+	return s.cur.Close()
+}
+
+// NewRotating returns a store that writes to cur, but can still read
+// data from old. Both should use the same underlying store.
+func NewRotating(cur *Convergent, old *Convergent) *Rotating {
+	return &Rotating{
+		cur: cur,
+		old: old,
+	}
+}
+
 type CorruptError struct {
 	Key []byte
 }
@@ -1,7 +1,9 @@
 package untrusted_test
 
 import (
+	"bytes"
 	"context"
+	"math/bits"
 	"testing"
 
 	"bazil.org/bazil/cas"
@@ -9,6 +11,7 @@ import (
 	"bazil.org/bazil/cas/chunks/kvchunks"
 	"bazil.org/bazil/kv/kvmock"
 	"bazil.org/bazil/kv/untrusted"
+	"golang.org/x/crypto/nacl/secretbox"
 )
 
 const GREETING = "Hello, world"
@@ -178,3 +181,179 @@ func TestWrongSecret(t *testing.T) {
 		}
 	}
 }
+
+func TestRotating(t *testing.T) {
+	remote := &kvmock.InMemory{}
+	oldSecret := &[32]byte{
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+	}
+	newSecret := &[32]byte{
+		13, 13, 13, 13, 13, 13, 13, 13,
+		13, 13, 13, 13, 13, 13, 13, 13,
+		13, 13, 13, 13, 13, 13, 13, 13,
+		13, 13, 13, 13, 13, 13, 13, 13,
+	}
+	ctx := context.Background()
+	if err := untrusted.New(remote, oldSecret).Put(ctx, []byte("k1"), []byte("old")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	rot := untrusted.NewRotating(untrusted.New(remote, newSecret), untrusted.New(remote, oldSecret))
+	got, err := rot.Get(ctx, []byte("k1"))
+	if err != nil {
+		t.Fatalf("Get of old data failed: %v", err)
+	}
+	if g, e := string(got), "old"; g != e {
+		t.Errorf("unexpected data: %q != %q", g, e)
+	}
+
+	if err := rot.Put(ctx, []byte("k2"), []byte("new")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	got, err = untrusted.New(remote, newSecret).Get(ctx, []byte("k2"))
+	if err != nil {
+		t.Fatalf("new data not encrypted with new secret: %v", err)
+	}
+	if g, e := string(got), "new"; g != e {
+		t.Errorf("unexpected data: %q != %q", g, e)
+	}
+	if _, err := untrusted.New(remote, oldSecret).Get(ctx, []byte("k2")); err == nil {
+		t.Errorf("new data must not be readable with old secret")
+	}
+}
+
+func TestPadding(t *testing.T) {
+	secret := &[32]byte{
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+	}
+	// inBucket reports whether a padded size is one of the allowed
+	// sizes for the mode.
+	tests := []struct {
+		padding  untrusted.Padding
+		inBucket func(size int) bool
+	}{
+		{untrusted.PadPow2, func(size int) bool {
+			return size&(size-1) == 0
+		}},
+		{untrusted.PadPadme, func(size int) bool {
+			// Padmé keeps only the top floor(log2(E))+1 bits of the
+			// size, where E is floor(log2(size)).
+			e := bits.Len(uint(size)) - 1
+			s := bits.Len(uint(e))
+			low := e - s
+			if low < 0 {
+				return true
+			}
+			return size&(1<<uint(low)-1) == 0
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.padding.String(), func(t *testing.T) {
+			ctx := context.Background()
+			sizes := make(map[int]struct{})
+			for n := 0; n < 5000; n += 7 {
+				remote := &kvmock.InMemory{}
+				store := untrusted.NewPadded(remote, secret, test.padding)
+				// trailing zeros must survive unpadding
+				value := bytes.Repeat([]byte{0xFF, 0x80, 0x00}, n/3+1)[:n]
+				key := []byte("k")
+				if err := store.Put(ctx, key, value); err != nil {
+					t.Fatalf("Put failed: %v", err)
+				}
+				var stored int
+				for _, box := range remote.Data {
+					stored = len(box) - secretbox.Overhead
+				}
+				if stored <= n {
+					t.Errorf("size %d was not padded: %d", n, stored)
+				}
+				if !test.inBucket(stored) {
+					t.Errorf("size %d padded to %d, not a bucket", n, stored)
+				}
+				sizes[stored] = struct{}{}
+
+				got, err := store.Get(ctx, key)
+				if err != nil {
+					t.Fatalf("Get failed: %v", err)
+				}
+				if !bytes.Equal(got, value) {
+					t.Errorf("size %d: wrong data after unpadding", n)
+				}
+			}
+			if len(sizes) > 100 {
+				t.Errorf("too many distinct stored sizes: %d", len(sizes))
+			}
+		})
+	}
+}
+
+func TestPaddingSize(t *testing.T) {
+	tests := []struct {
+		padding untrusted.Padding
+		n       int
+		size    int
+	}{
+		{untrusted.PadNone, 1000, 1000},
+		{untrusted.PadPow2, 0, 1},
+		{untrusted.PadPow2, 1000, 1024},
+		{untrusted.PadPow2, 1023, 1024},
+		{untrusted.PadPow2, 1024, 2048},
+		{untrusted.PadPadme, 8, 10},
+		{untrusted.PadPadme, 999, 1024},
+		{untrusted.PadPadme, 1024, 1088},
+	}
+	for _, test := range tests {
+		if g, e := test.padding.Size(test.n), test.size; g != e {
+			t.Errorf("%v.Size(%d) = %d, want %d", test.padding, test.n, g, e)
+		}
+	}
+}
+
+func TestPaddingMismatch(t *testing.T) {
+	secret := &[32]byte{
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+		42, 42, 42, 42, 42, 42, 42, 42,
+	}
+	ctx := context.Background()
+	// trailing bytes that look like padding must be kept
+	value := []byte("abc\x80\x00")
+	for _, writer := range []untrusted.Padding{untrusted.PadNone, untrusted.PadPow2, untrusted.PadPadme} {
+		remote := &kvmock.InMemory{}
+		if err := untrusted.NewPadded(remote, secret, writer).Put(ctx, []byte("k"), value); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+		for _, reader := range []untrusted.Padding{untrusted.PadNone, untrusted.PadPow2, untrusted.PadPadme} {
+			got, err := untrusted.NewPadded(remote, secret, reader).Get(ctx, []byte("k"))
+			if err != nil {
+				t.Errorf("written %v, read %v: Get failed: %v", writer, reader, err)
+				continue
+			}
+			if !bytes.Equal(got, value) {
+				t.Errorf("written %v, read %v: wrong data: %q", writer, reader, got)
+			}
+		}
+	}
+}
+
+func TestParsePadding(t *testing.T) {
+	for _, p := range []untrusted.Padding{untrusted.PadNone, untrusted.PadPow2, untrusted.PadPadme} {
+		got, err := untrusted.ParsePadding(p.String())
+		if err != nil {
+			t.Errorf("ParsePadding(%q): %v", p, err)
+		}
+		if got != p {
+			t.Errorf("ParsePadding(%q) = %v", p, got)
+		}
+	}
+	if _, err := untrusted.ParsePadding("bogus"); err == nil {
+		t.Error("expected error for unknown padding")
+	}
+}
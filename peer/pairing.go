@@ -0,0 +1,96 @@
+package peer
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+const (
+	pairingSecretSize      = 5
+	pairingFingerprintSize = 5
+)
+
+var pairingEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// PairingCode is given by the operator of a peer in pairing mode to
+// whoever wants to pair with it. The first half is a random secret
+// that proves the joining side was given the code. The second half
+// is a fingerprint of the public key of the peer that made the code,
+// so the joining side knows it is talking to the right server
+// without having to exchange public keys. The fingerprint is keyed
+// with the secret, so it cannot be matched by generating keys
+// without knowing the code.
+type PairingCode [pairingSecretSize + pairingFingerprintSize]byte
+
+// NewPairingCode returns a new random pairing code for the peer
+// with the given public key.
+func NewPairingCode(pub *PublicKey) (*PairingCode, error) {
+	var c PairingCode
+	if _, err := rand.Read(c[:pairingSecretSize]); err != nil {
+		return nil, err
+	}
+	fp := c.fingerprint(pub)
+	copy(c[pairingSecretSize:], fp[:])
+	return &c, nil
+}
+
+func (c *PairingCode) fingerprint(pub *PublicKey) [pairingFingerprintSize]byte {
+	mac := hmac.New(sha256.New, c.Secret())
+	// never fails
+	_, _ = mac.Write(pub[:])
+	var fp [pairingFingerprintSize]byte
+	copy(fp[:], mac.Sum(nil))
+	return fp
+}
+
+// Secret returns the part of the code that is sent to the pairing
+// peer.
+func (c *PairingCode) Secret() []byte {
+	return c[:pairingSecretSize]
+}
+
+// CheckSecret reports whether secret is the secret part of the code.
+func (c *PairingCode) CheckSecret(secret []byte) bool {
+	return subtle.ConstantTimeCompare(c.Secret(), secret) == 1
+}
+
+// Matches reports whether the code was made by the peer with the
+// given public key.
+func (c *PairingCode) Matches(pub *PublicKey) bool {
+	fp := c.fingerprint(pub)
+	return subtle.ConstantTimeCompare(c[pairingSecretSize:], fp[:]) == 1
+}
+
+var _ flag.Value = (*PairingCode)(nil)
+
+// String returns the code in groups of four characters, for reading
+// out loud or typing in.
+func (c *PairingCode) String() string {
+	s := strings.ToLower(pairingEncoding.EncodeToString(c[:]))
+	var groups []string
+	for len(s) > 4 {
+		groups = append(groups, s[:4])
+		s = s[4:]
+	}
+	groups = append(groups, s)
+	return strings.Join(groups, "-")
+}
+
+// Set parses a code in the format returned by String. Dashes and
+// case are ignored.
+func (c *PairingCode) Set(value string) error {
+	s := strings.ToUpper(strings.Replace(value, "-", "", -1))
+	if len(s) != pairingEncoding.EncodedLen(len(c)) {
+		return fmt.Errorf("not a valid pairing code: wrong size")
+	}
+	if _, err := pairingEncoding.Decode(c[:], []byte(s)); err != nil {
+		return fmt.Errorf("not a valid pairing code: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package peer_test
+
+import (
+	"testing"
+
+	"bazil.org/bazil/peer"
+)
+
+func TestPairingCode(t *testing.T) {
+	pub := peer.PublicKey{1, 2, 3}
+	other := peer.PublicKey{4, 5, 6}
+	code, err := peer.NewPairingCode(&pub)
+	if err != nil {
+		t.Fatalf("NewPairingCode: %v", err)
+	}
+	if !code.Matches(&pub) {
+		t.Error("code does not match its own key")
+	}
+	if code.Matches(&other) {
+		t.Error("code matches another key")
+	}
+	tampered := *code
+	tampered[0] ^= 1
+	if tampered.Matches(&pub) {
+		t.Error("fingerprint does not depend on the secret")
+	}
+	if !code.CheckSecret(code.Secret()) {
+		t.Error("secret does not check out")
+	}
+	if code.CheckSecret(make([]byte, len(code.Secret()))) {
+		t.Error("zero secret checks out")
+	}
+
+	s := code.String()
+	if g, e := len(s), 19; g != e {
+		t.Errorf("wrong code length: %q: %d != %d", s, g, e)
+	}
+	var parsed peer.PairingCode
+	if err := parsed.Set(s); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if parsed != *code {
+		t.Errorf("wrong code after round trip: %v != %v", parsed, code)
+	}
+}
+
+func TestPairingCodeSetSloppy(t *testing.T) {
+	var c peer.PairingCode
+	if err := c.Set("ABCDEFGH-ijklmnop"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if g, e := c.String(), "abcd-efgh-ijkl-mnop"; g != e {
+		t.Errorf("wrong code: %q != %q", g, e)
+	}
+}
+
+func TestPairingCodeSetBad(t *testing.T) {
+	for _, s := range []string{"", "abcd-efgh-ijkl", "abcd-efgh-ijkl-mnop-q", "abcd-efgh-ijkl-mno1"} {
+		var c peer.PairingCode
+		if err := c.Set(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
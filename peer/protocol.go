@@ -0,0 +1,118 @@
+package peer
+
+import (
+	"fmt"
+)
+
+// ProtocolVersion is the version of the peer protocol spoken by this
+// implementation. It is increased on incompatible wire changes.
+const ProtocolVersion = 1
+
+// Capability names an optional feature of the peer protocol.
+type Capability string
+
+const (
+	// The peer stores objects for us, with ObjectPut and ObjectGet.
+	CapObjects Capability = "objects"
+	// The peer serves volume contents with VolumeSyncPull.
+	CapVolumeSync Capability = "volume-sync"
+	// The peer understands tombstone directory entries in sync.
+	CapSyncTombstones Capability = "sync-tombstones"
+)
+
+// capabilities lists everything this implementation supports.
+var capabilities = []Capability{
+	CapObjects,
+	CapVolumeSync,
+	CapSyncTombstones,
+}
+
+// baseCapabilities are assumed for peers from before the handshake
+// existed, which announce version 0. Anything else must be
+// announced, so that older peers are downgraded instead of sent what
+// they cannot understand.
+var baseCapabilities = []Capability{
+	CapObjects,
+	CapVolumeSync,
+}
+
+// Capabilities returns the names of the capabilities we support, for
+// announcing them to peers.
+func Capabilities() []string {
+	names := make([]string, 0, len(capabilities))
+	for _, c := range capabilities {
+		names = append(names, string(c))
+	}
+	return names
+}
+
+// Protocol is what two peers agreed to speak, as the result of the
+// handshake.
+type Protocol struct {
+	// Highest version supported by both sides.
+	Version uint32
+	// Capabilities supported by both sides.
+	Capabilities []Capability
+}
+
+// Negotiate returns the protocol to use with a peer that announced
+// the given version and capabilities. Capabilities we do not know are
+// ignored. Version 0 is a peer from before the handshake existed,
+// which gets only the capabilities every peer has.
+func Negotiate(version uint32, names []string) *Protocol {
+	if version == 0 {
+		return &Protocol{
+			Version:      0,
+			Capabilities: append([]Capability(nil), baseCapabilities...),
+		}
+	}
+	theirs := make(map[Capability]bool, len(names))
+	for _, name := range names {
+		theirs[Capability(name)] = true
+	}
+	p := &Protocol{Version: version}
+	if p.Version > ProtocolVersion {
+		p.Version = ProtocolVersion
+	}
+	for _, c := range capabilities {
+		if theirs[c] {
+			p.Capabilities = append(p.Capabilities, c)
+		}
+	}
+	return p
+}
+
+// Has reports whether both sides support the capability.
+func (p *Protocol) Has(c Capability) bool {
+	for _, have := range p.Capabilities {
+		if have == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Require returns a *CapabilityError for the first capability the
+// peer lacks, or nil if it has all of them.
+func (p *Protocol) Require(caps ...Capability) error {
+	for _, c := range caps {
+		if !p.Has(c) {
+			return &CapabilityError{Capability: c, Version: p.Version}
+		}
+	}
+	return nil
+}
+
+// CapabilityError means the peer does not support a feature needed
+// for the operation.
+type CapabilityError struct {
+	Capability Capability
+	// Negotiated protocol version.
+	Version uint32
+}
+
+var _ error = (*CapabilityError)(nil)
+
+func (e *CapabilityError) Error() string {
+	return fmt.Sprintf("peer does not support %s (protocol version %d)", e.Capability, e.Version)
+}
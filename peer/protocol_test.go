@@ -0,0 +1,53 @@
+package peer_test
+
+import (
+	"testing"
+
+	"bazil.org/bazil/peer"
+)
+
+func TestNegotiateLegacy(t *testing.T) {
+	// what a peer from before the handshake sends, even if it
+	// happens to set capabilities
+	p := peer.Negotiate(0, peer.Capabilities())
+	if g, e := p.Version, uint32(0); g != e {
+		t.Errorf("wrong version: %d != %d", g, e)
+	}
+	if err := p.Require(peer.CapObjects, peer.CapVolumeSync); err != nil {
+		t.Error(err)
+	}
+	if p.Has(peer.CapSyncTombstones) {
+		t.Error("legacy peer must not get tombstones")
+	}
+}
+
+func TestNegotiateCurrent(t *testing.T) {
+	p := peer.Negotiate(peer.ProtocolVersion, peer.Capabilities())
+	if err := p.Require(peer.CapObjects, peer.CapVolumeSync, peer.CapSyncTombstones); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNegotiateNewer(t *testing.T) {
+	p := peer.Negotiate(peer.ProtocolVersion+1, []string{"objects", "from-the-future"})
+	if g, e := p.Version, uint32(peer.ProtocolVersion); g != e {
+		t.Errorf("wrong version: %d != %d", g, e)
+	}
+	if g, e := len(p.Capabilities), 1; g != e {
+		t.Errorf("wrong capabilities: %v", p.Capabilities)
+	}
+	if err := p.Require(peer.CapObjects); err != nil {
+		t.Errorf("Require: %v", err)
+	}
+	err := p.Require(peer.CapObjects, peer.CapVolumeSync)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	capErr, ok := err.(*peer.CapabilityError)
+	if !ok {
+		t.Fatalf("expected CapabilityError, got %T: %v", err, err)
+	}
+	if g, e := capErr.Capability, peer.CapVolumeSync; g != e {
+		t.Errorf("wrong missing capability: %q != %q", g, e)
+	}
+}
@@ -51,7 +51,13 @@ func (VolumeSyncPullItem_Error) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_f2a9abb617589e2c, []int{9, 0}
 }
 
+// Ping doubles as the protocol handshake. Both sides announce the
+// highest protocol version they speak and the optional features they
+// support. Peers from before the handshake send and return empty
+// messages, which reads as version 0.
 type PingRequest struct {
+	Version              uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Capabilities         []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -82,7 +88,23 @@ func (m *PingRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingRequest proto.InternalMessageInfo
 
+func (m *PingRequest) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PingRequest) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type PingResponse struct {
+	Version              uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Capabilities         []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -113,6 +135,20 @@ func (m *PingResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingResponse proto.InternalMessageInfo
 
+func (m *PingResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PingResponse) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type ObjectPutRequest struct {
 	// Only set in the first streamed message.
 	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -349,8 +385,14 @@ func (m *VolumeConnectResponse) GetVolumeID() []byte {
 }
 
 type VolumeSyncPullRequest struct {
-	VolumeID             []byte   `protobuf:"bytes,1,opt,name=volumeID,proto3" json:"volumeID,omitempty"`
-	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	VolumeID []byte `protobuf:"bytes,1,opt,name=volumeID,proto3" json:"volumeID,omitempty"`
+	Path     string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// What the caller speaks, as in PingRequest. Sent with every
+	// request, so the answer does not depend on an earlier handshake
+	// on the same connection. Callers predating this get only what
+	// every peer supports.
+	Version              uint32   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Capabilities         []string `protobuf:"bytes,4,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -395,6 +437,20 @@ func (m *VolumeSyncPullRequest) GetPath() string {
 	return ""
 }
 
+func (m *VolumeSyncPullRequest) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *VolumeSyncPullRequest) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 type VolumeSyncPullItem struct {
 	// This is used to work around gRPC fixed error codes and error
 	// strings.
@@ -686,6 +742,80 @@ func (m *Tombstone) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Tombstone proto.InternalMessageInfo
 
+// Pair is the only call open to unknown peers, while the server is
+// in pairing mode. A peer that knows the pairing code is put on a
+// list for the operator to approve.
+type PairRequest struct {
+	// The secret part of the pairing code.
+	Secret               []byte   `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PairRequest) Reset()         { *m = PairRequest{} }
+func (m *PairRequest) String() string { return proto.CompactTextString(m) }
+func (*PairRequest) ProtoMessage()    {}
+func (*PairRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2a9abb617589e2c, []int{14}
+}
+
+func (m *PairRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PairRequest.Unmarshal(m, b)
+}
+func (m *PairRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PairRequest.Marshal(b, m, deterministic)
+}
+func (m *PairRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PairRequest.Merge(m, src)
+}
+func (m *PairRequest) XXX_Size() int {
+	return xxx_messageInfo_PairRequest.Size(m)
+}
+func (m *PairRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PairRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PairRequest proto.InternalMessageInfo
+
+func (m *PairRequest) GetSecret() []byte {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+type PairResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PairResponse) Reset()         { *m = PairResponse{} }
+func (m *PairResponse) String() string { return proto.CompactTextString(m) }
+func (*PairResponse) ProtoMessage()    {}
+func (*PairResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2a9abb617589e2c, []int{15}
+}
+
+func (m *PairResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PairResponse.Unmarshal(m, b)
+}
+func (m *PairResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PairResponse.Marshal(b, m, deterministic)
+}
+func (m *PairResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PairResponse.Merge(m, src)
+}
+func (m *PairResponse) XXX_Size() int {
+	return xxx_messageInfo_PairResponse.Size(m)
+}
+func (m *PairResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PairResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PairResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("bazil.peer.VolumeSyncPullItem_Error", VolumeSyncPullItem_Error_name, VolumeSyncPullItem_Error_value)
 	proto.RegisterType((*PingRequest)(nil), "bazil.peer.PingRequest")
@@ -703,6 +833,8 @@ func init() {
 	proto.RegisterType((*File)(nil), "bazil.peer.File")
 	proto.RegisterType((*Dir)(nil), "bazil.peer.Dir")
 	proto.RegisterType((*Tombstone)(nil), "bazil.peer.Tombstone")
+	proto.RegisterType((*PairRequest)(nil), "bazil.peer.PairRequest")
+	proto.RegisterType((*PairResponse)(nil), "bazil.peer.PairResponse")
 }
 
 func init() {
@@ -710,48 +842,54 @@ func init() {
 }
 
 var fileDescriptor_f2a9abb617589e2c = []byte{
-	// 652 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x5d, 0x4f, 0xdb, 0x30,
-	0x14, 0x6d, 0x9a, 0xb4, 0xa3, 0xb7, 0x7c, 0x74, 0x06, 0xb4, 0xa8, 0xda, 0x10, 0x78, 0x68, 0xc0,
-	0x4b, 0x3a, 0x15, 0x6d, 0x43, 0xec, 0x61, 0x1a, 0x6d, 0x07, 0x48, 0x1b, 0x54, 0x2e, 0x63, 0xda,
-	0x5e, 0x50, 0x9a, 0x1a, 0xc8, 0x48, 0x93, 0xce, 0x71, 0x99, 0xba, 0x5f, 0xb1, 0x5f, 0xb4, 0x87,
-	0xfd, 0xb2, 0xc9, 0x76, 0x92, 0xba, 0x05, 0xba, 0xb7, 0x6b, 0xdf, 0x73, 0xce, 0xf5, 0xb5, 0x7d,
-	0x2e, 0x6c, 0x76, 0xdd, 0x5f, 0x7e, 0xe0, 0x44, 0xec, 0xaa, 0x26, 0xa3, 0xda, 0x80, 0x52, 0x56,
-	0xfb, 0xe9, 0x33, 0x2a, 0x23, 0x67, 0xc0, 0x22, 0x1e, 0x21, 0x50, 0x28, 0xb1, 0x53, 0xdd, 0x9a,
-	0x66, 0x78, 0x6e, 0xac, 0x08, 0x7d, 0x37, 0xf4, 0x2f, 0x69, 0xcc, 0x15, 0x09, 0x2f, 0x40, 0xb9,
-	0xed, 0x87, 0x57, 0x84, 0xfe, 0x18, 0xd2, 0x98, 0xe3, 0x45, 0x98, 0x57, 0xcb, 0x78, 0x10, 0x85,
-	0x31, 0xc5, 0x7b, 0x50, 0x39, 0xed, 0x7e, 0xa7, 0x1e, 0x6f, 0x0f, 0x79, 0x82, 0x41, 0x15, 0x30,
-	0x6f, 0xe8, 0xc8, 0x36, 0xd6, 0x8d, 0xed, 0x79, 0x22, 0x42, 0x84, 0xc0, 0xea, 0xb9, 0xdc, 0xb5,
-	0xf3, 0x72, 0x4b, 0xc6, 0x78, 0x19, 0x1e, 0x6b, 0xcc, 0x44, 0x6e, 0x33, 0x95, 0x3b, 0xa4, 0x0f,
-	0xcb, 0xe1, 0xad, 0x94, 0x2a, 0x51, 0x8a, 0x9a, 0xd5, 0x30, 0xb4, 0x1a, 0xaf, 0x61, 0xe5, 0x3c,
-	0x0a, 0x86, 0x7d, 0xda, 0x88, 0xc2, 0x90, 0x7a, 0x99, 0xe4, 0x1a, 0xc0, 0xad, 0xdc, 0x3f, 0x71,
-	0xfb, 0x54, 0x32, 0x4a, 0x44, 0xdb, 0xc1, 0xbb, 0xb0, 0x3a, 0xc5, 0x4b, 0x8a, 0x54, 0x61, 0x4e,
-	0xc1, 0x8e, 0x9b, 0x49, 0xa1, 0x6c, 0x8d, 0x0f, 0x53, 0x52, 0x67, 0x14, 0x7a, 0xed, 0x61, 0x10,
-	0xa4, 0xd5, 0x66, 0x90, 0xc4, 0xa9, 0x07, 0x2e, 0xbf, 0x96, 0x37, 0x53, 0x22, 0x32, 0xc6, 0x7f,
-	0xf3, 0x80, 0x26, 0x95, 0x8e, 0x39, 0xed, 0xa3, 0x7d, 0x28, 0x50, 0xc6, 0x22, 0x26, 0x35, 0x16,
-	0xeb, 0x9b, 0xce, 0xf8, 0x39, 0x9d, 0xbb, 0x70, 0xa7, 0x25, 0xb0, 0x44, 0x51, 0xd0, 0x3b, 0x28,
-	0x08, 0x5c, 0x6c, 0xe7, 0xd7, 0xcd, 0xed, 0x72, 0x7d, 0xe7, 0x3f, 0xdc, 0xb6, 0xc0, 0xb6, 0x42,
-	0xce, 0x46, 0x44, 0xf1, 0x44, 0x0f, 0x3d, 0x9f, 0x35, 0x82, 0xc8, 0xbb, 0xb1, 0x2d, 0xd5, 0x43,
-	0xba, 0x46, 0x0e, 0xcc, 0x79, 0xd7, 0x7e, 0xd0, 0x63, 0x34, 0xb4, 0x4d, 0xa9, 0x8f, 0x74, 0xfd,
-	0xa6, 0xcf, 0x68, 0xc8, 0x49, 0x86, 0xa9, 0xee, 0x01, 0x8c, 0x0b, 0xe8, 0xcf, 0xbb, 0xa0, 0x7e,
-	0xcb, 0x0a, 0x14, 0x6e, 0xdd, 0x60, 0x48, 0x93, 0xef, 0xa2, 0x16, 0xfb, 0xf9, 0x3d, 0x03, 0xef,
-	0x40, 0x41, 0xb6, 0x85, 0xca, 0xf0, 0xa8, 0xf3, 0xb9, 0xd1, 0x68, 0x75, 0x3a, 0x95, 0x1c, 0x5a,
-	0x86, 0xa5, 0x93, 0xd3, 0xb3, 0x8b, 0xf7, 0x17, 0xcd, 0x63, 0xd2, 0x6a, 0x9c, 0x9d, 0x92, 0xaf,
-	0x15, 0x03, 0xff, 0x31, 0xa0, 0xa8, 0x2a, 0x8b, 0x3b, 0x0e, 0xc7, 0xef, 0x2c, 0x63, 0xf4, 0x02,
-	0xac, 0x4b, 0x3f, 0x50, 0x25, 0xca, 0xf5, 0x8a, 0x7e, 0xde, 0x0f, 0x7e, 0x40, 0x8f, 0x72, 0x44,
-	0xe6, 0xd1, 0x73, 0x30, 0x7b, 0x3e, 0xb3, 0x4d, 0x09, 0x5b, 0x9a, 0x6a, 0xeb, 0x28, 0x47, 0x44,
-	0x16, 0xbd, 0x82, 0x12, 0x8f, 0xfa, 0xdd, 0x98, 0x47, 0x21, 0xb5, 0x0b, 0x12, 0xba, 0xaa, 0x43,
-	0xcf, 0xd2, 0xe4, 0x51, 0x8e, 0x8c, 0x91, 0xa2, 0x4f, 0x4f, 0xbb, 0x50, 0xb5, 0x38, 0x28, 0x82,
-	0xc5, 0x47, 0x03, 0x8a, 0xdf, 0x80, 0x25, 0x4e, 0x82, 0x6a, 0x30, 0x97, 0x5a, 0x52, 0x76, 0x50,
-	0xae, 0x2f, 0x27, 0xda, 0x9e, 0x1b, 0x3b, 0x9f, 0x92, 0x14, 0xc9, 0x40, 0xb8, 0x00, 0x66, 0xd3,
-	0x67, 0xb8, 0x0c, 0xa5, 0xac, 0x6e, 0xfd, 0xb7, 0x09, 0x96, 0xb8, 0x73, 0xf4, 0x16, 0x2c, 0xe1,
-	0x5f, 0xf4, 0x44, 0x3f, 0x9f, 0x66, 0xf0, 0xaa, 0x7d, 0x37, 0x91, 0x78, 0x33, 0x87, 0x3e, 0x42,
-	0x29, 0xb3, 0x2c, 0x7a, 0xaa, 0x03, 0xa7, 0x67, 0x40, 0xf5, 0xd9, 0x03, 0xd9, 0x54, 0x6b, 0xdb,
-	0x18, 0xab, 0x1d, 0xd2, 0x7b, 0xd5, 0xc6, 0x23, 0xe0, 0x3e, 0x35, 0xcd, 0xfa, 0x38, 0xf7, 0xd2,
-	0x40, 0xe7, 0xb0, 0x30, 0x61, 0x59, 0xb4, 0x7e, 0xf7, 0x8f, 0x4f, 0x4e, 0x81, 0xea, 0xc6, 0x0c,
-	0x44, 0xd6, 0xf3, 0x17, 0x58, 0x9c, 0x34, 0x08, 0xda, 0x78, 0xd8, 0x3c, 0xa9, 0xf2, 0xda, 0x6c,
-	0x7f, 0x89, 0x03, 0x1f, 0x14, 0xbf, 0x59, 0x62, 0xde, 0x76, 0x8b, 0x72, 0xce, 0xee, 0xfe, 0x0b,
-	0x00, 0x00, 0xff, 0xff, 0x52, 0xcb, 0x9d, 0x70, 0xc4, 0x05, 0x00, 0x00,
+	// 738 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x41, 0x53, 0x1a, 0x4b,
+	0x10, 0x66, 0x61, 0x41, 0xe9, 0x45, 0xc5, 0x51, 0xdf, 0xdb, 0xa2, 0xde, 0xb3, 0x74, 0x9e, 0xef,
+	0xa9, 0x17, 0x78, 0x85, 0x95, 0xc4, 0x32, 0x87, 0x54, 0x04, 0xa2, 0x56, 0x8c, 0x52, 0x83, 0x31,
+	0x95, 0x5c, 0xac, 0x65, 0x19, 0x75, 0xe2, 0xb2, 0x4b, 0x66, 0x07, 0x53, 0xe4, 0x9e, 0xbf, 0x95,
+	0x43, 0xee, 0xf9, 0x4f, 0xa9, 0x99, 0xd9, 0x85, 0x05, 0x94, 0x1c, 0x72, 0xeb, 0x9e, 0xfe, 0xfa,
+	0xeb, 0xee, 0xe9, 0x9e, 0x1e, 0xd8, 0x6a, 0x3b, 0x5f, 0x98, 0x57, 0x0e, 0xf8, 0x4d, 0x45, 0x49,
+	0x95, 0x1e, 0xa5, 0xbc, 0xf2, 0x99, 0x71, 0xaa, 0xa4, 0x72, 0x8f, 0x07, 0x22, 0x40, 0xa0, 0x51,
+	0xf2, 0xa4, 0xb4, 0x3d, 0xe9, 0xe1, 0x3a, 0xa1, 0x76, 0xe8, 0x3a, 0x3e, 0xbb, 0xa6, 0xa1, 0xd0,
+	0x4e, 0xf8, 0x35, 0x58, 0x4d, 0xe6, 0xdf, 0x10, 0xfa, 0xa9, 0x4f, 0x43, 0x81, 0x6c, 0x98, 0xbb,
+	0xa7, 0x3c, 0x64, 0x81, 0x6f, 0x1b, 0x1b, 0xc6, 0xce, 0x02, 0x89, 0x55, 0x84, 0xa1, 0xe0, 0x3a,
+	0x3d, 0xa7, 0xcd, 0x3c, 0x26, 0x18, 0x0d, 0xed, 0xf4, 0x46, 0x66, 0x27, 0x4f, 0xc6, 0xce, 0xf0,
+	0x29, 0x14, 0x34, 0x59, 0xd8, 0x0b, 0xfc, 0x90, 0xfe, 0x26, 0xdb, 0x3e, 0x14, 0xcf, 0xdb, 0x1f,
+	0xa9, 0x2b, 0x9a, 0x7d, 0x11, 0xe7, 0x57, 0x84, 0xcc, 0x1d, 0x1d, 0x28, 0xb6, 0x02, 0x91, 0x22,
+	0x42, 0x60, 0x76, 0x1c, 0xe1, 0xd8, 0x69, 0x75, 0xa4, 0x64, 0xbc, 0x02, 0xcb, 0x09, 0x4f, 0x9d,
+	0x0c, 0xde, 0x8a, 0xe9, 0x8e, 0xe8, 0xe3, 0x74, 0x78, 0x1b, 0x96, 0x13, 0xa8, 0xa8, 0x8e, 0x38,
+	0x86, 0x91, 0x88, 0xf1, 0x14, 0x56, 0x2f, 0x03, 0xaf, 0xdf, 0xa5, 0xb5, 0xc0, 0xf7, 0xa9, 0x3b,
+	0xa4, 0x5c, 0x07, 0xb8, 0x57, 0xe7, 0x67, 0x4e, 0x97, 0x2a, 0x8f, 0x3c, 0x49, 0x9c, 0xe0, 0x3d,
+	0x58, 0x9b, 0xf0, 0x8b, 0x82, 0x94, 0x60, 0x5e, 0xc3, 0x4e, 0xea, 0x51, 0xa0, 0xa1, 0x8e, 0xbf,
+	0x1a, 0xb1, 0x57, 0x6b, 0xe0, 0xbb, 0xcd, 0xbe, 0xe7, 0xc5, 0xe1, 0x66, 0x78, 0xc9, 0xb4, 0x7b,
+	0x8e, 0xb8, 0x55, 0x57, 0x93, 0x27, 0x4a, 0x4e, 0xb6, 0x24, 0x33, 0xbb, 0x25, 0xe6, 0x03, 0x2d,
+	0xf9, 0x9e, 0x06, 0x34, 0x9e, 0xc7, 0x89, 0xa0, 0x5d, 0x74, 0x00, 0x59, 0xca, 0x79, 0xc0, 0x55,
+	0x06, 0x8b, 0xd5, 0xad, 0xf2, 0x68, 0x12, 0xcb, 0xd3, 0xf0, 0x72, 0x43, 0x62, 0x89, 0x76, 0x41,
+	0x2f, 0x20, 0x2b, 0x71, 0x7a, 0x04, 0xac, 0xea, 0xee, 0x2f, 0x7c, 0x9b, 0x12, 0xdb, 0xf0, 0x05,
+	0x1f, 0x10, 0xed, 0x27, 0x6f, 0xa0, 0xc3, 0x78, 0xcd, 0x0b, 0xdc, 0x3b, 0xdb, 0xd4, 0x37, 0x10,
+	0xeb, 0xa8, 0x0c, 0xf3, 0xee, 0x2d, 0xf3, 0x3a, 0x9c, 0xca, 0x72, 0x25, 0x3f, 0x4a, 0xf2, 0xd7,
+	0x19, 0xa7, 0xbe, 0x20, 0x43, 0x4c, 0x69, 0x1f, 0x60, 0x14, 0x20, 0x39, 0x1d, 0x0b, 0x7a, 0xd8,
+	0x56, 0x21, 0x7b, 0xef, 0x78, 0x7d, 0x1a, 0x4d, 0x9b, 0x56, 0x0e, 0xd2, 0xfb, 0x06, 0xde, 0x85,
+	0xac, 0x2a, 0x0b, 0x59, 0x30, 0xd7, 0x7a, 0x5b, 0xab, 0x35, 0x5a, 0xad, 0x62, 0x0a, 0xad, 0xc0,
+	0xd2, 0xd9, 0xf9, 0xc5, 0xd5, 0xcb, 0xab, 0xfa, 0x09, 0x69, 0xd4, 0x2e, 0xce, 0xc9, 0xfb, 0xa2,
+	0x81, 0xbf, 0x19, 0x90, 0xd3, 0x91, 0x65, 0x87, 0xfc, 0xd1, 0x98, 0x28, 0x19, 0xfd, 0x07, 0xe6,
+	0x35, 0xf3, 0x74, 0x08, 0xab, 0x5a, 0x4c, 0xe6, 0xfb, 0x8a, 0x79, 0xf4, 0x38, 0x45, 0x94, 0x1d,
+	0xfd, 0x03, 0x99, 0x0e, 0xe3, 0xaa, 0x8b, 0x56, 0x75, 0x69, 0xa2, 0xac, 0xe3, 0x14, 0x91, 0x56,
+	0xf4, 0x04, 0xf2, 0x22, 0xe8, 0xb6, 0x43, 0x11, 0xf8, 0xd4, 0xce, 0x2a, 0xe8, 0x5a, 0x12, 0x7a,
+	0x11, 0x1b, 0x8f, 0x53, 0x64, 0x84, 0x94, 0x75, 0xba, 0x89, 0x0b, 0xd5, 0xca, 0x61, 0x0e, 0x4c,
+	0x31, 0xe8, 0x51, 0xfc, 0x0c, 0x4c, 0x99, 0x09, 0xaa, 0xc0, 0x7c, 0xbc, 0x4d, 0x54, 0x05, 0x56,
+	0x75, 0x25, 0xe2, 0x76, 0x9d, 0xb0, 0xfc, 0x26, 0x32, 0x91, 0x21, 0x08, 0x67, 0x21, 0x53, 0x67,
+	0x1c, 0x5b, 0x90, 0x1f, 0xc6, 0xc5, 0xff, 0x82, 0xd5, 0x74, 0x18, 0x8f, 0xe7, 0xf9, 0x0f, 0xc8,
+	0x85, 0xd4, 0xe5, 0x54, 0x44, 0xd3, 0x1c, 0x69, 0x78, 0x11, 0x0a, 0x1a, 0xa6, 0x5f, 0x4b, 0xf5,
+	0x47, 0x06, 0x4c, 0xd9, 0x2a, 0xf4, 0x1c, 0x4c, 0xb9, 0x73, 0xd0, 0x9f, 0xc9, 0xb2, 0x12, 0x2b,
+	0xad, 0x64, 0x4f, 0x1b, 0xa2, 0x8d, 0x90, 0x42, 0xa7, 0x90, 0x1f, 0x2e, 0x0a, 0xf4, 0x57, 0x12,
+	0x38, 0xb9, 0x79, 0x4a, 0x7f, 0x3f, 0x62, 0x8d, 0xb9, 0x76, 0x8c, 0x11, 0xdb, 0x11, 0x7d, 0x90,
+	0xed, 0x88, 0xce, 0x62, 0x4b, 0x2c, 0x1c, 0x9c, 0xfa, 0xdf, 0x40, 0x97, 0xb0, 0x30, 0xb6, 0x28,
+	0xd0, 0xc6, 0xf4, 0xd3, 0x18, 0xdf, 0x3d, 0xa5, 0xcd, 0x19, 0x88, 0x61, 0xcd, 0xef, 0x60, 0x71,
+	0xfc, 0x5d, 0xa1, 0xcd, 0xc7, 0xdf, 0x5c, 0xcc, 0xbc, 0x3e, 0xfb, 0x59, 0xaa, 0x84, 0x65, 0x27,
+	0x1c, 0xc6, 0x27, 0x3a, 0x31, 0xea, 0x6d, 0xc9, 0x9e, 0x36, 0xc4, 0x59, 0x1d, 0xe6, 0x3e, 0x98,
+	0xf2, 0x7b, 0x6a, 0xe7, 0xd4, 0xb7, 0xb4, 0xf7, 0x73, 0x00, 0xde, 0x28, 0x64, 0x78, 0xf3, 0x06,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -771,6 +909,7 @@ type PeerClient interface {
 	ObjectGet(ctx context.Context, in *ObjectGetRequest, opts ...grpc.CallOption) (Peer_ObjectGetClient, error)
 	VolumeConnect(ctx context.Context, in *VolumeConnectRequest, opts ...grpc.CallOption) (*VolumeConnectResponse, error)
 	VolumeSyncPull(ctx context.Context, in *VolumeSyncPullRequest, opts ...grpc.CallOption) (Peer_VolumeSyncPullClient, error)
+	Pair(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (*PairResponse, error)
 }
 
 type peerClient struct {
@@ -897,6 +1036,15 @@ func (x *peerVolumeSyncPullClient) Recv() (*VolumeSyncPullItem, error) {
 	return m, nil
 }
 
+func (c *peerClient) Pair(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (*PairResponse, error) {
+	out := new(PairResponse)
+	err := c.cc.Invoke(ctx, "/bazil.peer.Peer/Pair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PeerServer is the server API for Peer service.
 type PeerServer interface {
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
@@ -904,6 +1052,7 @@ type PeerServer interface {
 	ObjectGet(*ObjectGetRequest, Peer_ObjectGetServer) error
 	VolumeConnect(context.Context, *VolumeConnectRequest) (*VolumeConnectResponse, error)
 	VolumeSyncPull(*VolumeSyncPullRequest, Peer_VolumeSyncPullServer) error
+	Pair(context.Context, *PairRequest) (*PairResponse, error)
 }
 
 // UnimplementedPeerServer can be embedded to have forward compatible implementations.
@@ -925,6 +1074,9 @@ func (*UnimplementedPeerServer) VolumeConnect(ctx context.Context, req *VolumeCo
 func (*UnimplementedPeerServer) VolumeSyncPull(req *VolumeSyncPullRequest, srv Peer_VolumeSyncPullServer) error {
 	return status.Errorf(codes.Unimplemented, "method VolumeSyncPull not implemented")
 }
+func (*UnimplementedPeerServer) Pair(ctx context.Context, req *PairRequest) (*PairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pair not implemented")
+}
 
 func RegisterPeerServer(s *grpc.Server, srv PeerServer) {
 	s.RegisterService(&_Peer_serviceDesc, srv)
@@ -1034,6 +1186,24 @@ func (x *peerVolumeSyncPullServer) Send(m *VolumeSyncPullItem) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Peer_Pair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServer).Pair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.peer.Peer/Pair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServer).Pair(ctx, req.(*PairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Peer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "bazil.peer.Peer",
 	HandlerType: (*PeerServer)(nil),
@@ -1046,6 +1216,10 @@ var _Peer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeConnect",
 			Handler:    _Peer_VolumeConnect_Handler,
 		},
+		{
+			MethodName: "Pair",
+			Handler:    _Peer_Pair_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"log"
+	"path/filepath"
+
+	"bazil.org/bazil/server/audit"
+)
+
+// AuditDir returns the directory holding the audit log of the server
+// with the given data directory.
+func AuditDir(dataDir string) string {
+	return filepath.Join(dataDir, "audit")
+}
+
+// Audit appends a record to the audit log. Failing to record does
+// not fail the operation, it is only logged.
+func (app *App) Audit(r *audit.Record) {
+	if err := app.audit.Write(r); err != nil {
+		log.Printf("audit log error: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+// Package audit keeps a record of the operations peers and local
+// users perform on the server.
+//
+// The log is a directory of files with one JSON record per line. New
+// records are appended to the current file; when it grows too big,
+// it is renamed aside and a new one started, and the oldest files
+// are removed.
+package audit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Name of the file being appended to. Older files have a
+	// numeric suffix, with higher numbers being older.
+	currentName = "audit.log"
+
+	defaultMaxSize = 16 << 20
+	defaultKeep    = 4
+)
+
+// Record describes one operation.
+type Record struct {
+	Time time.Time `json:"time"`
+	// Public key of the calling peer, for peer calls.
+	Peer string `json:"peer,omitempty"`
+	// User and process ID of the caller, for control calls. Nil when
+	// the platform cannot tell.
+	UID *uint32 `json:"uid,omitempty"`
+	PID *int32  `json:"pid,omitempty"`
+	// Full gRPC method name.
+	RPC string `json:"rpc"`
+	// What the operation was about, as far as the request tells.
+	Volume     string `json:"volume,omitempty"`
+	VolumeID   string `json:"volumeID,omitempty"`
+	Object     string `json:"object,omitempty"`
+	TargetPeer string `json:"targetPeer,omitempty"`
+	// gRPC status code name, "OK" on success.
+	Code  string `json:"code"`
+	Error string `json:"error,omitempty"`
+}
+
+// Note fills in what a request message says about the operation.
+// Only fields known to be safe to record are looked at; requests
+// also carry secrets.
+func (r *Record) Note(msg interface{}) {
+	if m, ok := msg.(interface{ GetVolumeName() string }); ok && r.Volume == "" {
+		r.Volume = m.GetVolumeName()
+	}
+	if m, ok := msg.(interface{ GetVolumeID() []byte }); ok && r.VolumeID == "" {
+		r.VolumeID = hex.EncodeToString(m.GetVolumeID())
+	}
+	if m, ok := msg.(interface{ GetKey() []byte }); ok && r.Object == "" {
+		r.Object = hex.EncodeToString(m.GetKey())
+	}
+	if m, ok := msg.(interface{ GetPub() []byte }); ok && r.TargetPeer == "" {
+		r.TargetPeer = hex.EncodeToString(m.GetPub())
+	}
+}
+
+// Finish records the outcome of the operation.
+func (r *Record) Finish(err error) {
+	s := status.Convert(err)
+	r.Code = s.Code().String()
+	r.Error = s.Message()
+}
+
+// Log appends records to the files in a directory.
+type Log struct {
+	dir     string
+	maxSize int64
+	keep    int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// Open opens the audit log in dir, creating it if needed.
+func Open(dir string) (*Log, error) {
+	return open(dir, defaultMaxSize, defaultKeep)
+}
+
+func open(dir string, maxSize int64, keep int) (*Log, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	l := &Log{
+		dir:     dir,
+		maxSize: maxSize,
+		keep:    keep,
+	}
+	if err := l.openCurrent(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Log) path(n int) string {
+	if n == 0 {
+		return filepath.Join(l.dir, currentName)
+	}
+	return filepath.Join(l.dir, fmt.Sprintf("%s.%d", currentName, n))
+}
+
+func (l *Log) openCurrent() error {
+	f, err := os.OpenFile(l.path(0), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	l.f = f
+	l.size = fi.Size()
+	return nil
+}
+
+// rotate starts a new current file. Caller must hold l.mu.
+func (l *Log) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	l.f = nil
+	if err := os.Remove(l.path(l.keep)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for n := l.keep - 1; n >= 0; n-- {
+		if err := os.Rename(l.path(n), l.path(n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return l.openCurrent()
+}
+
+// Write appends a record to the log. The time is set to now, if
+// zero.
+func (l *Log) Write(r *Record) error {
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		// a failed rotation; try again
+		if err := l.openCurrent(); err != nil {
+			return err
+		}
+	}
+	if l.size > 0 && l.size+int64(len(buf)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.f.Write(buf)
+	l.size += int64(n)
+	return err
+}
+
+// Close closes the log.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testRequest struct {
+	name string
+	key  []byte
+}
+
+func (r *testRequest) GetVolumeName() string { return r.name }
+func (r *testRequest) GetKey() []byte        { return r.key }
+
+func TestRecord(t *testing.T) {
+	r := &Record{}
+	r.Note(&testRequest{name: "vol", key: []byte{0xab, 0xcd}})
+	r.Finish(status.Errorf(codes.PermissionDenied, "no way"))
+	if g, e := r.Volume, "vol"; g != e {
+		t.Errorf("wrong volume: %q != %q", g, e)
+	}
+	if g, e := r.Object, "abcd"; g != e {
+		t.Errorf("wrong object: %q != %q", g, e)
+	}
+	if g, e := r.Code, "PermissionDenied"; g != e {
+		t.Errorf("wrong code: %q != %q", g, e)
+	}
+	if g, e := r.Error, "no way"; g != e {
+		t.Errorf("wrong error: %q != %q", g, e)
+	}
+
+	// later messages of a stream do not override the first
+	r.Note(&testRequest{name: "other"})
+	if g, e := r.Volume, "vol"; g != e {
+		t.Errorf("wrong volume: %q != %q", g, e)
+	}
+
+	r = &Record{}
+	r.Finish(nil)
+	if g, e := r.Code, "OK"; g != e {
+		t.Errorf("wrong code: %q != %q", g, e)
+	}
+	r.Finish(errors.New("plain"))
+	if g, e := r.Code, "Unknown"; g != e {
+		t.Errorf("wrong code: %q != %q", g, e)
+	}
+}
+
+func TestTail(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+
+	records, err := Tail(tmp.Path, 10)
+	if err != nil {
+		t.Fatalf("Tail of empty log: %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("unexpected records: %v", records)
+	}
+
+	// small enough to rotate every few records
+	l, err := open(tmp.Path, 300, 2)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer l.Close()
+	for i := 0; i < 20; i++ {
+		if err := l.Write(&Record{RPC: fmt.Sprintf("rpc%d", i)}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	paths, err := files(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := len(paths), 3; g != e {
+		t.Fatalf("wrong number of files: %d != %d: %v", g, e, paths)
+	}
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() > 300 {
+			t.Errorf("file too big: %s: %d", p, fi.Size())
+		}
+	}
+
+	records, err = Tail(tmp.Path, 5)
+	if err != nil {
+		t.Fatalf("Tail: %v", err)
+	}
+	if g, e := len(records), 5; g != e {
+		t.Fatalf("wrong number of records: %d != %d", g, e)
+	}
+	for i, r := range records {
+		if g, e := r.RPC, fmt.Sprintf("rpc%d", 15+i); g != e {
+			t.Errorf("wrong record %d: %q != %q", i, g, e)
+		}
+	}
+
+	// asking for more than there is returns what was kept
+	records, err = Tail(tmp.Path, 1000)
+	if err != nil {
+		t.Fatalf("Tail: %v", err)
+	}
+	if len(records) >= 20 {
+		t.Errorf("old files were not removed: %d records", len(records))
+	}
+	if g, e := records[len(records)-1].RPC, "rpc19"; g != e {
+		t.Errorf("wrong last record: %q != %q", g, e)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+
+	l, err := open(tmp.Path, 300, 2)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer l.Close()
+	for i := 0; i < 5; i++ {
+		if err := l.Write(&Record{RPC: fmt.Sprintf("rpc%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the backlog spans a rotation
+	fl, err := Follow(tmp.Path, 4)
+	if err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+	defer fl.Close()
+
+	var got []string
+	for i := 5; i < 20; i++ {
+		if err := l.Write(&Record{RPC: fmt.Sprintf("rpc%d", i)}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		// poll now and then, so some rotations happen in between
+		if i%3 == 0 {
+			records, err := fl.Poll()
+			if err != nil {
+				t.Fatalf("Poll: %v", err)
+			}
+			for _, r := range records {
+				got = append(got, r.RPC)
+			}
+		}
+	}
+	records, err := fl.Poll()
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	for _, r := range records {
+		got = append(got, r.RPC)
+	}
+
+	if g, e := len(got), 19; g != e {
+		t.Fatalf("wrong number of records: %d != %d: %v", g, e, got)
+	}
+	for i, rpc := range got {
+		if g, e := rpc, fmt.Sprintf("rpc%d", i+1); g != e {
+			t.Errorf("wrong record %d: %q != %q", i, g, e)
+		}
+	}
+}
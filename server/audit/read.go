@@ -0,0 +1,247 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// files returns the paths of the log files in dir, oldest first.
+func files(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, currentName+".*"))
+	if err != nil {
+		return nil, err
+	}
+	type numbered struct {
+		path string
+		n    int
+	}
+	var old []numbered
+	for _, p := range matches {
+		n, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(p), currentName+"."))
+		if err != nil || n <= 0 {
+			// not ours
+			continue
+		}
+		old = append(old, numbered{path: p, n: n})
+	}
+	sort.Slice(old, func(i, j int) bool { return old[i].n > old[j].n })
+	var paths []string
+	for _, o := range old {
+		paths = append(paths, o.path)
+	}
+	cur := filepath.Join(dir, currentName)
+	if _, err := os.Stat(cur); err == nil {
+		paths = append(paths, cur)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// parse decodes the complete lines in buf. A partial last line is
+// ignored.
+func parse(path string, buf []byte) ([]*Record, error) {
+	var records []*Record
+	for len(buf) > 0 {
+		idx := bytes.IndexByte(buf, '\n')
+		if idx < 0 {
+			break
+		}
+		line := buf[:idx]
+		buf = buf[idx+1:]
+		var r Record
+		if err := json.Unmarshal(line, &r); err != nil {
+			return nil, fmt.Errorf("%s: corrupt audit record: %v", path, err)
+		}
+		records = append(records, &r)
+	}
+	return records, nil
+}
+
+// last returns the last n records in the given files, which are
+// oldest first.
+func last(paths []string, n int) ([]*Record, error) {
+	var records []*Record
+	for i := len(paths) - 1; i >= 0 && len(records) < n; i-- {
+		buf, err := ioutil.ReadFile(paths[i])
+		if err != nil {
+			if os.IsNotExist(err) {
+				// rotated away while we were reading
+				continue
+			}
+			return nil, err
+		}
+		older, err := parse(paths[i], buf)
+		if err != nil {
+			return nil, err
+		}
+		records = append(older, records...)
+	}
+	if len(records) > n {
+		records = records[len(records)-n:]
+	}
+	return records, nil
+}
+
+// Tail returns the last n records in the audit log in dir, oldest
+// first.
+func Tail(dir string, n int) ([]*Record, error) {
+	paths, err := files(dir)
+	if err != nil {
+		return nil, err
+	}
+	return last(paths, n)
+}
+
+// Follower reads records as they are appended to the log, across
+// rotations.
+type Follower struct {
+	path    string
+	f       *os.File
+	r       *bufio.Reader
+	partial []byte
+	// Records to return from the next Poll, before new ones.
+	backlog []*Record
+}
+
+// Follow starts following the audit log in dir. The first Poll also
+// returns up to n records that were already in the log.
+func Follow(dir string, n int) (*Follower, error) {
+	path := filepath.Join(dir, currentName)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fl := &Follower{
+		path: path,
+		f:    f,
+		r:    bufio.NewReader(f),
+	}
+	if err := fl.start(dir, n); err != nil {
+		_ = fl.Close()
+		return nil, err
+	}
+	return fl, nil
+}
+
+// start reads the records already in the log.
+func (fl *Follower) start(dir string, n int) error {
+	records, err := fl.read()
+	if err != nil {
+		return err
+	}
+	if len(records) < n {
+		paths, err := files(dir)
+		if err != nil {
+			return err
+		}
+		ours, err := fl.f.Stat()
+		if err != nil {
+			return err
+		}
+		// Only files older than ours; it may have been rotated
+		// already, and then it is not the last one.
+		var older []string
+		for _, p := range paths {
+			fi, err := os.Stat(p)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return err
+			}
+			if os.SameFile(fi, ours) {
+				break
+			}
+			older = append(older, p)
+		}
+		more, err := last(older, n-len(records))
+		if err != nil {
+			return err
+		}
+		records = append(more, records...)
+	}
+	if len(records) > n {
+		records = records[len(records)-n:]
+	}
+	fl.backlog = records
+	return nil
+}
+
+// read returns the records appended to the open file since the last
+// call.
+func (fl *Follower) read() ([]*Record, error) {
+	buf, err := ioutil.ReadAll(fl.r)
+	if err != nil {
+		return nil, err
+	}
+	buf = append(fl.partial, buf...)
+	records, err := parse(fl.path, buf)
+	if err != nil {
+		return nil, err
+	}
+	fl.partial = buf[bytes.LastIndexByte(buf, '\n')+1:]
+	return records, nil
+}
+
+// Poll returns the records appended since the last call, if any.
+func (fl *Follower) Poll() ([]*Record, error) {
+	records, err := fl.read()
+	if err != nil {
+		return nil, err
+	}
+	if fl.backlog != nil {
+		records = append(fl.backlog, records...)
+		fl.backlog = nil
+	}
+
+	cur, err := os.Stat(fl.path)
+	if os.IsNotExist(err) {
+		// in the middle of a rotation
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ours, err := fl.f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if os.SameFile(cur, ours) {
+		return records, nil
+	}
+
+	// Rotated. The old file was closed by the writer before being
+	// renamed, so whatever it has now is all there will be.
+	rest, err := fl.read()
+	if err != nil {
+		return nil, err
+	}
+	records = append(records, rest...)
+	f, err := os.Open(fl.path)
+	if err != nil {
+		return nil, err
+	}
+	_ = fl.f.Close()
+	fl.f = f
+	fl.r.Reset(f)
+	fl.partial = nil
+	more, err := fl.read()
+	if err != nil {
+		return nil, err
+	}
+	return append(records, more...), nil
+}
+
+// Close stops following.
+func (fl *Follower) Close() error {
+	return fl.f.Close()
+}
@@ -0,0 +1,32 @@
+package control
+
+import (
+	"context"
+	"time"
+
+	"bazil.org/bazil/server/audit"
+	"google.golang.org/grpc"
+)
+
+func (c *Control) record(ctx context.Context, method string) *audit.Record {
+	r := &audit.Record{
+		Time: time.Now(),
+		RPC:  method,
+	}
+	if cred := callerCredentials(ctx); cred != nil {
+		uid, pid := cred.uid, cred.pid
+		r.UID = &uid
+		r.PID = &pid
+	}
+	return r
+}
+
+// auditUnary records every administrative call in the audit log.
+func (c *Control) auditUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	r := c.record(ctx, info.FullMethod)
+	r.Note(req)
+	resp, err := handler(ctx, req)
+	r.Finish(err)
+	c.app.Audit(r)
+	return resp, err
+}
@@ -0,0 +1,86 @@
+package control_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/audit"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestAudit(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Subdir("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	pub := peer.PublicKey{1, 2, 3}
+	if _, err := rpcClient.PeerAdd(ctx, &wire.PeerAddRequest{Pub: pub[:]}); err != nil {
+		t.Fatalf("peer add failed: %v", err)
+	}
+	if _, err := rpcClient.VolumeGet(ctx, &wire.VolumeGetRequest{VolumeName: "missing"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	records, err := audit.Tail(server.AuditDir(app.DataDir), 10)
+	if err != nil {
+		t.Fatalf("audit tail: %v", err)
+	}
+	if g, e := len(records), 2; g != e {
+		t.Fatalf("wrong number of audit records: %d != %d", g, e)
+	}
+
+	add := records[0]
+	if g, e := add.RPC, "/bazil.control.Control/PeerAdd"; g != e {
+		t.Errorf("wrong rpc: %q != %q", g, e)
+	}
+	if g, e := add.TargetPeer, pub.String(); g != e {
+		t.Errorf("wrong target peer: %q != %q", g, e)
+	}
+	if g, e := add.Code, "OK"; g != e {
+		t.Errorf("wrong code: %q != %q", g, e)
+	}
+	if runtime.GOOS == "linux" {
+		if add.UID == nil {
+			t.Error("missing uid")
+		} else if g, e := *add.UID, uint32(os.Getuid()); g != e {
+			t.Errorf("wrong uid: %d != %d", g, e)
+		}
+	}
+
+	get := records[1]
+	if g, e := get.RPC, "/bazil.control.Control/VolumeGet"; g != e {
+		t.Errorf("wrong rpc: %q != %q", g, e)
+	}
+	if g, e := get.Volume, "missing"; g != e {
+		t.Errorf("wrong volume: %q != %q", g, e)
+	}
+	if get.Code == "OK" || get.Error == "" {
+		t.Errorf("failure not recorded: %+v", get)
+	}
+}
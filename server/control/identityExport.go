@@ -0,0 +1,21 @@
+package control
+
+import (
+	"context"
+
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) IdentityExport(ctx context.Context, req *wire.IdentityExportRequest) (*wire.IdentityExportResponse, error) {
+	if len(req.Passphrase) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "passphrase is required")
+	}
+	armored, err := server.ExportIdentity(c.app.Keys, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.IdentityExportResponse{Armored: armored}, nil
+}
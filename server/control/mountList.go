@@ -0,0 +1,38 @@
+package control
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) MountList(ctx context.Context, req *wire.MountListRequest) (*wire.MountListResponse, error) {
+	mounts := c.app.Mounts()
+	sort.Slice(mounts, func(i, j int) bool {
+		return mounts[i].Mountpoint < mounts[j].Mountpoint
+	})
+
+	resp := &wire.MountListResponse{}
+	list := func(tx *db.Tx) error {
+		names := volumeNames(tx)
+		for _, m := range mounts {
+			volID := m.VolumeID
+			resp.Mounts = append(resp.Mounts, &wire.MountInfo{
+				VolumeName: names[volID],
+				VolumeID:   volID[:],
+				Mountpoint: m.Mountpoint,
+			})
+		}
+		return nil
+	}
+	if err := c.app.DB.View(list); err != nil {
+		log.Printf("db error: listing mounts: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return resp, nil
+}
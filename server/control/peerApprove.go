@@ -0,0 +1,27 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) PeerApprove(ctx context.Context, req *wire.PeerApproveRequest) (*wire.PeerApproveResponse, error) {
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(req.Pub); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+	}
+	if err := c.app.ApprovePeer(&pub); err != nil {
+		if err == server.ErrPeerNotPending {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		log.Printf("db update error: approve peer %v: %v", &pub, err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return &wire.PeerApproveResponse{}, nil
+}
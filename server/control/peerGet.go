@@ -0,0 +1,41 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) PeerGet(ctx context.Context, req *wire.PeerGetRequest) (*wire.PeerGetResponse, error) {
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(req.Pub); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+	}
+
+	resp := &wire.PeerGetResponse{}
+	get := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(&pub)
+		if err != nil {
+			return err
+		}
+		info, err := peerInfo(p, volumeNames(tx))
+		if err != nil {
+			return err
+		}
+		resp.Peer = info
+		return nil
+	}
+	if err := c.app.DB.View(get); err != nil {
+		if err == db.ErrPeerNotFound {
+			return nil, status.Errorf(codes.NotFound, "peer not found")
+		}
+		log.Printf("db error: getting peer %x: %v", pub[:], err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return resp, nil
+}
@@ -0,0 +1,119 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPeerGet(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	pub := peer.PublicKey{1, 2, 3, 4, 5}
+	setup := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(&pub)
+		if err != nil {
+			return err
+		}
+		if err := p.Locations().Set("peer.example.com:1234"); err != nil {
+			return err
+		}
+		if err := p.Storage().Allow("local"); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := app.DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	resp, err := rpcClient.PeerGet(ctx, &wire.PeerGetRequest{Pub: pub[:]})
+	if err != nil {
+		t.Fatalf("getting peer failed: %v", err)
+	}
+	if g, e := resp.Peer.Id, uint32(1); g != e {
+		t.Errorf("wrong peer id: %v != %v", g, e)
+	}
+	if g, e := len(resp.Peer.Locations), 1; g != e {
+		t.Fatalf("wrong number of locations: %v != %v", g, e)
+	}
+	if g, e := resp.Peer.Locations[0], "peer.example.com:1234"; g != e {
+		t.Errorf("wrong location: %q != %q", g, e)
+	}
+	if g, e := len(resp.Peer.Storage), 1; g != e {
+		t.Fatalf("wrong number of storage: %v != %v", g, e)
+	}
+	if g, e := resp.Peer.Storage[0], "local"; g != e {
+		t.Errorf("wrong storage: %q != %q", g, e)
+	}
+
+	listResp, err := rpcClient.PeerList(ctx, &wire.PeerListRequest{})
+	if err != nil {
+		t.Fatalf("listing peers failed: %v", err)
+	}
+	if g, e := len(listResp.Peers), 1; g != e {
+		t.Fatalf("wrong number of peers: %v != %v", g, e)
+	}
+	if g, e := listResp.Peers[0].Pub, pub[:]; string(g) != string(e) {
+		t.Errorf("wrong peer listed: %x != %x", g, e)
+	}
+}
+
+func TestPeerGetNotFound(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	pub := peer.PublicKey{1, 2, 3, 4, 5}
+	ctx := context.Background()
+	_, err = rpcClient.PeerGet(ctx, &wire.PeerGetRequest{Pub: pub[:]})
+	if err == nil {
+		t.Fatalf("expected error from PeerGet of unknown peer")
+	}
+	if err := checkRPCError(err, codes.NotFound, "peer not found"); err != nil {
+		t.Error(err)
+	}
+}
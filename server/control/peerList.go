@@ -0,0 +1,76 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// volumeNames maps volume IDs to local volume names.
+func volumeNames(tx *db.Tx) map[db.VolumeID]string {
+	names := make(map[db.VolumeID]string)
+	c := tx.Volumes().Cursor()
+	for item := c.First(); item != nil; item = c.Next() {
+		var volID db.VolumeID
+		item.Volume().VolumeID(&volID)
+		names[volID] = item.Name()
+	}
+	return names
+}
+
+// peerInfo describes a peer for listing purposes.
+func peerInfo(p *db.Peer, names map[db.VolumeID]string) (*wire.PeerInfo, error) {
+	info := &wire.PeerInfo{
+		Pub: p.Pub()[:],
+		Id:  uint32(p.ID()),
+	}
+
+	info.Locations = p.Locations().List()
+
+	storage := p.Storage().Cursor()
+	for item := storage.First(); item != nil; item = storage.Next() {
+		info.Storage = append(info.Storage, item.Backend())
+	}
+
+	vols := p.Volumes().Cursor()
+	for item := vols.First(); item != nil; item = vols.Next() {
+		var volID db.VolumeID
+		item.VolumeID(&volID)
+		grant, err := item.Grant()
+		if err != nil {
+			return nil, err
+		}
+		info.Volumes = append(info.Volumes, &wire.PeerVolumeInfo{
+			VolumeID:   volID[:],
+			VolumeName: names[volID],
+			Paths:      grant.Paths,
+			ReadOnly:   grant.ReadOnly,
+		})
+	}
+	return info, nil
+}
+
+func (c controlRPC) PeerList(ctx context.Context, req *wire.PeerListRequest) (*wire.PeerListResponse, error) {
+	resp := &wire.PeerListResponse{}
+	list := func(tx *db.Tx) error {
+		names := volumeNames(tx)
+		c := tx.Peers().Cursor()
+		for p := c.First(); p != nil; p = c.Next() {
+			info, err := peerInfo(p, names)
+			if err != nil {
+				return err
+			}
+			resp.Peers = append(resp.Peers, info)
+		}
+		return nil
+	}
+	if err := c.app.DB.View(list); err != nil {
+		log.Printf("db error: listing peers: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return resp, nil
+}
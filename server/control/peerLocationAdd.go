@@ -0,0 +1,38 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) PeerLocationAdd(ctx context.Context, req *wire.PeerLocationAddRequest) (*wire.PeerLocationAddResponse, error) {
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(req.Pub); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+	}
+
+	addLoc := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(&pub)
+		if err != nil {
+			return err
+		}
+		return p.Locations().Add(req.Netloc, req.Priority)
+	}
+	if err := c.app.DB.Update(addLoc); err != nil {
+		switch err {
+		case db.ErrPeerNotFound:
+			return nil, status.Errorf(codes.InvalidArgument, "peer not found")
+		case db.ErrPeerLocationInvalid:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		log.Printf("db error: adding peer addr: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return &wire.PeerLocationAddResponse{}, nil
+}
@@ -0,0 +1,50 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) PeerLocationList(ctx context.Context, req *wire.PeerLocationListRequest) (*wire.PeerLocationListResponse, error) {
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(req.Pub); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+	}
+
+	resp := &wire.PeerLocationListResponse{}
+	list := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(&pub)
+		if err != nil {
+			return err
+		}
+		locs := p.Locations()
+		priorities := make(map[string]uint32)
+		c := locs.Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			priorities[item.Addr()] = item.Priority()
+		}
+		last := locs.Last()
+		for _, addr := range locs.List() {
+			resp.Locations = append(resp.Locations, &wire.PeerLocationInfo{
+				Netloc:   addr,
+				Priority: priorities[addr],
+				Last:     addr == last,
+			})
+		}
+		return nil
+	}
+	if err := c.app.DB.View(list); err != nil {
+		if err == db.ErrPeerNotFound {
+			return nil, status.Errorf(codes.NotFound, "peer not found")
+		}
+		log.Printf("db error: listing peer addrs: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return resp, nil
+}
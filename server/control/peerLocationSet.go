@@ -28,6 +28,9 @@ func (c controlRPC) PeerLocationSet(ctx context.Context, req *wire.PeerLocationS
 		if err == db.ErrPeerNotFound {
 			return nil, status.Errorf(codes.InvalidArgument, "peer not found")
 		}
+		if err == db.ErrPeerLocationInvalid {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		log.Printf("db error: setting peer addr: %v", err)
 		return nil, status.Errorf(codes.Internal, "database error")
 	}
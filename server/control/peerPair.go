@@ -0,0 +1,26 @@
+package control
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) PeerPair(ctx context.Context, req *wire.PeerPairRequest) (*wire.PeerPairResponse, error) {
+	d := time.Duration(req.Seconds) * time.Second
+	if d <= 0 || d > server.MaxPairingDuration {
+		return nil, status.Errorf(codes.InvalidArgument, "pairing duration must be between 1s and %v", server.MaxPairingDuration)
+	}
+	code, err := c.app.StartPairing(d)
+	if err != nil {
+		log.Printf("pairing error: %v", err)
+		return nil, status.Errorf(codes.Internal, "cannot start pairing")
+	}
+	log.Printf("pairing: open for %v", d)
+	return &wire.PeerPairResponse{Code: code.String()}, nil
+}
@@ -0,0 +1,30 @@
+package control
+
+import (
+	"context"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) PeerPairJoin(ctx context.Context, req *wire.PeerPairJoinRequest) (*wire.PeerPairJoinResponse, error) {
+	if req.Netloc == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing peer address")
+	}
+	var code peer.PairingCode
+	if err := code.Set(req.Code); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	pub, err := c.app.PairWith(ctx, req.Netloc, &code)
+	if err != nil {
+		switch err {
+		case server.ErrPairingSelf, server.ErrPairingPeer:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, err
+	}
+	return &wire.PeerPairJoinResponse{Pub: pub[:]}, nil
+}
@@ -0,0 +1,134 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/server/http/httptest"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPeerPair(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Subdir("app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+	other, err := server.New(tmp.Subdir("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+	ctrlOther := controltest.ListenAndServe(t, &wg, other)
+	defer ctrlOther.Close()
+	web := httptest.ServeHTTP(t, &wg, app)
+	defer web.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+	otherConn, err := grpcunix.Dial(filepath.Join(other.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer otherConn.Close()
+	otherClient := wire.NewControlClient(otherConn)
+
+	ctx := context.Background()
+	pairResp, err := rpcClient.PeerPair(ctx, &wire.PeerPairRequest{Seconds: 60})
+	if err != nil {
+		t.Fatalf("pair failed: %v", err)
+	}
+	joinReq := &wire.PeerPairJoinRequest{
+		Netloc: web.Addr().String(),
+		Code:   pairResp.Code,
+	}
+	joinResp, err := otherClient.PeerPairJoin(ctx, joinReq)
+	if err != nil {
+		t.Fatalf("join failed: %v", err)
+	}
+	pub := (*peer.PublicKey)(app.Keys.Sign.Pub)
+	if g, e := string(joinResp.Pub), string(pub[:]); g != e {
+		t.Errorf("joined wrong peer: %x != %x", g, e)
+	}
+
+	pendingResp, err := rpcClient.PeerPendingList(ctx, &wire.PeerPendingListRequest{})
+	if err != nil {
+		t.Fatalf("pending list failed: %v", err)
+	}
+	if g, e := len(pendingResp.Peers), 1; g != e {
+		t.Fatalf("wrong number of pending peers: %d != %d", g, e)
+	}
+	otherPub := (*peer.PublicKey)(other.Keys.Sign.Pub)
+	if g, e := string(pendingResp.Peers[0].Pub), string(otherPub[:]); g != e {
+		t.Errorf("wrong pending peer: %x != %x", g, e)
+	}
+
+	if _, err := rpcClient.PeerApprove(ctx, &wire.PeerApproveRequest{Pub: otherPub[:]}); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	_, err = rpcClient.PeerApprove(ctx, &wire.PeerApproveRequest{Pub: otherPub[:]})
+	if err := checkRPCError(err, codes.NotFound, "peer is not waiting for approval"); err != nil {
+		t.Error(err)
+	}
+	if _, err := rpcClient.PeerGet(ctx, &wire.PeerGetRequest{Pub: otherPub[:]}); err != nil {
+		t.Errorf("approved peer not found: %v", err)
+	}
+	if _, err := otherClient.PeerGet(ctx, &wire.PeerGetRequest{Pub: pub[:]}); err != nil {
+		t.Errorf("joined peer not found: %v", err)
+	}
+}
+
+func TestPeerPairBad(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Subdir("app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	_, err = rpcClient.PeerPair(ctx, &wire.PeerPairRequest{})
+	if err := checkRPCError(err, codes.InvalidArgument, "pairing duration must be between 1s and 1h0m0s"); err != nil {
+		t.Error(err)
+	}
+	_, err = rpcClient.PeerPairJoin(ctx, &wire.PeerPairJoinRequest{Netloc: "localhost:1", Code: "nope"})
+	if err := checkRPCError(err, codes.InvalidArgument, "not a valid pairing code: wrong size"); err != nil {
+		t.Error(err)
+	}
+	_, err = rpcClient.PeerApprove(ctx, &wire.PeerApproveRequest{Pub: []byte{1, 2, 3}})
+	if err := checkRPCError(err, codes.InvalidArgument, "bad peer public key: peer public key must be exactly 32 bytes"); err != nil {
+		t.Error(err)
+	}
+}
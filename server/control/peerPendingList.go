@@ -0,0 +1,19 @@
+package control
+
+import (
+	"context"
+
+	"bazil.org/bazil/server/control/wire"
+)
+
+func (c controlRPC) PeerPendingList(ctx context.Context, req *wire.PeerPendingListRequest) (*wire.PeerPendingListResponse, error) {
+	resp := &wire.PeerPendingListResponse{}
+	for _, p := range c.app.PendingPeers() {
+		pub := p.Pub
+		resp.Peers = append(resp.Peers, &wire.PeerPending{
+			Pub:   pub[:],
+			Since: p.Since.Unix(),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,28 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) PeerRemove(ctx context.Context, req *wire.PeerRemoveRequest) (*wire.PeerRemoveResponse, error) {
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(req.Pub); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+	}
+
+	if err := c.app.RemovePeer(&pub); err != nil {
+		if err == db.ErrPeerNotFound {
+			return nil, status.Errorf(codes.InvalidArgument, "peer not found")
+		}
+		log.Printf("db update error: remove peer %x: %v", pub[:], err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return &wire.PeerRemoveResponse{}, nil
+}
@@ -0,0 +1,81 @@
+package control
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// peerStatusPingTimeout limits how long to wait for each peer to
+// answer a ping.
+const peerStatusPingTimeout = 5 * time.Second
+
+func (c controlRPC) PeerStatus(ctx context.Context, req *wire.PeerStatusRequest) (*wire.PeerStatusResponse, error) {
+	var pubs []peer.PublicKey
+	if len(req.Pub) > 0 {
+		var pub peer.PublicKey
+		if err := pub.UnmarshalBinary(req.Pub); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+		}
+		pubs = append(pubs, pub)
+	}
+	list := func(tx *db.Tx) error {
+		if len(pubs) > 0 {
+			_, err := tx.Peers().Get(&pubs[0])
+			return err
+		}
+		c := tx.Peers().Cursor()
+		for p := c.First(); p != nil; p = c.Next() {
+			pubs = append(pubs, *p.Pub())
+		}
+		return nil
+	}
+	if err := c.app.DB.View(list); err != nil {
+		if err == db.ErrPeerNotFound {
+			return nil, status.Errorf(codes.NotFound, "peer not found")
+		}
+		log.Printf("db error: listing peers: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+
+	if req.Ping {
+		// the outcome is recorded in the peer health
+		ctx, cancel := context.WithTimeout(ctx, peerStatusPingTimeout)
+		defer cancel()
+		var wg sync.WaitGroup
+		for i := range pubs {
+			wg.Add(1)
+			go func(pub *peer.PublicKey) {
+				defer wg.Done()
+				_ = c.app.PingPeer(ctx, pub)
+			}(&pubs[i])
+		}
+		wg.Wait()
+	}
+
+	resp := &wire.PeerStatusResponse{}
+	for i := range pubs {
+		pub := &pubs[i]
+		h := c.app.PeerHealth(pub)
+		s := &wire.PeerStatus{
+			Pub:           pub[:],
+			Connected:     h.Connected,
+			State:         h.State,
+			InUse:         uint32(h.InUse),
+			LastError:     h.LastError,
+			LatencyMicros: int64(h.Latency / time.Microsecond),
+		}
+		if !h.LastSeen.IsZero() {
+			s.LastSeen = h.LastSeen.Unix()
+		}
+		resp.Peers = append(resp.Peers, s)
+	}
+	return resp, nil
+}
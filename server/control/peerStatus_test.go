@@ -0,0 +1,150 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/server/http/httptest"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPeerStatus(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Subdir("app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+	other, err := server.New(tmp.Subdir("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+	web := httptest.ServeHTTP(t, &wg, other)
+	defer web.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	pub := (*peer.PublicKey)(app.Keys.Sign.Pub)
+	otherPub := (*peer.PublicKey)(other.Keys.Sign.Pub)
+	lost := peer.PublicKey{1, 2, 3, 4, 5}
+	setup := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(otherPub)
+		if err != nil {
+			return err
+		}
+		if err := p.Locations().Set(web.Addr().String()); err != nil {
+			return err
+		}
+		_, err = tx.Peers().Make(&lost)
+		return err
+	}
+	if err := app.DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+	setupOther := func(tx *db.Tx) error {
+		_, err := tx.Peers().Make(pub)
+		return err
+	}
+	if err := other.DB.Update(setupOther); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	resp, err := rpcClient.PeerStatus(ctx, &wire.PeerStatusRequest{Pub: otherPub[:]})
+	if err != nil {
+		t.Fatalf("peer status failed: %v", err)
+	}
+	if g, e := len(resp.Peers), 1; g != e {
+		t.Fatalf("wrong number of peers: %v != %v", g, e)
+	}
+	if s := resp.Peers[0]; s.Connected || s.LastSeen != 0 {
+		t.Errorf("peer must not be contacted without -ping: %v", s)
+	}
+
+	resp, err = rpcClient.PeerStatus(ctx, &wire.PeerStatusRequest{Ping: true})
+	if err != nil {
+		t.Fatalf("peer status failed: %v", err)
+	}
+	if g, e := len(resp.Peers), 2; g != e {
+		t.Fatalf("wrong number of peers: %v != %v", g, e)
+	}
+	for _, s := range resp.Peers {
+		switch string(s.Pub) {
+		case string(otherPub[:]):
+			if !s.Connected {
+				t.Error("pooled connection was not kept")
+			}
+			if s.InUse != 0 {
+				t.Errorf("connection still in use: %d", s.InUse)
+			}
+			if s.LastSeen == 0 {
+				t.Error("peer was not seen")
+			}
+			if s.LastError != "" {
+				t.Errorf("unexpected error: %v", s.LastError)
+			}
+			if s.LatencyMicros <= 0 {
+				t.Errorf("bad latency: %v", s.LatencyMicros)
+			}
+		case string(lost[:]):
+			if s.LastSeen != 0 {
+				t.Error("peer without location was seen")
+			}
+			if s.LastError == "" {
+				t.Error("expected error for peer without location")
+			}
+		default:
+			t.Errorf("unexpected peer: %x", s.Pub)
+		}
+	}
+}
+
+func TestPeerStatusNotFound(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	pub := peer.PublicKey{1, 2, 3, 4, 5}
+	ctx := context.Background()
+	_, err = rpcClient.PeerStatus(ctx, &wire.PeerStatusRequest{Pub: pub[:]})
+	if err := checkRPCError(err, codes.NotFound, "peer not found"); err != nil {
+		t.Error(err)
+	}
+}
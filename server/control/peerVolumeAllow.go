@@ -26,7 +26,11 @@ func (c controlRPC) PeerVolumeAllow(ctx context.Context, req *wire.PeerVolumeAll
 		if err != nil {
 			return err
 		}
-		return p.Volumes().Allow(v)
+		grant := &db.VolumeGrant{
+			Paths:    req.Paths,
+			ReadOnly: req.ReadOnly,
+		}
+		return p.Volumes().AllowGrant(v, grant)
 	}
 	if err := c.app.DB.Update(allowVolume); err != nil {
 		if err == db.ErrPeerNotFound {
@@ -0,0 +1,104 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPeerVolumeAllowPaths(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	pub := peer.PublicKey{1, 2, 3, 4, 5}
+	setup := func(tx *db.Tx) error {
+		_, err := tx.Peers().Make(&pub)
+		return err
+	}
+	if err := app.DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	createReq := &wire.VolumeCreateRequest{
+		VolumeName:     "foo",
+		Backend:        "local",
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient.VolumeCreate(ctx, createReq); err != nil {
+		t.Fatalf("creating volume failed: %v", err)
+	}
+	allowReq := &wire.PeerVolumeAllowRequest{
+		Pub:        pub[:],
+		VolumeName: "foo",
+		Paths:      []string{"/shared/clients/"},
+		ReadOnly:   true,
+	}
+	if _, err := rpcClient.PeerVolumeAllow(ctx, allowReq); err != nil {
+		t.Fatalf("allowing volume failed: %v", err)
+	}
+
+	resp, err := rpcClient.PeerGet(ctx, &wire.PeerGetRequest{Pub: pub[:]})
+	if err != nil {
+		t.Fatalf("getting peer failed: %v", err)
+	}
+	if g, e := len(resp.Peer.Volumes), 1; g != e {
+		t.Fatalf("wrong number of volumes: %v != %v", g, e)
+	}
+	v := resp.Peer.Volumes[0]
+	if g, e := v.Paths, []string{"shared/clients"}; !reflect.DeepEqual(g, e) {
+		t.Errorf("wrong paths: %q != %q", g, e)
+	}
+	if !v.ReadOnly {
+		t.Errorf("expected read-only grant")
+	}
+
+	// changes from a read-only peer are never merged
+	syncReq := &wire.VolumeSyncRequest{
+		VolumeName: "foo",
+		Pub:        pub[:],
+		Path:       "shared/clients",
+	}
+	_, err = rpcClient.VolumeSync(ctx, syncReq)
+	if err := checkRPCError(err, codes.PermissionDenied, "peer has read-only access to volume"); err != nil {
+		t.Error(err)
+	}
+
+	allowReq.ReadOnly = false
+	if _, err := rpcClient.PeerVolumeAllow(ctx, allowReq); err != nil {
+		t.Fatalf("allowing volume failed: %v", err)
+	}
+	syncReq.Path = "shared"
+	_, err = rpcClient.VolumeSync(ctx, syncReq)
+	if err := checkRPCError(err, codes.PermissionDenied, "peer is not allowed to change that path"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,159 @@
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os/user"
+	"strconv"
+
+	grpccreds "google.golang.org/grpc/credentials"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+// errNoPeerCredentials means the platform cannot tell who is on the
+// other end of a UNIX domain socket. The control socket then relies
+// on file permissions alone.
+var errNoPeerCredentials = errors.New("peer credentials not supported on this platform")
+
+// credentials identify the process on the other end of a
+// connection.
+type credentials struct {
+	pid int32
+	uid uint32
+	gid uint32
+}
+
+func userGroupIDs(uid uint32) ([]uint32, error) {
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return nil, err
+	}
+	ids, err := u.GroupIds()
+	if err != nil {
+		return nil, err
+	}
+	gids := make([]uint32, 0, len(ids))
+	for _, id := range ids {
+		gid, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			// not a POSIX system after all
+			continue
+		}
+		gids = append(gids, uint32(gid))
+	}
+	return gids, nil
+}
+
+// allowed returns an error explaining why the process may not use
+// the control socket, or nil.
+func (t *credTransport) allowed(c *credentials) error {
+	if c.uid == t.uid {
+		return nil
+	}
+	if t.hasGroup {
+		if c.gid == t.gid {
+			return nil
+		}
+		gids, err := t.groupIDs(c.uid)
+		if err != nil {
+			return fmt.Errorf("uid %d (pid %d): cannot look up groups: %v", c.uid, c.pid, err)
+		}
+		for _, gid := range gids {
+			if gid == t.gid {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("uid %d (pid %d) is not allowed", c.uid, c.pid)
+}
+
+// check returns the credentials of the connecting process, or nil
+// if they cannot be known.
+func (t *credTransport) check(conn net.Conn) (*credentials, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, fmt.Errorf("not a UNIX domain socket: %T", conn)
+	}
+	cred, err := peerCredentials(uc)
+	if err == errNoPeerCredentials {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get peer credentials: %v", err)
+	}
+	if err := t.allowed(cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// credAuth makes the credentials of the caller available to calls,
+// through the gRPC peer information.
+type credAuth struct {
+	cred *credentials
+}
+
+var _ grpccreds.AuthInfo = credAuth{}
+
+func (credAuth) AuthType() string { return "peercred" }
+
+// credTransport only lets through connections from processes
+// running as the same user as the server, or in the admin group, and
+// passes their credentials to gRPC. It does no handshake of its own,
+// and is only for the server side. gRPC calls it for each connection
+// in a goroutine of its own, so the group lookups do not hold up
+// accepting other connections.
+type credTransport struct {
+	uid      uint32
+	hasGroup bool
+	gid      uint32
+	// Supplementary groups of a user; replaced in tests.
+	groupIDs func(uid uint32) ([]uint32, error)
+}
+
+var _ grpccreds.TransportCredentials = (*credTransport)(nil)
+
+func (*credTransport) ClientHandshake(ctx context.Context, addr string, conn net.Conn) (net.Conn, grpccreds.AuthInfo, error) {
+	return nil, nil, errors.New("control credentials are for the server side only")
+}
+
+func (t *credTransport) ServerHandshake(conn net.Conn) (net.Conn, grpccreds.AuthInfo, error) {
+	cred, err := t.check(conn)
+	if err != nil {
+		log.Printf("control: rejected connection: %v", err)
+		return nil, nil, err
+	}
+	return conn, credAuth{cred: cred}, nil
+}
+
+func (*credTransport) Info() grpccreds.ProtocolInfo {
+	return grpccreds.ProtocolInfo{
+		SecurityProtocol: "peercred",
+	}
+}
+
+func (t *credTransport) Clone() grpccreds.TransportCredentials {
+	c := *t
+	return &c
+}
+
+func (*credTransport) OverrideServerName(string) error {
+	return nil
+}
+
+// callerCredentials returns the credentials of the process making
+// the call, or nil if not known.
+func callerCredentials(ctx context.Context) *credentials {
+	p, ok := grpcpeer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	auth, ok := p.AuthInfo.(credAuth)
+	if !ok {
+		return nil
+	}
+	return auth.cred
+}
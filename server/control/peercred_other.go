@@ -0,0 +1,11 @@
+// +build !linux
+
+package control
+
+import (
+	"net"
+)
+
+func peerCredentials(conn *net.UnixConn) (*credentials, error) {
+	return nil, errNoPeerCredentials
+}
@@ -0,0 +1,169 @@
+package control
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestCredAllowed(t *testing.T) {
+	tr := &credTransport{
+		uid: 1000,
+		groupIDs: func(uid uint32) ([]uint32, error) {
+			switch uid {
+			case 2000:
+				return []uint32{10, 50}, nil
+			case 3000:
+				return nil, errors.New("no such user")
+			}
+			return nil, nil
+		},
+	}
+	tests := []struct {
+		name     string
+		hasGroup bool
+		cred     credentials
+		ok       bool
+	}{
+		{"owner", false, credentials{uid: 1000, gid: 1000}, true},
+		{"other", false, credentials{uid: 2000, gid: 2000}, false},
+		{"other without group", true, credentials{uid: 4000, gid: 4000}, false},
+		{"primary group", true, credentials{uid: 4000, gid: 50}, true},
+		{"supplementary group", true, credentials{uid: 2000, gid: 2000}, true},
+		{"group lookup fails", true, credentials{uid: 3000, gid: 3000}, false},
+		{"group not configured", false, credentials{uid: 4000, gid: 50}, false},
+	}
+	for _, test := range tests {
+		tr.hasGroup, tr.gid = test.hasGroup, 50
+		err := tr.allowed(&test.cred)
+		if test.ok && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !test.ok && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+func TestPeerCredentials(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on Linux")
+	}
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	l, err := net.Listen("unix", filepath.Join(tmp.Path, "sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		conn, err := net.Dial("unix", l.Addr().String())
+		if err == nil {
+			conn.Close()
+		}
+		errCh <- err
+	}()
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+
+	cred, err := peerCredentials(conn.(*net.UnixConn))
+	if err != nil {
+		t.Fatalf("peerCredentials: %v", err)
+	}
+	if g, e := cred.uid, uint32(os.Getuid()); g != e {
+		t.Errorf("wrong uid: %d != %d", g, e)
+	}
+	if g, e := cred.pid, int32(os.Getpid()); g != e {
+		t.Errorf("wrong pid: %d != %d", g, e)
+	}
+}
+
+func TestAdminGroupAccess(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	gid := uint32(os.Getgid())
+	app, err := server.New(tmp.Subdir("data"), server.ControlAdminGroup(gid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+	ctrl, err := New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctrl.Close()
+
+	for _, test := range []struct {
+		path string
+		mode os.FileMode
+	}{
+		{app.DataDir, 0710},
+		{filepath.Join(app.DataDir, "control"), 0660},
+	} {
+		fi, err := os.Stat(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g, e := fi.Mode().Perm(), test.mode; g != e {
+			t.Errorf("%s: wrong mode: %v != %v", test.path, g, e)
+		}
+		if g, e := fi.Sys().(*syscall.Stat_t).Gid, gid; g != e {
+			t.Errorf("%s: wrong group: %d != %d", test.path, g, e)
+		}
+	}
+}
+
+func TestAdminGroupRevoked(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	dataDir := tmp.Subdir("data")
+	gid := uint32(os.Getgid())
+
+	app, err := server.New(dataDir, server.ControlAdminGroup(gid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl, err := New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl.Close()
+	app.Close()
+
+	app, err = server.New(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+	ctrl, err = New(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctrl.Close()
+
+	fi, err := os.Stat(app.DataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := fi.Mode().Perm(), os.FileMode(0700); g != e {
+		t.Errorf("wrong mode: %v != %v", g, e)
+	}
+	if g, e := fi.Sys().(*syscall.Stat_t).Gid, gid; g != e {
+		t.Errorf("wrong group: %d != %d", g, e)
+	}
+}
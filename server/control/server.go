@@ -1,6 +1,7 @@
 package control
 
 import (
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -13,12 +14,32 @@ import (
 type Control struct {
 	app      *server.App
 	listener net.Listener
+	creds    *credTransport
 }
 
 // New creates a control socket to listen for administrative commands.
+// Only processes running as the same user as the server, or in the
+// admin group of the app, may connect. With an admin group, the data
+// directory is made searchable and the socket writable by the group;
+// without one, access given by an earlier run is taken away.
 // Caller is expected to call Control.Serve to actually process
 // incoming requests and Control.Close to clean up.
 func New(app *server.App) (*Control, error) {
+	creds := &credTransport{
+		uid:      uint32(os.Getuid()),
+		groupIDs: userGroupIDs,
+	}
+	creds.gid, creds.hasGroup = app.ControlAdminGroup()
+	if creds.hasGroup {
+		if err := shareWithGroup(app.DataDir, 0710, creds.gid); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := unshare(app.DataDir); err != nil {
+			return nil, err
+		}
+	}
+
 	socketPath := filepath.Join(app.DataDir, "control")
 	// because app holds lock, this is safe
 	err := os.Remove(socketPath)
@@ -29,20 +50,53 @@ func New(app *server.App) (*Control, error) {
 	if err != nil {
 		return nil, err
 	}
+	if creds.hasGroup {
+		if err := shareWithGroup(socketPath, 0660, creds.gid); err != nil {
+			_ = l.Close()
+			return nil, err
+		}
+	}
 
 	c := &Control{
 		app:      app,
 		listener: l,
+		creds:    creds,
 	}
 	return c, nil
 }
 
+// shareWithGroup gives the group access to the file.
+func shareWithGroup(path string, mode os.FileMode, gid uint32) error {
+	if err := os.Chown(path, -1, int(gid)); err != nil {
+		return fmt.Errorf("cannot give admin group access: %v", err)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return fmt.Errorf("cannot give admin group access: %v", err)
+	}
+	return nil
+}
+
+// unshare returns the directory to the owner's group, accessible
+// only by the owner.
+func unshare(path string) error {
+	if err := os.Chown(path, -1, os.Getgid()); err != nil {
+		return fmt.Errorf("cannot revoke admin group access: %v", err)
+	}
+	if err := os.Chmod(path, 0700); err != nil {
+		return fmt.Errorf("cannot revoke admin group access: %v", err)
+	}
+	return nil
+}
+
 func (c *Control) Close() {
 	_ = c.listener.Close()
 }
 
 func (c *Control) Serve() error {
-	srv := grpc.NewServer()
+	srv := grpc.NewServer(
+		grpc.Creds(c.creds),
+		grpc.UnaryInterceptor(c.auditUnary),
+	)
 	wire.RegisterControlServer(srv, controlRPC{c})
 	return srv.Serve(c.listener)
 }
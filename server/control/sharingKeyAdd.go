@@ -5,26 +5,93 @@ import (
 	"log"
 
 	"bazil.org/bazil/db"
+	wiredb "bazil.org/bazil/db/wire"
+	"bazil.org/bazil/kv/untrusted"
 	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/server/sharing"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 const sharingKeySize = 32
 
+func kdfFromWire(kdf *wire.SharingKeyKDF) *wiredb.SharingKeyKDF {
+	out := &wiredb.SharingKeyKDF{
+		Salt: kdf.Salt,
+		LogN: kdf.LogN,
+		R:    kdf.R,
+		P:    kdf.P,
+	}
+	if out.LogN == 0 {
+		out.LogN = sharing.DefaultLogN
+	}
+	if out.R == 0 {
+		out.R = sharing.DefaultR
+	}
+	if out.P == 0 {
+		out.P = sharing.DefaultP
+	}
+	return out
+}
+
+func kdfToWire(kdf *wiredb.SharingKeyKDF) *wire.SharingKeyKDF {
+	return &wire.SharingKeyKDF{
+		Salt: kdf.Salt,
+		LogN: kdf.LogN,
+		R:    kdf.R,
+		P:    kdf.P,
+	}
+}
+
 func (c controlRPC) SharingKeyAdd(ctx context.Context, req *wire.SharingKeyAddRequest) (*wire.SharingKeyAddResponse, error) {
-	if len(req.Secret) != sharingKeySize {
-		return nil, status.Errorf(codes.InvalidArgument, "sharing key must be exactly 32 bytes")
+	if _, err := untrusted.ParsePadding(req.Padding); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
 	var secret [32]byte
-	copy(secret[:], req.Secret)
+	var kdf *wiredb.SharingKeyKDF
+	switch {
+	case len(req.Passphrase) > 0:
+		if len(req.Secret) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot give both secret and passphrase")
+		}
+		if req.Kdf != nil {
+			kdf = kdfFromWire(req.Kdf)
+		} else {
+			k, err := sharing.NewKDF()
+			if err != nil {
+				return nil, err
+			}
+			kdf = k
+		}
+		s, err := sharing.Derive(req.Passphrase, kdf)
+		if err != nil {
+			if err == sharing.ErrBadKDF {
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			return nil, err
+		}
+		secret = *s
+
+	default:
+		if len(req.Secret) != sharingKeySize {
+			return nil, status.Errorf(codes.InvalidArgument, "sharing key must be exactly 32 bytes")
+		}
+		copy(secret[:], req.Secret)
+	}
 
 	update := func(tx *db.Tx) error {
-		if _, err := tx.SharingKeys().Add(req.Name, &secret); err != nil {
+		var sharingKey *db.SharingKey
+		var err error
+		if kdf != nil {
+			sharingKey, err = tx.SharingKeys().AddDerived(req.Name, &secret, kdf)
+		} else {
+			sharingKey, err = tx.SharingKeys().Add(req.Name, &secret)
+		}
+		if err != nil {
 			return err
 		}
-		return nil
+		return sharingKey.SetPadding(req.Padding)
 	}
 	if err := c.app.DB.Update(update); err != nil {
 		switch err {
@@ -39,5 +106,9 @@ func (c controlRPC) SharingKeyAdd(ctx context.Context, req *wire.SharingKeyAddRe
 		log.Printf("db update error: put sharing key %q: %v", req.Name, err)
 		return nil, status.Errorf(codes.Internal, "database error")
 	}
-	return &wire.SharingKeyAddResponse{}, nil
+	resp := &wire.SharingKeyAddResponse{}
+	if kdf != nil {
+		resp.Kdf = kdfToWire(kdf)
+	}
+	return resp, nil
 }
@@ -198,3 +198,174 @@ func TestSharingAddBadSecretShort(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestSharingAddPassphrase(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	addReq := &wire.SharingKeyAddRequest{
+		Name:       "foo",
+		Passphrase: []byte("correct horse battery staple"),
+		Kdf: &wire.SharingKeyKDF{
+			Salt: []byte("salty"),
+			LogN: 10,
+		},
+	}
+	resp, err := rpcClient.SharingKeyAdd(ctx, addReq)
+	if err != nil {
+		t.Fatalf("adding sharing key failed: %v", err)
+	}
+	if resp.Kdf == nil {
+		t.Fatal("expected KDF parameters in response")
+	}
+	if g, e := resp.Kdf.R, uint32(8); g != e {
+		t.Errorf("expected default r: %v != %v", g, e)
+	}
+
+	// same passphrase and parameters must derive the same secret
+	addReq.Name = "bar"
+	addReq.Kdf = resp.Kdf
+	if _, err := rpcClient.SharingKeyAdd(ctx, addReq); err != nil {
+		t.Fatalf("adding sharing key failed: %v", err)
+	}
+	check := func(tx *db.Tx) error {
+		var foo, bar [32]byte
+		k, err := tx.SharingKeys().Get("foo")
+		if err != nil {
+			return err
+		}
+		k.Secret(&foo)
+		k, err = tx.SharingKeys().Get("bar")
+		if err != nil {
+			return err
+		}
+		k.Secret(&bar)
+		if foo != bar {
+			t.Errorf("same passphrase gave different secrets: %x != %x", foo, bar)
+		}
+		if foo == ([32]byte{}) {
+			t.Errorf("secret is all zeroes")
+		}
+		return nil
+	}
+	if err := app.DB.View(check); err != nil {
+		t.Error(err)
+	}
+
+	list, err := rpcClient.SharingKeyList(ctx, &wire.SharingKeyListRequest{})
+	if err != nil {
+		t.Fatalf("listing sharing keys failed: %v", err)
+	}
+	for _, k := range list.SharingKeys {
+		if g, e := k.Kdf != nil, k.Name != "default"; g != e {
+			t.Errorf("sharing key %q: wrong KDF presence: %v", k.Name, k.Kdf)
+		}
+	}
+}
+
+func TestSharingAddPassphraseAndSecret(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	secret := [32]byte{1, 2, 3, 4, 5}
+	addReq := &wire.SharingKeyAddRequest{
+		Name:       "foo",
+		Secret:     secret[:],
+		Passphrase: []byte("hunter2"),
+	}
+	ctx := context.Background()
+	_, err = rpcClient.SharingKeyAdd(ctx, addReq)
+	if err := checkRPCError(err, codes.InvalidArgument, "cannot give both secret and passphrase"); err != nil {
+		t.Error(err)
+	}
+	if err := app.DB.View(checkNoSharingKey("foo")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSharingAddPadding(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	secret := [32]byte{1, 2, 3, 4, 5}
+	ctx := context.Background()
+	addReq := &wire.SharingKeyAddRequest{
+		Name:    "foo",
+		Secret:  secret[:],
+		Padding: "bogus",
+	}
+	_, err = rpcClient.SharingKeyAdd(ctx, addReq)
+	if err := checkRPCError(err, codes.InvalidArgument, `unknown padding: "bogus"`); err != nil {
+		t.Error(err)
+	}
+	if err := app.DB.View(checkNoSharingKey("foo")); err != nil {
+		t.Error(err)
+	}
+
+	addReq.Padding = "padme"
+	if _, err := rpcClient.SharingKeyAdd(ctx, addReq); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	list, err := rpcClient.SharingKeyList(ctx, &wire.SharingKeyListRequest{})
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	for _, k := range list.SharingKeys {
+		want := ""
+		if k.Name == "foo" {
+			want = "padme"
+		}
+		if g, e := k.Padding, want; g != e {
+			t.Errorf("wrong padding for %q: %q != %q", k.Name, g, e)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	wiredb "bazil.org/bazil/db/wire"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/server/sharing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) SharingKeyExport(ctx context.Context, req *wire.SharingKeyExportRequest) (*wire.SharingKeyExportResponse, error) {
+	if len(req.Passphrase) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "passphrase is required")
+	}
+
+	key := &sharing.Key{
+		Name: req.Name,
+	}
+	get := func(tx *db.Tx) error {
+		sharingKey, err := tx.SharingKeys().Get(req.Name)
+		if err != nil {
+			return err
+		}
+		sharingKey.Secret(&key.Secret)
+		key.Padding = sharingKey.Padding()
+		var kdf wiredb.SharingKeyKDF
+		derived, err := sharingKey.KDF(&kdf)
+		if err != nil {
+			return err
+		}
+		if derived {
+			key.KDF = &kdf
+		}
+		return nil
+	}
+	if err := c.app.DB.View(get); err != nil {
+		if err == db.ErrSharingKeyNotFound {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		log.Printf("db error: exporting sharing key %q: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+
+	armored, err := sharing.Export(key, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.SharingKeyExportResponse{Armored: armored}, nil
+}
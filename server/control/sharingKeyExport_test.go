@@ -0,0 +1,94 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestSharingKeyExportImport(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	exportReq := &wire.SharingKeyExportRequest{
+		Name:       "default",
+		Passphrase: []byte("s3kr1t"),
+	}
+	exported, err := rpcClient.SharingKeyExport(ctx, exportReq)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	importReq := &wire.SharingKeyImportRequest{
+		Armored:    exported.Armored,
+		Passphrase: []byte("wrong"),
+		Name:       "copy",
+	}
+	_, err = rpcClient.SharingKeyImport(ctx, importReq)
+	if err := checkRPCError(err, codes.InvalidArgument, "wrong passphrase or corrupt encrypted file"); err != nil {
+		t.Error(err)
+	}
+
+	importReq.Passphrase = []byte("s3kr1t")
+	resp, err := rpcClient.SharingKeyImport(ctx, importReq)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if g, e := resp.Name, "copy"; g != e {
+		t.Errorf("wrong imported name: %q != %q", g, e)
+	}
+
+	check := func(tx *db.Tx) error {
+		var orig, imported [32]byte
+		k, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		k.Secret(&orig)
+		k, err = tx.SharingKeys().Get("copy")
+		if err != nil {
+			return err
+		}
+		k.Secret(&imported)
+		if orig != imported {
+			t.Errorf("imported secret differs: %x != %x", imported, orig)
+		}
+		return nil
+	}
+	if err := app.DB.View(check); err != nil {
+		t.Error(err)
+	}
+
+	// name from the export collides with the existing key
+	importReq.Name = ""
+	_, err = rpcClient.SharingKeyImport(ctx, importReq)
+	if err := checkRPCError(err, codes.AlreadyExists, `sharing key exists already: "default"`); err != nil {
+		t.Error(err)
+	}
+}
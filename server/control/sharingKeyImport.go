@@ -0,0 +1,52 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/server/sharing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) SharingKeyImport(ctx context.Context, req *wire.SharingKeyImportRequest) (*wire.SharingKeyImportResponse, error) {
+	key, err := sharing.Import(req.Armored, req.Passphrase)
+	if err != nil {
+		switch err {
+		case sharing.ErrMalformed, sharing.ErrBadKDF, sharing.ErrBadPassphrase:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, err
+	}
+
+	name := req.Name
+	if name == "" {
+		name = key.Name
+	}
+	update := func(tx *db.Tx) error {
+		var sharingKey *db.SharingKey
+		var err error
+		if key.KDF != nil {
+			sharingKey, err = tx.SharingKeys().AddDerived(name, &key.Secret, key.KDF)
+		} else {
+			sharingKey, err = tx.SharingKeys().Add(name, &key.Secret)
+		}
+		if err != nil {
+			return err
+		}
+		return sharingKey.SetPadding(key.Padding)
+	}
+	if err := c.app.DB.Update(update); err != nil {
+		switch err {
+		case db.ErrSharingKeyExist:
+			return nil, status.Errorf(codes.AlreadyExists, "sharing key exists already: %q", name)
+		case db.ErrSharingKeyNameInvalid:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		log.Printf("db update error: import sharing key %q: %v", name, err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return &wire.SharingKeyImportResponse{Name: name}, nil
+}
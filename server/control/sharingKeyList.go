@@ -0,0 +1,41 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	wiredb "bazil.org/bazil/db/wire"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) SharingKeyList(ctx context.Context, req *wire.SharingKeyListRequest) (*wire.SharingKeyListResponse, error) {
+	resp := &wire.SharingKeyListResponse{}
+	list := func(tx *db.Tx) error {
+		c := tx.SharingKeys().Cursor()
+		for sharingKey := c.First(); sharingKey != nil; sharingKey = c.Next() {
+			// never expose the secret itself
+			info := &wire.SharingKeyInfo{
+				Name:    sharingKey.Name(),
+				Padding: sharingKey.Padding(),
+			}
+			var kdf wiredb.SharingKeyKDF
+			derived, err := sharingKey.KDF(&kdf)
+			if err != nil {
+				return err
+			}
+			if derived {
+				info.Kdf = kdfToWire(&kdf)
+			}
+			resp.SharingKeys = append(resp.SharingKeys, info)
+		}
+		return nil
+	}
+	if err := c.app.DB.View(list); err != nil {
+		log.Printf("db error: listing sharing keys: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return resp, nil
+}
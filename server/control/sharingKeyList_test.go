@@ -0,0 +1,63 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestSharingList(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	secret := [32]byte{1, 2, 3, 4, 5}
+	addReq := &wire.SharingKeyAddRequest{
+		Name:   "foo",
+		Secret: secret[:],
+	}
+	if _, err := rpcClient.SharingKeyAdd(ctx, addReq); err != nil {
+		t.Fatalf("adding sharing key failed: %v", err)
+	}
+
+	resp, err := rpcClient.SharingKeyList(ctx, &wire.SharingKeyListRequest{})
+	if err != nil {
+		t.Fatalf("listing sharing keys failed: %v", err)
+	}
+	var names []string
+	for _, k := range resp.SharingKeys {
+		names = append(names, k.Name)
+	}
+	found := false
+	for _, name := range names {
+		if name == "foo" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("sharing key not listed: %q", names)
+	}
+}
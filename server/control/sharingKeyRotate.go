@@ -0,0 +1,29 @@
+package control
+
+import (
+	"context"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/kv/untrusted"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) SharingKeyRotate(ctx context.Context, req *wire.SharingKeyRotateRequest) (*wire.SharingKeyRotateResponse, error) {
+	if _, err := untrusted.ParsePadding(req.Padding); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	newName, err := c.app.RotateSharingKey(req.Name, req.Padding)
+	if err != nil {
+		switch err {
+		case db.ErrSharingKeyNotFound:
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case server.ErrSharingKeyRotating, server.ErrVolumeInUse:
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, err
+	}
+	return &wire.SharingKeyRotateResponse{NewName: newName}, nil
+}
@@ -0,0 +1,235 @@
+package control_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"bazil.org/bazil/cas/blobs"
+	"bazil.org/bazil/cas/chunks/kvchunks"
+	wirecas "bazil.org/bazil/cas/wire"
+	"bazil.org/bazil/db"
+	wirefs "bazil.org/bazil/fs/wire"
+	"bazil.org/bazil/kv/kvfiles"
+	"bazil.org/bazil/kv/untrusted"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/tokens"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func sharingSecret(t testing.TB, app *server.App, name string) *[32]byte {
+	var secret [32]byte
+	get := func(tx *db.Tx) error {
+		k, err := tx.SharingKeys().Get(name)
+		if err != nil {
+			return err
+		}
+		k.Secret(&secret)
+		return nil
+	}
+	if err := app.DB.View(get); err != nil {
+		t.Fatal(err)
+	}
+	return &secret
+}
+
+func TestSharingKeyRotate(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	createReq := &wire.VolumeCreateRequest{
+		VolumeName:     "foo",
+		Backend:        "local",
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient.VolumeCreate(ctx, createReq); err != nil {
+		t.Fatalf("creating volume failed: %v", err)
+	}
+
+	// store a file spanning multiple chunks, encrypted with the old
+	// sharing key
+	local, err := kvfiles.Open(filepath.Join(app.DataDir, "chunks"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStore := kvchunks.New(untrusted.New(local, sharingSecret(t, app, "default")))
+	greeting := bytes.Repeat([]byte("hello, world\n"), 1000)
+	blob, err := blobs.Open(oldStore, &blobs.Manifest{
+		Type:      "file",
+		ChunkSize: blobs.MinChunkSize,
+		Fanout:    2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := blob.IO(ctx).WriteAt(greeting, 0); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := blob.Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addFile := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName("foo")
+		if err != nil {
+			return err
+		}
+		de := &wirefs.Dirent{
+			Inode: 42,
+			Type: &wirefs.Dirent_File{
+				File: &wirefs.File{
+					Manifest: wirecas.FromBlob(manifest),
+				},
+			},
+		}
+		return vol.Dirs().Put(tokens.InodeRoot, "greeting", de)
+	}
+	if err := app.DB.Update(addFile); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := rpcClient.SharingKeyRotate(ctx, &wire.SharingKeyRotateRequest{Name: "default"})
+	if err != nil {
+		t.Fatalf("rotate failed: %v", err)
+	}
+	if g, e := resp.NewName, "default@2"; g != e {
+		t.Errorf("wrong new sharing key name: %q != %q", g, e)
+	}
+
+	var status *wire.SharingKeyRotationResponse
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		status, err = rpcClient.SharingKeyRotation(ctx, &wire.SharingKeyRotationRequest{Name: "default"})
+		if err != nil {
+			t.Fatalf("rotation status failed: %v", err)
+		}
+		if status.Done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("rotation did not complete: %v", status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if g, e := status.VolumesDone, uint32(1); g != e {
+		t.Errorf("wrong number of volumes done: %v != %v", g, e)
+	}
+	if status.Chunks == 0 {
+		t.Errorf("expected chunks to be re-encrypted")
+	}
+
+	check := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName("foo")
+		if err != nil {
+			return err
+		}
+		c := vol.Storage().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			name, err := item.SharingKeyName()
+			if err != nil {
+				return err
+			}
+			if g, e := name, "default@2"; g != e {
+				t.Errorf("storage %q not switched to new key: %q != %q", item.Name(), g, e)
+			}
+			old, err := item.OldSharingKeyName()
+			if err != nil {
+				return err
+			}
+			if old != "" {
+				t.Errorf("storage %q still falls back to old key %q", item.Name(), old)
+			}
+		}
+		return nil
+	}
+	if err := app.DB.View(check); err != nil {
+		t.Fatal(err)
+	}
+
+	// the file must be readable with only the new sharing key
+	newStore := kvchunks.New(untrusted.New(local, sharingSecret(t, app, "default@2")))
+	b, err := blobs.Open(newStore, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len(greeting)+1)
+	n, err := b.IO(ctx).ReadAt(buf, 0)
+	if err != io.EOF {
+		t.Fatalf("read with new key failed: %v", err)
+	}
+	if !bytes.Equal(buf[:n], greeting) {
+		t.Errorf("wrong content after rotation")
+	}
+}
+
+func TestSharingKeyRotateInUse(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	createReq := &wire.VolumeCreateRequest{
+		VolumeName:     "foo",
+		Backend:        "local",
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient.VolumeCreate(ctx, createReq); err != nil {
+		t.Fatalf("creating volume failed: %v", err)
+	}
+
+	ref, err := app.GetVolumeByName("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = rpcClient.SharingKeyRotate(ctx, &wire.SharingKeyRotateRequest{Name: "default"})
+	ref.Close()
+	if err := checkRPCError(err, codes.FailedPrecondition, "volume is in use"); err != nil {
+		t.Error(err)
+	}
+
+	_, err = rpcClient.SharingKeyRotate(ctx, &wire.SharingKeyRotateRequest{Name: "missing"})
+	if err := checkRPCError(err, codes.NotFound, "sharing key not found"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,34 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	wiredb "bazil.org/bazil/db/wire"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) SharingKeyRotation(ctx context.Context, req *wire.SharingKeyRotationRequest) (*wire.SharingKeyRotationResponse, error) {
+	var state wiredb.SharingKeyRotation
+	get := func(tx *db.Tx) error {
+		return tx.SharingKeyRotations().Get(req.Name, &state)
+	}
+	if err := c.app.DB.View(get); err != nil {
+		if err == db.ErrSharingKeyRotationNotFound {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		log.Printf("db error: getting sharing key rotation %q: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	resp := &wire.SharingKeyRotationResponse{
+		NewName:     state.NewName,
+		Volumes:     state.Volumes,
+		VolumesDone: state.VolumesDone,
+		Chunks:      state.Chunks,
+		Done:        state.Done,
+	}
+	return resp, nil
+}
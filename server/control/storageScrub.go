@@ -0,0 +1,80 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) StorageScrub(ctx context.Context, req *wire.StorageScrubRequest) (*wire.StorageScrubResponse, error) {
+	type volume struct {
+		name string
+		id   db.VolumeID
+	}
+	var vols []volume
+	list := func(tx *db.Tx) error {
+		if req.VolumeName != "" {
+			vol, err := tx.Volumes().GetByName(req.VolumeName)
+			if err != nil {
+				return err
+			}
+			v := volume{name: req.VolumeName}
+			vol.VolumeID(&v.id)
+			vols = append(vols, v)
+			return nil
+		}
+		c := tx.Volumes().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			v := volume{name: item.Name()}
+			item.Volume().VolumeID(&v.id)
+			vols = append(vols, v)
+		}
+		return nil
+	}
+	if err := c.app.DB.View(list); err != nil {
+		if err == db.ErrVolNameNotFound {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		log.Printf("db error: listing volumes: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+
+	resp := &wire.StorageScrubResponse{}
+	for i := range vols {
+		v := &vols[i]
+		result, err := c.app.ScrubVolume(ctx, &v.id, req.Repair)
+		if err == db.ErrVolumeIDNotFound {
+			// deleted meanwhile
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+			log.Printf("scrub error: volume %q: %v", v.name, err)
+			return nil, status.Errorf(codes.Internal, "scrubbing volume %q failed", v.name)
+		}
+		resp.Chunks += result.Chunks
+		for _, p := range result.Problems {
+			key, err := p.Key.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			resp.Problems = append(resp.Problems, &wire.StorageScrubProblem{
+				VolumeName: v.name,
+				Storage:    p.Storage,
+				Key:        key,
+				Type:       p.Type,
+				Level:      uint32(p.Level),
+				Error:      p.Err.Error(),
+				Paths:      p.Paths,
+				Repaired:   p.Repaired,
+			})
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,237 @@
+package control_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/cas/blobs"
+	"bazil.org/bazil/cas/chunks/kvchunks"
+	wirecas "bazil.org/bazil/cas/wire"
+	"bazil.org/bazil/db"
+	wirefs "bazil.org/bazil/fs/wire"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/tokens"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestStorageScrub(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	createReq := &wire.VolumeCreateRequest{
+		VolumeName:     "foo",
+		Backend:        "local",
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient.VolumeCreate(ctx, createReq); err != nil {
+		t.Fatalf("creating volume failed: %v", err)
+	}
+	mirror := filepath.Join(tmp.Path, "mirror")
+	if err := os.Mkdir(mirror, 0700); err != nil {
+		t.Fatal(err)
+	}
+	storageReq := &wire.VolumeStorageAddRequest{
+		VolumeName:     "foo",
+		Name:           "mirror",
+		Backend:        mirror,
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient.VolumeStorageAdd(ctx, storageReq); err != nil {
+		t.Fatalf("adding storage failed: %v", err)
+	}
+
+	// store a file in both storages
+	addFile := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName("foo")
+		if err != nil {
+			return err
+		}
+		kvstore, err := app.OpenKV(tx, vol.Storage())
+		if err != nil {
+			return err
+		}
+		store := kvchunks.New(kvstore)
+		defer store.Close()
+		blob, err := blobs.Open(store, &blobs.Manifest{
+			Type:      "file",
+			ChunkSize: blobs.MinChunkSize,
+			Fanout:    2,
+		})
+		if err != nil {
+			return err
+		}
+		greeting := bytes.Repeat([]byte("hello, world\n"), 1000)
+		if _, err := blob.IO(ctx).WriteAt(greeting, 0); err != nil {
+			return err
+		}
+		manifest, err := blob.Save(ctx)
+		if err != nil {
+			return err
+		}
+		de := &wirefs.Dirent{
+			Inode: 42,
+			Type: &wirefs.Dirent_File{
+				File: &wirefs.File{
+					Manifest: wirecas.FromBlob(manifest),
+				},
+			},
+		}
+		return vol.Dirs().Put(tokens.InodeRoot, "greeting", de)
+	}
+	if err := app.DB.Update(addFile); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := rpcClient.StorageScrub(ctx, &wire.StorageScrubRequest{VolumeName: "foo"})
+	if err != nil {
+		t.Fatalf("scrub failed: %v", err)
+	}
+	if resp.Chunks < 2 {
+		t.Errorf("expected multiple chunks to be checked: %d", resp.Chunks)
+	}
+	if len(resp.Problems) != 0 {
+		t.Fatalf("unexpected problems: %v", resp.Problems)
+	}
+
+	// in the middle of a sharing key rotation, nothing has been
+	// re-encrypted yet
+	rotating := func(tx *db.Tx) error {
+		sharingKey, err := tx.SharingKeys().Rotate("default")
+		if err != nil {
+			return err
+		}
+		vol, err := tx.Volumes().GetByName("foo")
+		if err != nil {
+			return err
+		}
+		for _, name := range []string{"default", "mirror"} {
+			if err := vol.Storage().RotateSharingKey(name, sharingKey); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := app.DB.Update(rotating); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = rpcClient.StorageScrub(ctx, &wire.StorageScrubRequest{VolumeName: "foo"})
+	if err != nil {
+		t.Fatalf("scrub failed: %v", err)
+	}
+	if len(resp.Problems) != 0 {
+		t.Fatalf("unexpected problems while rotating: %v", resp.Problems)
+	}
+
+	// damage one chunk in the mirror
+	var damaged string
+	find := func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if damaged == "" && strings.HasSuffix(p, ".data") {
+			damaged = p
+		}
+		return nil
+	}
+	if err := filepath.Walk(mirror, find); err != nil {
+		t.Fatal(err)
+	}
+	if damaged == "" {
+		t.Fatal("no chunks in mirror")
+	}
+	if err := os.Truncate(damaged, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = rpcClient.StorageScrub(ctx, &wire.StorageScrubRequest{VolumeName: "foo"})
+	if err != nil {
+		t.Fatalf("scrub failed: %v", err)
+	}
+	if len(resp.Problems) != 1 {
+		t.Fatalf("expected one problem: %v", resp.Problems)
+	}
+	p := resp.Problems[0]
+	if g, e := p.VolumeName, "foo"; g != e {
+		t.Errorf("wrong volume: %q != %q", g, e)
+	}
+	if g, e := p.Storage, "mirror"; g != e {
+		t.Errorf("wrong storage: %q != %q", g, e)
+	}
+	if g, e := strings.Join(p.Paths, ","), "greeting"; g != e {
+		t.Errorf("wrong paths: %q != %q", g, e)
+	}
+	if p.Repaired {
+		t.Errorf("must not repair without asking")
+	}
+
+	resp, err = rpcClient.StorageScrub(ctx, &wire.StorageScrubRequest{Repair: true})
+	if err != nil {
+		t.Fatalf("scrub failed: %v", err)
+	}
+	if len(resp.Problems) != 1 || !resp.Problems[0].Repaired {
+		t.Fatalf("expected one repaired problem: %v", resp.Problems)
+	}
+
+	resp, err = rpcClient.StorageScrub(ctx, &wire.StorageScrubRequest{VolumeName: "foo"})
+	if err != nil {
+		t.Fatalf("scrub failed: %v", err)
+	}
+	if len(resp.Problems) != 0 {
+		t.Errorf("problems remain after repair: %v", resp.Problems)
+	}
+}
+
+func TestStorageScrubNotFound(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	_, err = rpcClient.StorageScrub(ctx, &wire.StorageScrubRequest{VolumeName: "missing"})
+	if err := checkRPCError(err, codes.NotFound, "volume name not found"); err != nil {
+		t.Error(err)
+	}
+}
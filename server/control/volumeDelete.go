@@ -0,0 +1,24 @@
+package control
+
+import (
+	"context"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) VolumeDelete(ctx context.Context, req *wire.VolumeDeleteRequest) (*wire.VolumeDeleteResponse, error) {
+	if err := c.app.DeleteVolume(req.VolumeName); err != nil {
+		switch err {
+		case db.ErrVolNameNotFound:
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case server.ErrVolumeMounted, server.ErrVolumeInUse:
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, err
+	}
+	return &wire.VolumeDeleteResponse{}, nil
+}
@@ -0,0 +1,73 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestVolumeDeleteInUse(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	createReq := &wire.VolumeCreateRequest{
+		VolumeName:     "foo",
+		Backend:        "local",
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient.VolumeCreate(ctx, createReq); err != nil {
+		t.Fatalf("creating volume failed: %v", err)
+	}
+
+	ref, err := app.GetVolumeByName("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = rpcClient.VolumeDelete(ctx, &wire.VolumeDeleteRequest{VolumeName: "foo"})
+	ref.Close()
+	if err == nil {
+		t.Fatalf("expected error from VolumeDelete of volume in use")
+	}
+	if err := checkRPCError(err, codes.FailedPrecondition, "volume is in use"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := rpcClient.VolumeDelete(ctx, &wire.VolumeDeleteRequest{VolumeName: "foo"}); err != nil {
+		t.Fatalf("deleting volume failed: %v", err)
+	}
+	check := func(tx *db.Tx) error {
+		if _, err := tx.Volumes().GetByName("foo"); err != db.ErrVolNameNotFound {
+			t.Errorf("expected ErrVolNameNotFound, got %v", err)
+		}
+		return nil
+	}
+	if err := app.DB.View(check); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,35 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) VolumeGet(ctx context.Context, req *wire.VolumeGetRequest) (*wire.VolumeGetResponse, error) {
+	resp := &wire.VolumeGetResponse{}
+	get := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName(req.VolumeName)
+		if err != nil {
+			return err
+		}
+		info, err := volumeInfo(tx, req.VolumeName, vol)
+		if err != nil {
+			return err
+		}
+		resp.Volume = info
+		return nil
+	}
+	if err := c.app.DB.View(get); err != nil {
+		if err == db.ErrVolNameNotFound {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		log.Printf("db error: getting volume %q: %v", req.VolumeName, err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+package control
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/server/invite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errNoVolumeStorage = errors.New("volume has no storage")
+
+// inviteSharingKey returns the sharing key to give to invitees of
+// the volume; that of the default storage, if it exists.
+func inviteSharingKey(tx *db.Tx, vol *db.Volume) (*db.SharingKey, error) {
+	var name string
+	found := false
+	c := vol.Storage().Cursor()
+	for item := c.First(); item != nil; item = c.Next() {
+		if found && item.Name() != "default" {
+			// prefer default, otherwise take the first one
+			continue
+		}
+		n, err := item.SharingKeyName()
+		if err != nil {
+			return nil, err
+		}
+		name = n
+		found = true
+	}
+	if !found {
+		return nil, errNoVolumeStorage
+	}
+	return tx.SharingKeys().Get(name)
+}
+
+func (c controlRPC) VolumeInvite(ctx context.Context, req *wire.VolumeInviteRequest) (*wire.VolumeInviteResponse, error) {
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(req.Pub); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+	}
+	if pub == *(*peer.PublicKey)(c.app.Keys.Sign.Pub) {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot invite self")
+	}
+	if req.ValidSeconds <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invitation validity must be positive")
+	}
+	if req.Backend != "" {
+		if err := c.app.ValidateKV(req.Backend); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid backend: %q", req.Backend)
+		}
+	}
+
+	inv := &invite.Invitation{
+		VolumeName: req.VolumeName,
+		Inviter:    *(*peer.PublicKey)(c.app.Keys.Sign.Pub),
+		Invitee:    pub,
+		Locations:  req.Locations,
+		Expires:    time.Now().Add(time.Duration(req.ValidSeconds) * time.Second),
+	}
+	volumeInvite := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName(req.VolumeName)
+		if err != nil {
+			return err
+		}
+		vol.VolumeID(&inv.VolumeID)
+		sharingKey, err := inviteSharingKey(tx, vol)
+		if err != nil {
+			return err
+		}
+		inv.SharingKeyName = sharingKey.Name()
+		sharingKey.Secret(&inv.SharingKey)
+		inv.SharingKeyPadding = sharingKey.Padding()
+
+		p, err := tx.Peers().Make(&pub)
+		if err != nil {
+			return err
+		}
+		if err := p.Volumes().Allow(vol); err != nil {
+			return err
+		}
+		if req.Backend != "" {
+			if err := p.Storage().Allow(req.Backend); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := c.app.DB.Update(volumeInvite); err != nil {
+		switch err {
+		case db.ErrVolNameNotFound:
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case errNoVolumeStorage, db.ErrSharingKeyNotFound:
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, err
+	}
+
+	token, err := invite.Seal(inv, c.app.Keys)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.VolumeInviteResponse{Token: token}, nil
+}
@@ -0,0 +1,104 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/server/invite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// joinSharingKey adds the sharing key from the invitation, or finds
+// an existing identical one. If name is empty, the name used by the
+// inviter is tried first, then one qualified by the inviter key.
+func joinSharingKey(tx *db.Tx, inv *invite.Invitation, name string) (*db.SharingKey, error) {
+	candidates := []string{name}
+	if name == "" {
+		candidates = []string{
+			inv.SharingKeyName,
+			fmt.Sprintf("%s-%s", inv.SharingKeyName, inv.Inviter.String()[:8]),
+		}
+	}
+	keys := tx.SharingKeys()
+	for _, n := range candidates {
+		sharingKey, err := keys.Get(n)
+		if err == db.ErrSharingKeyNotFound {
+			sharingKey, err := keys.Add(n, &inv.SharingKey)
+			if err != nil {
+				return nil, err
+			}
+			if err := sharingKey.SetPadding(inv.SharingKeyPadding); err != nil {
+				return nil, err
+			}
+			return sharingKey, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var secret [32]byte
+		sharingKey.Secret(&secret)
+		if secret == inv.SharingKey && sharingKey.Padding() == inv.SharingKeyPadding {
+			return sharingKey, nil
+		}
+	}
+	return nil, db.ErrSharingKeyExist
+}
+
+func (c controlRPC) VolumeJoin(ctx context.Context, req *wire.VolumeJoinRequest) (*wire.VolumeJoinResponse, error) {
+	inv, err := invite.Open(req.Token, c.app.Keys, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := c.app.ValidateKV(req.Backend); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid backend: %q", req.Backend)
+	}
+
+	resp := &wire.VolumeJoinResponse{
+		VolumeName: req.LocalVolumeName,
+	}
+	if resp.VolumeName == "" {
+		resp.VolumeName = inv.VolumeName
+	}
+	volumeJoin := func(tx *db.Tx) error {
+		sharingKey, err := joinSharingKey(tx, inv, req.SharingKeyName)
+		if err != nil {
+			return err
+		}
+		resp.SharingKeyName = sharingKey.Name()
+
+		p, err := tx.Peers().Make(&inv.Inviter)
+		if err != nil {
+			return err
+		}
+		for _, addr := range inv.Locations {
+			if err := p.Locations().Add(addr, 0); err != nil {
+				return err
+			}
+		}
+
+		v, err := tx.Volumes().Add(resp.VolumeName, &inv.VolumeID, req.Backend, sharingKey)
+		if err != nil {
+			return err
+		}
+		if err := p.Volumes().Allow(v); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := c.app.DB.Update(volumeJoin); err != nil {
+		switch err {
+		case db.ErrVolNameInvalid, db.ErrSharingKeyNameInvalid, db.ErrPeerLocationInvalid:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case db.ErrVolNameExist, db.ErrVolumeIDExist:
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		case db.ErrSharingKeyExist:
+			return nil, status.Errorf(codes.AlreadyExists, "sharing key exists already with a different secret, pick another name")
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,151 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestVolumeInviteJoin(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1, err := server.New(tmp.Subdir("app1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app1.Close()
+	app2, err := server.New(tmp.Subdir("app2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app2.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl1 := controltest.ListenAndServe(t, &wg, app1)
+	defer ctrl1.Close()
+	ctrl2 := controltest.ListenAndServe(t, &wg, app2)
+	defer ctrl2.Close()
+
+	rpcConn1, err := grpcunix.Dial(filepath.Join(app1.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn1.Close()
+	rpcClient1 := wire.NewControlClient(rpcConn1)
+	rpcConn2, err := grpcunix.Dial(filepath.Join(app2.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn2.Close()
+	rpcClient2 := wire.NewControlClient(rpcConn2)
+
+	ctx := context.Background()
+	createReq := &wire.VolumeCreateRequest{
+		VolumeName:     "foo",
+		Backend:        "local",
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient1.VolumeCreate(ctx, createReq); err != nil {
+		t.Fatalf("creating volume failed: %v", err)
+	}
+
+	pub1 := (*peer.PublicKey)(app1.Keys.Sign.Pub)
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+	inviteReq := &wire.VolumeInviteRequest{
+		VolumeName:   "foo",
+		Pub:          pub2[:],
+		Locations:    []string{"192.0.2.1:1234"},
+		Backend:      "local",
+		ValidSeconds: 60,
+	}
+	inviteResp, err := rpcClient1.VolumeInvite(ctx, inviteReq)
+	if err != nil {
+		t.Fatalf("invite failed: %v", err)
+	}
+
+	joinReq := &wire.VolumeJoinRequest{
+		Token:   inviteResp.Token,
+		Backend: "local",
+	}
+	joinResp, err := rpcClient2.VolumeJoin(ctx, joinReq)
+	if err != nil {
+		t.Fatalf("join failed: %v", err)
+	}
+	if g, e := joinResp.VolumeName, "foo"; g != e {
+		t.Errorf("wrong volume name: %q != %q", g, e)
+	}
+	// both have a "default" sharing key, with different secrets
+	if !strings.HasPrefix(joinResp.SharingKeyName, "default-") {
+		t.Errorf("unexpected sharing key name: %q", joinResp.SharingKeyName)
+	}
+
+	var volID db.VolumeID
+	var secret [32]byte
+	check1 := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName("foo")
+		if err != nil {
+			return err
+		}
+		vol.VolumeID(&volID)
+		sharingKey, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		sharingKey.Secret(&secret)
+
+		p, err := tx.Peers().Get(pub2)
+		if err != nil {
+			return err
+		}
+		if !p.Volumes().IsAllowed(vol) {
+			t.Error("invitee not allowed to use volume")
+		}
+		return nil
+	}
+	if err := app1.DB.View(check1); err != nil {
+		t.Fatal(err)
+	}
+
+	check2 := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName("foo")
+		if err != nil {
+			return err
+		}
+		var id db.VolumeID
+		vol.VolumeID(&id)
+		if id != volID {
+			t.Errorf("wrong volume ID: %v != %v", id, volID)
+		}
+		sharingKey, err := tx.SharingKeys().Get(joinResp.SharingKeyName)
+		if err != nil {
+			return err
+		}
+		var got [32]byte
+		sharingKey.Secret(&got)
+		if got != secret {
+			t.Error("sharing key secret does not match")
+		}
+		p, err := tx.Peers().Get(pub1)
+		if err != nil {
+			return err
+		}
+		if g, e := strings.Join(p.Locations().List(), ","), "192.0.2.1:1234"; g != e {
+			t.Errorf("wrong inviter locations: %q != %q", g, e)
+		}
+		return nil
+	}
+	if err := app2.DB.View(check2); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,67 @@
+package control
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// volumeInfo describes a volume for listing purposes.
+func volumeInfo(tx *db.Tx, name string, vol *db.Volume) (*wire.VolumeInfo, error) {
+	var volID db.VolumeID
+	vol.VolumeID(&volID)
+	info := &wire.VolumeInfo{
+		VolumeName: name,
+		VolumeID:   volID[:],
+	}
+
+	c := vol.Storage().Cursor()
+	for item := c.First(); item != nil; item = c.Next() {
+		backend, err := item.Backend()
+		if err != nil {
+			return nil, err
+		}
+		sharingKeyName, err := item.SharingKeyName()
+		if err != nil {
+			return nil, err
+		}
+		info.Storage = append(info.Storage, &wire.VolumeStorageInfo{
+			Name:           item.Name(),
+			Backend:        backend,
+			SharingKeyName: sharingKeyName,
+		})
+	}
+
+	peers := tx.Peers().Cursor()
+	for p := peers.First(); p != nil; p = peers.Next() {
+		if !p.Volumes().IsAllowed(vol) {
+			continue
+		}
+		info.Peers = append(info.Peers, p.Pub()[:])
+	}
+	return info, nil
+}
+
+func (c controlRPC) VolumeList(ctx context.Context, req *wire.VolumeListRequest) (*wire.VolumeListResponse, error) {
+	resp := &wire.VolumeListResponse{}
+	list := func(tx *db.Tx) error {
+		c := tx.Volumes().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			info, err := volumeInfo(tx, item.Name(), item.Volume())
+			if err != nil {
+				return err
+			}
+			resp.Volumes = append(resp.Volumes, info)
+		}
+		return nil
+	}
+	if err := c.app.DB.View(list); err != nil {
+		log.Printf("db error: listing volumes: %v", err)
+		return nil, status.Errorf(codes.Internal, "database error")
+	}
+	return resp, nil
+}
@@ -0,0 +1,28 @@
+package control
+
+import (
+	"context"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) VolumeRename(ctx context.Context, req *wire.VolumeRenameRequest) (*wire.VolumeRenameResponse, error) {
+	rename := func(tx *db.Tx) error {
+		return tx.Volumes().Rename(req.VolumeName, req.NewName)
+	}
+	if err := c.app.DB.Update(rename); err != nil {
+		switch err {
+		case db.ErrVolNameInvalid:
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		case db.ErrVolNameNotFound:
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		case db.ErrVolNameExist:
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+		return nil, err
+	}
+	return &wire.VolumeRenameResponse{}, nil
+}
@@ -2,9 +2,11 @@ package control
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"bazil.org/bazil/db"
+	"bazil.org/bazil/fs"
 	"bazil.org/bazil/peer"
 	wirepeer "bazil.org/bazil/peer/wire"
 	"bazil.org/bazil/server/control/wire"
@@ -12,7 +14,14 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+var errSyncHeaderRepeated = errors.New("error, peers or directory clock after first message")
+
 func (c controlRPC) VolumeSync(ctx context.Context, req *wire.VolumeSyncRequest) (*wire.VolumeSyncResponse, error) {
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(req.Pub); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
+	}
+
 	var volID db.VolumeID
 	loadVolume := func(tx *db.Tx) error {
 		v, err := tx.Volumes().GetByName(req.VolumeName)
@@ -23,30 +32,58 @@ func (c controlRPC) VolumeSync(ctx context.Context, req *wire.VolumeSyncRequest)
 			return err
 		}
 		v.VolumeID(&volID)
+
+		// Only merge changes the peer is allowed to make. Pulling
+		// from a peer that has no grant for the volume is trusted
+		// as an explicit choice.
+		p, err := tx.Peers().Get(&pub)
+		if err != nil {
+			if err == db.ErrPeerNotFound {
+				return status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			return err
+		}
+		grant, err := p.Volumes().Grant(v)
+		if err == db.ErrPeerVolumeNotAllowed {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if grant.ReadOnly {
+			return status.Errorf(codes.PermissionDenied, "peer has read-only access to volume")
+		}
+		if !grant.AllowsPath(req.Path) {
+			return status.Errorf(codes.PermissionDenied, "peer is not allowed to change that path")
+		}
 		return nil
 	}
 	if err := c.app.DB.View(loadVolume); err != nil {
 		return nil, err
 	}
 
-	var pub peer.PublicKey
-	if err := pub.UnmarshalBinary(req.Pub); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "bad peer public key: %v", err)
-	}
-
 	client, err := c.app.DialPeer(&pub)
 	if err != nil {
 		return nil, err
 	}
 	defer client.Close()
+	proto, err := client.Protocol(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Require(peer.CapVolumeSync); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
 	volIDBuf, err := volID.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
 
 	peerReq := &wirepeer.VolumeSyncPullRequest{
-		VolumeID: volIDBuf,
-		Path:     req.Path,
+		VolumeID:     volIDBuf,
+		Path:         req.Path,
+		Version:      peer.ProtocolVersion,
+		Capabilities: peer.Capabilities(),
 	}
 	stream, err := client.VolumeSyncPull(ctx, peerReq)
 	if err != nil {
@@ -54,7 +91,10 @@ func (c controlRPC) VolumeSync(ctx context.Context, req *wire.VolumeSyncRequest)
 	}
 
 	first, err := stream.Recv()
-	if err != nil && err != io.EOF {
+	if err == io.EOF {
+		return nil, status.Errorf(codes.FailedPrecondition, "peer sent invalid sync data: empty response")
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -78,6 +118,10 @@ func (c controlRPC) VolumeSync(ctx context.Context, req *wire.VolumeSyncRequest)
 		if err != nil {
 			return nil, err
 		}
+		// these are only allowed in the first message
+		if item.Error != wirepeer.VolumeSyncPullItem_SUCCESS || item.Peers != nil || item.DirClock != nil {
+			return nil, errSyncHeaderRepeated
+		}
 		return item.Children, nil
 	}
 
@@ -87,7 +131,10 @@ func (c controlRPC) VolumeSync(ctx context.Context, req *wire.VolumeSyncRequest)
 	}
 	defer ref.Close()
 
-	if err := ref.FS().SyncReceive(ctx, req.Path, first.Peers, first.DirClock, recv); err != nil {
+	if err := ref.SyncReceive(ctx, req.Path, first.Peers, first.DirClock, recv); err != nil {
+		if _, ok := err.(*fs.InvalidSyncError); ok || err == errSyncHeaderRepeated {
+			return nil, status.Errorf(codes.FailedPrecondition, "peer sent invalid sync data: %v", err)
+		}
 		return nil, err
 	}
 
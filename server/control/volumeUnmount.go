@@ -0,0 +1,29 @@
+package control
+
+import (
+	"context"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c controlRPC) VolumeUnmount(ctx context.Context, req *wire.VolumeUnmountRequest) (*wire.VolumeUnmountResponse, error) {
+	ref, err := c.app.GetVolumeByName(req.VolumeName)
+	if err != nil {
+		if err == db.ErrVolNameNotFound {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, err
+	}
+	defer ref.Close()
+	if err := ref.Unmount(ctx); err != nil {
+		if _, busy := err.(*server.VolumeBusyError); busy || err == server.ErrNotMounted {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, err
+	}
+	return &wire.VolumeUnmountResponse{}, nil
+}
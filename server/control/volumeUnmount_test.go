@@ -0,0 +1,62 @@
+package control_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/control/controltest"
+	"bazil.org/bazil/server/control/wire"
+	"bazil.org/bazil/util/grpcunix"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+)
+
+func TestVolumeUnmountNotMounted(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctrl := controltest.ListenAndServe(t, &wg, app)
+	defer ctrl.Close()
+
+	rpcConn, err := grpcunix.Dial(filepath.Join(app.DataDir, "control"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpcConn.Close()
+	rpcClient := wire.NewControlClient(rpcConn)
+
+	ctx := context.Background()
+	createReq := &wire.VolumeCreateRequest{
+		VolumeName:     "foo",
+		Backend:        "local",
+		SharingKeyName: "default",
+	}
+	if _, err := rpcClient.VolumeCreate(ctx, createReq); err != nil {
+		t.Fatalf("creating volume failed: %v", err)
+	}
+
+	_, err = rpcClient.VolumeUnmount(ctx, &wire.VolumeUnmountRequest{VolumeName: "foo"})
+	if err == nil {
+		t.Fatalf("expected error from VolumeUnmount of unmounted volume")
+	}
+	if err := checkRPCError(err, codes.FailedPrecondition, "not currently mounted"); err != nil {
+		t.Error(err)
+	}
+
+	resp, err := rpcClient.MountList(ctx, &wire.MountListRequest{})
+	if err != nil {
+		t.Fatalf("listing mounts failed: %v", err)
+	}
+	if g, e := len(resp.Mounts), 0; g != e {
+		t.Errorf("wrong number of mounts: %v != %v", g, e)
+	}
+}
@@ -96,33 +96,58 @@ func init() {
 }
 
 var fileDescriptor_225e4c08a400f555 = []byte{
-	// 413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xd1, 0x4e, 0xfa, 0x30,
-	0x14, 0xc6, 0xff, 0x17, 0x04, 0xfe, 0x29, 0xa0, 0xa6, 0x97, 0x18, 0x8d, 0x4c, 0xc5, 0x3b, 0xa6,
-	0xf2, 0x04, 0xc8, 0x85, 0x89, 0x68, 0x42, 0x5c, 0x42, 0xa2, 0xf1, 0x66, 0x1b, 0x27, 0x73, 0x71,
-	0xb6, 0xd8, 0x75, 0x90, 0xf9, 0x8e, 0xbe, 0x93, 0xd9, 0xba, 0xce, 0x6e, 0x74, 0x63, 0x77, 0xb4,
-	0xdf, 0xf7, 0xfd, 0x4e, 0x7b, 0x0e, 0x2b, 0xba, 0x71, 0xec, 0x6f, 0x3f, 0x18, 0x53, 0xe6, 0x99,
-	0xe9, 0x2f, 0x33, 0x04, 0xb6, 0x01, 0x66, 0xba, 0x94, 0x70, 0x46, 0x03, 0x73, 0xeb, 0x33, 0x90,
-	0x8b, 0xf1, 0x9a, 0x51, 0x4e, 0x71, 0x5f, 0x44, 0xb2, 0xcd, 0xc1, 0x75, 0x13, 0xc2, 0x86, 0x06,
-	0xd1, 0x27, 0x08, 0xc0, 0xa0, 0x51, 0xcd, 0xf0, 0xdd, 0x66, 0x3e, 0xf1, 0xb2, 0xc8, 0xb8, 0x49,
-	0x64, 0x0d, 0xc0, 0x32, 0xff, 0xa4, 0x91, 0x3f, 0x72, 0x02, 0xdf, 0xfd, 0x80, 0x58, 0x84, 0x8c,
-	0x3e, 0xea, 0x2e, 0x7c, 0xe2, 0x3d, 0xc3, 0x57, 0x04, 0x21, 0x37, 0x0e, 0x50, 0x4f, 0x2c, 0xc3,
-	0x35, 0x25, 0x21, 0xdc, 0xfe, 0xfc, 0x47, 0x9d, 0x99, 0xc8, 0xe3, 0x29, 0x6a, 0x25, 0x1a, 0x96,
-	0x07, 0x93, 0x1d, 0x52, 0xf2, 0x83, 0x63, 0xad, 0x26, 0x60, 0xc6, 0x3f, 0xfc, 0x82, 0x7a, 0x8b,
-	0xf4, 0x00, 0x73, 0x88, 0xef, 0x81, 0x63, 0xa3, 0x6c, 0x57, 0x44, 0x89, 0x3c, 0xaf, 0xf5, 0xa8,
-	0xe8, 0x65, 0xda, 0xf0, 0x19, 0x03, 0x9b, 0xc3, 0x0e, 0x5a, 0x15, 0xab, 0xd0, 0x45, 0x4f, 0x8e,
-	0x7e, 0x43, 0xfd, 0x4c, 0xa1, 0x84, 0x80, 0xcb, 0x71, 0x45, 0x4e, 0xa8, 0x12, 0x7e, 0x51, 0x6f,
-	0xca, 0xe9, 0x4b, 0xd4, 0x15, 0xd2, 0x13, 0x8d, 0x08, 0xc7, 0x43, 0x6d, 0x2c, 0xd5, 0x24, 0xd9,
-	0xa8, 0xb3, 0xe4, 0x5c, 0x40, 0x47, 0x42, 0xb0, 0x38, 0x65, 0xb6, 0x07, 0xd3, 0xd5, 0x0a, 0x8f,
-	0xb4, 0xc9, 0x3f, 0x83, 0xac, 0x70, 0xb5, 0xd7, 0x97, 0x97, 0xb1, 0x10, 0xca, 0xd4, 0x98, 0xb8,
-	0xf8, 0x4c, 0x1f, 0x8c, 0x89, 0x2b, 0xd1, 0xc3, 0x1a, 0x87, 0xda, 0x71, 0x4b, 0x7c, 0x0b, 0x73,
-	0x88, 0x93, 0x83, 0x97, 0x3b, 0x5e, 0x50, 0xab, 0x3a, 0x5e, 0x32, 0xe5, 0xf4, 0x07, 0xd4, 0x59,
-	0x00, 0xb0, 0x84, 0x7b, 0x52, 0xfe, 0x73, 0x89, 0x7d, 0x49, 0x3c, 0xad, 0x92, 0x73, 0x96, 0x83,
-	0x0e, 0x93, 0xcd, 0x47, 0xea, 0xda, 0xdc, 0xa7, 0xc4, 0x02, 0x8e, 0x2f, 0x35, 0x21, 0x45, 0x97,
-	0xec, 0xd1, 0x3e, 0x9b, 0x3a, 0xc9, 0x44, 0x94, 0xed, 0x0f, 0x02, 0xba, 0xc5, 0xba, 0xb4, 0x6a,
-	0xa8, 0x9a, 0xe4, 0xae, 0xaf, 0x7c, 0x15, 0x31, 0x10, 0x51, 0x45, 0x77, 0x15, 0x45, 0xaf, 0xbb,
-	0x4a, 0xc1, 0x26, 0x6b, 0xdc, 0xb5, 0x5f, 0x5b, 0xc9, 0x33, 0xe4, 0xb4, 0xd3, 0xd7, 0x67, 0xf2,
-	0x1b, 0x00, 0x00, 0xff, 0xff, 0x60, 0xd7, 0xbf, 0x47, 0x8b, 0x05, 0x00, 0x00,
+	// 812 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0xe5, 0x61, 0xda, 0xc0, 0xfb, 0x00, 0xe5, 0x71, 0x13, 0xdb, 0xe8, 0xb6, 0x6e, 0x7b, 0x59,
+	0x61, 0xfb, 0x05, 0x63, 0x20, 0xd4, 0x8d, 0x49, 0x55, 0x2b, 0x26, 0x81, 0x90, 0x50, 0x3f, 0xae,
+	0x8a, 0xb5, 0xc4, 0x2e, 0x8e, 0xdb, 0x51, 0x7e, 0x13, 0x3f, 0x12, 0xd9, 0x8e, 0xd3, 0x9b, 0xc4,
+	0x76, 0xc2, 0x5b, 0xeb, 0x73, 0xee, 0xb9, 0xf6, 0x3d, 0xf7, 0xc6, 0x09, 0x79, 0x37, 0x1a, 0xfe,
+	0xa1, 0xf1, 0x05, 0x17, 0xd3, 0x8e, 0xfe, 0xd5, 0x49, 0x41, 0x2c, 0x40, 0x74, 0xc6, 0x9c, 0x49,
+	0xc1, 0xe3, 0xce, 0x13, 0x15, 0x60, 0xff, 0x5c, 0xcc, 0x04, 0x97, 0x3c, 0xda, 0x36, 0x21, 0xd9,
+	0xe2, 0xee, 0xdb, 0x26, 0x0a, 0x0b, 0x1e, 0xcf, 0x13, 0x30, 0x02, 0xbb, 0x8d, 0x72, 0xa6, 0x3f,
+	0x87, 0x82, 0xb2, 0x69, 0x16, 0x72, 0xd1, 0x24, 0x64, 0x06, 0x20, 0x32, 0xfe, 0x55, 0x23, 0xfe,
+	0x7c, 0x14, 0xd3, 0xf1, 0x23, 0x2c, 0xb3, 0xa0, 0xcb, 0x26, 0x41, 0x74, 0x02, 0x4c, 0x52, 0xb9,
+	0xfc, 0xaf, 0xb3, 0x48, 0x2e, 0x86, 0xd3, 0xec, 0xf8, 0xad, 0x6d, 0xb2, 0xd9, 0xa3, 0x6c, 0xda,
+	0x87, 0x5f, 0x73, 0x48, 0x65, 0x6b, 0x87, 0x6c, 0x99, 0xbf, 0xe9, 0x8c, 0xb3, 0x14, 0x2e, 0xff,
+	0xee, 0x91, 0x8d, 0x1b, 0x13, 0x1d, 0x5d, 0x93, 0x35, 0x85, 0x45, 0xf6, 0xfc, 0xd6, 0x08, 0x14,
+	0xbf, 0xbb, 0xe7, 0xc4, 0x8c, 0x58, 0xeb, 0x59, 0xf4, 0x95, 0x6c, 0xf5, 0xf4, 0x39, 0xef, 0x60,
+	0xf9, 0x09, 0x64, 0xd4, 0x2a, 0xd3, 0x11, 0x68, 0x25, 0x8f, 0x82, 0x9c, 0x5c, 0xfa, 0x07, 0xd9,
+	0xe9, 0x66, 0xd5, 0xf8, 0xf8, 0x7b, 0xc6, 0x85, 0x8c, 0x8e, 0x4b, 0x81, 0x45, 0xd8, 0xca, 0x9f,
+	0xd4, 0xb0, 0xf0, 0xde, 0x1f, 0x74, 0xe3, 0xdc, 0x08, 0x18, 0x4a, 0xa8, 0xec, 0x1d, 0x83, 0xbe,
+	0xbd, 0x17, 0x39, 0xb9, 0xf4, 0x80, 0x10, 0x83, 0x7c, 0xa6, 0xa9, 0x8c, 0x0e, 0x9d, 0x41, 0x0a,
+	0xb2, 0xb2, 0x6f, 0x02, 0x8c, 0x5c, 0xb4, 0x47, 0x5e, 0x98, 0x75, 0x55, 0xe8, 0x03, 0x67, 0x04,
+	0xaa, 0xf2, 0xa1, 0x9f, 0x50, 0xad, 0xc0, 0x07, 0x88, 0xc1, 0x5b, 0x01, 0x03, 0x86, 0x2b, 0x60,
+	0x39, 0x55, 0xe9, 0x3e, 0xb0, 0x61, 0xe2, 0x93, 0x36, 0x60, 0x58, 0xda, 0x72, 0x72, 0xe9, 0xef,
+	0x64, 0x3b, 0x2b, 0x3b, 0x67, 0x0c, 0xc6, 0x32, 0xf2, 0x98, 0x62, 0x50, 0x2b, 0x7e, 0x1c, 0x26,
+	0x55, 0x37, 0xde, 0x65, 0x0b, 0xea, 0xad, 0x89, 0x01, 0xc3, 0x1b, 0xb7, 0x9c, 0x6a, 0x57, 0xdc,
+	0x72, 0xca, 0x3c, 0x5d, 0xa1, 0xa0, 0x70, 0x57, 0x18, 0x46, 0x2e, 0xfa, 0x40, 0x36, 0xcd, 0xfa,
+	0x3d, 0x9f, 0x33, 0x19, 0xb9, 0x63, 0x34, 0x66, 0x65, 0x5b, 0x21, 0x4a, 0xb5, 0xca, 0x5f, 0x58,
+	0xa2, 0x95, 0xdd, 0x87, 0xcc, 0xd0, 0x70, 0x95, 0x73, 0x12, 0xee, 0x65, 0x9d, 0x50, 0xcf, 0x47,
+	0xb9, 0x97, 0x73, 0xc4, 0xd7, 0xcb, 0x88, 0x90, 0x2b, 0x02, 0x79, 0x65, 0x92, 0x0d, 0xcc, 0xe3,
+	0xf0, 0x7a, 0x32, 0x89, 0xda, 0xce, 0xdd, 0xac, 0x08, 0x56, 0xff, 0xb4, 0x96, 0x57, 0xf5, 0x70,
+	0xb0, 0x64, 0x63, 0x8f, 0x87, 0x0a, 0x0a, 0x7b, 0x68, 0x18, 0xb8, 0xe7, 0xb2, 0x64, 0x83, 0xb1,
+	0x98, 0x8f, 0x2a, 0x3d, 0x87, 0x41, 0x5f, 0xcf, 0x15, 0x39, 0xd8, 0xc6, 0x81, 0xb9, 0xeb, 0xee,
+	0x60, 0xa9, 0x6a, 0x52, 0x89, 0xc3, 0xa8, 0xcf, 0xc6, 0x12, 0x09, 0x3f, 0xa3, 0x57, 0x90, 0xf6,
+	0xd2, 0x1f, 0x89, 0x0d, 0x3d, 0xa9, 0x61, 0x61, 0x57, 0x57, 0x58, 0x9f, 0x4b, 0xf5, 0x9c, 0x6e,
+	0x7b, 0x83, 0x0d, 0xc1, 0xe7, 0x6a, 0x95, 0x97, 0xa7, 0x79, 0x24, 0x51, 0x09, 0xa5, 0x9c, 0x45,
+	0x67, 0x61, 0x01, 0xca, 0xf3, 0x49, 0x3d, 0x6f, 0xc0, 0x74, 0x9f, 0x29, 0xbb, 0xda, 0xfc, 0x67,
+	0x2a, 0x5e, 0x6e, 0xa7, 0xb5, 0x3c, 0x77, 0x9a, 0x6e, 0x52, 0x93, 0xa6, 0x9b, 0x34, 0x4b, 0xd3,
+	0x4d, 0x4a, 0x69, 0x6e, 0xc9, 0x46, 0x0f, 0x40, 0xa8, 0xd6, 0x7a, 0x5d, 0xbe, 0xd8, 0xcd, 0xba,
+	0x15, 0xdd, 0xf7, 0xc1, 0x78, 0xb8, 0xd4, 0x62, 0x1f, 0x12, 0xbe, 0x80, 0xca, 0x70, 0xad, 0x20,
+	0xdf, 0x70, 0x61, 0x46, 0x2e, 0x7a, 0x4f, 0x9e, 0xab, 0x75, 0xdd, 0x9d, 0xae, 0x2d, 0xe0, 0xbe,
+	0x3c, 0xf0, 0xe2, 0xe5, 0xf3, 0xaa, 0x3b, 0xd8, 0x75, 0x5e, 0x74, 0x03, 0xef, 0xfb, 0xe0, 0x5c,
+	0x6b, 0x44, 0x5e, 0xea, 0x0c, 0x7c, 0xac, 0x7b, 0x64, 0x00, 0x32, 0x3a, 0x71, 0xed, 0x60, 0x85,
+	0x5b, 0xed, 0x76, 0x1d, 0xcd, 0x97, 0x43, 0xf9, 0x14, 0xca, 0x81, 0xfc, 0x6a, 0xd7, 0xd1, 0xf0,
+	0xf8, 0x60, 0x30, 0xf3, 0xef, 0x2c, 0x10, 0x5f, 0xf4, 0xf1, 0xbc, 0x01, 0x13, 0xf7, 0x35, 0xc6,
+	0xb5, 0xaf, 0xa1, 0xad, 0x62, 0x7f, 0x4f, 0x6b, 0x79, 0xe5, 0x34, 0xf6, 0x12, 0x88, 0x63, 0xfe,
+	0xe4, 0x4c, 0x83, 0x09, 0xa1, 0x34, 0x45, 0x5e, 0xd9, 0x1e, 0x73, 0x2d, 0x98, 0x2c, 0x2e, 0x7b,
+	0x10, 0x1e, 0xb2, 0xa7, 0x40, 0x2b, 0x8f, 0xd5, 0x40, 0x0e, 0xe5, 0x3c, 0x75, 0x8e, 0x95, 0x81,
+	0x42, 0x63, 0x65, 0x19, 0xe5, 0xb1, 0xea, 0x0d, 0xa9, 0x70, 0x8e, 0x95, 0x02, 0x42, 0x63, 0x65,
+	0xf0, 0xc2, 0x87, 0x44, 0xb6, 0xaa, 0xdf, 0x8e, 0x5a, 0x9e, 0x10, 0xfc, 0x7e, 0x74, 0x14, 0xe4,
+	0x94, 0x4b, 0xdc, 0x03, 0x36, 0xa1, 0x6c, 0xaa, 0xfb, 0xc5, 0x55, 0x62, 0x84, 0x87, 0x4a, 0x5c,
+	0xa0, 0xe1, 0xb7, 0x30, 0xfd, 0x38, 0x9b, 0xcd, 0x84, 0x6a, 0x7d, 0x57, 0x05, 0x33, 0xcc, 0xf7,
+	0x16, 0x56, 0xa0, 0x58, 0xdd, 0xf7, 0xeb, 0xdf, 0xd6, 0xd4, 0x37, 0xde, 0x68, 0x5d, 0x7f, 0xdc,
+	0x5d, 0xfd, 0x1b, 0x00, 0x29, 0xaa, 0xc0, 0xb4, 0x51, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -139,16 +164,42 @@ const _ = grpc.SupportPackageIsVersion4
 type ControlClient interface {
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 	PublicKeyGet(ctx context.Context, in *PublicKeyGetRequest, opts ...grpc.CallOption) (*PublicKeyGetResponse, error)
+	IdentityExport(ctx context.Context, in *IdentityExportRequest, opts ...grpc.CallOption) (*IdentityExportResponse, error)
 	VolumeCreate(ctx context.Context, in *VolumeCreateRequest, opts ...grpc.CallOption) (*VolumeCreateResponse, error)
+	VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error)
+	VolumeGet(ctx context.Context, in *VolumeGetRequest, opts ...grpc.CallOption) (*VolumeGetResponse, error)
+	VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error)
+	VolumeRename(ctx context.Context, in *VolumeRenameRequest, opts ...grpc.CallOption) (*VolumeRenameResponse, error)
 	VolumeConnect(ctx context.Context, in *VolumeConnectRequest, opts ...grpc.CallOption) (*VolumeConnectResponse, error)
+	VolumeInvite(ctx context.Context, in *VolumeInviteRequest, opts ...grpc.CallOption) (*VolumeInviteResponse, error)
+	VolumeJoin(ctx context.Context, in *VolumeJoinRequest, opts ...grpc.CallOption) (*VolumeJoinResponse, error)
 	VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error)
+	VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error)
+	MountList(ctx context.Context, in *MountListRequest, opts ...grpc.CallOption) (*MountListResponse, error)
 	VolumeStorageAdd(ctx context.Context, in *VolumeStorageAddRequest, opts ...grpc.CallOption) (*VolumeStorageAddResponse, error)
 	VolumeSync(ctx context.Context, in *VolumeSyncRequest, opts ...grpc.CallOption) (*VolumeSyncResponse, error)
+	StorageScrub(ctx context.Context, in *StorageScrubRequest, opts ...grpc.CallOption) (*StorageScrubResponse, error)
 	SharingKeyAdd(ctx context.Context, in *SharingKeyAddRequest, opts ...grpc.CallOption) (*SharingKeyAddResponse, error)
+	SharingKeyList(ctx context.Context, in *SharingKeyListRequest, opts ...grpc.CallOption) (*SharingKeyListResponse, error)
+	SharingKeyRotate(ctx context.Context, in *SharingKeyRotateRequest, opts ...grpc.CallOption) (*SharingKeyRotateResponse, error)
+	SharingKeyRotation(ctx context.Context, in *SharingKeyRotationRequest, opts ...grpc.CallOption) (*SharingKeyRotationResponse, error)
+	SharingKeyExport(ctx context.Context, in *SharingKeyExportRequest, opts ...grpc.CallOption) (*SharingKeyExportResponse, error)
+	SharingKeyImport(ctx context.Context, in *SharingKeyImportRequest, opts ...grpc.CallOption) (*SharingKeyImportResponse, error)
 	PeerAdd(ctx context.Context, in *PeerAddRequest, opts ...grpc.CallOption) (*PeerAddResponse, error)
+	PeerRemove(ctx context.Context, in *PeerRemoveRequest, opts ...grpc.CallOption) (*PeerRemoveResponse, error)
+	PeerList(ctx context.Context, in *PeerListRequest, opts ...grpc.CallOption) (*PeerListResponse, error)
+	PeerGet(ctx context.Context, in *PeerGetRequest, opts ...grpc.CallOption) (*PeerGetResponse, error)
 	PeerLocationSet(ctx context.Context, in *PeerLocationSetRequest, opts ...grpc.CallOption) (*PeerLocationSetResponse, error)
+	PeerLocationAdd(ctx context.Context, in *PeerLocationAddRequest, opts ...grpc.CallOption) (*PeerLocationAddResponse, error)
+	PeerLocationRemove(ctx context.Context, in *PeerLocationRemoveRequest, opts ...grpc.CallOption) (*PeerLocationRemoveResponse, error)
+	PeerLocationList(ctx context.Context, in *PeerLocationListRequest, opts ...grpc.CallOption) (*PeerLocationListResponse, error)
 	PeerStorageAllow(ctx context.Context, in *PeerStorageAllowRequest, opts ...grpc.CallOption) (*PeerStorageAllowResponse, error)
 	PeerVolumeAllow(ctx context.Context, in *PeerVolumeAllowRequest, opts ...grpc.CallOption) (*PeerVolumeAllowResponse, error)
+	PeerStatus(ctx context.Context, in *PeerStatusRequest, opts ...grpc.CallOption) (*PeerStatusResponse, error)
+	PeerPair(ctx context.Context, in *PeerPairRequest, opts ...grpc.CallOption) (*PeerPairResponse, error)
+	PeerPairJoin(ctx context.Context, in *PeerPairJoinRequest, opts ...grpc.CallOption) (*PeerPairJoinResponse, error)
+	PeerPendingList(ctx context.Context, in *PeerPendingListRequest, opts ...grpc.CallOption) (*PeerPendingListResponse, error)
+	PeerApprove(ctx context.Context, in *PeerApproveRequest, opts ...grpc.CallOption) (*PeerApproveResponse, error)
 }
 
 type controlClient struct {
@@ -177,6 +228,15 @@ func (c *controlClient) PublicKeyGet(ctx context.Context, in *PublicKeyGetReques
 	return out, nil
 }
 
+func (c *controlClient) IdentityExport(ctx context.Context, in *IdentityExportRequest, opts ...grpc.CallOption) (*IdentityExportResponse, error) {
+	out := new(IdentityExportResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/IdentityExport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) VolumeCreate(ctx context.Context, in *VolumeCreateRequest, opts ...grpc.CallOption) (*VolumeCreateResponse, error) {
 	out := new(VolumeCreateResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeCreate", in, out, opts...)
@@ -186,6 +246,42 @@ func (c *controlClient) VolumeCreate(ctx context.Context, in *VolumeCreateReques
 	return out, nil
 }
 
+func (c *controlClient) VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error) {
+	out := new(VolumeListResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) VolumeGet(ctx context.Context, in *VolumeGetRequest, opts ...grpc.CallOption) (*VolumeGetResponse, error) {
+	out := new(VolumeGetResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error) {
+	out := new(VolumeDeleteResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) VolumeRename(ctx context.Context, in *VolumeRenameRequest, opts ...grpc.CallOption) (*VolumeRenameResponse, error) {
+	out := new(VolumeRenameResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeRename", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) VolumeConnect(ctx context.Context, in *VolumeConnectRequest, opts ...grpc.CallOption) (*VolumeConnectResponse, error) {
 	out := new(VolumeConnectResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeConnect", in, out, opts...)
@@ -195,6 +291,24 @@ func (c *controlClient) VolumeConnect(ctx context.Context, in *VolumeConnectRequ
 	return out, nil
 }
 
+func (c *controlClient) VolumeInvite(ctx context.Context, in *VolumeInviteRequest, opts ...grpc.CallOption) (*VolumeInviteResponse, error) {
+	out := new(VolumeInviteResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) VolumeJoin(ctx context.Context, in *VolumeJoinRequest, opts ...grpc.CallOption) (*VolumeJoinResponse, error) {
+	out := new(VolumeJoinResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeJoin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error) {
 	out := new(VolumeMountResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeMount", in, out, opts...)
@@ -204,6 +318,24 @@ func (c *controlClient) VolumeMount(ctx context.Context, in *VolumeMountRequest,
 	return out, nil
 }
 
+func (c *controlClient) VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error) {
+	out := new(VolumeUnmountResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeUnmount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) MountList(ctx context.Context, in *MountListRequest, opts ...grpc.CallOption) (*MountListResponse, error) {
+	out := new(MountListResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/MountList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) VolumeStorageAdd(ctx context.Context, in *VolumeStorageAddRequest, opts ...grpc.CallOption) (*VolumeStorageAddResponse, error) {
 	out := new(VolumeStorageAddResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/VolumeStorageAdd", in, out, opts...)
@@ -222,6 +354,15 @@ func (c *controlClient) VolumeSync(ctx context.Context, in *VolumeSyncRequest, o
 	return out, nil
 }
 
+func (c *controlClient) StorageScrub(ctx context.Context, in *StorageScrubRequest, opts ...grpc.CallOption) (*StorageScrubResponse, error) {
+	out := new(StorageScrubResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/StorageScrub", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) SharingKeyAdd(ctx context.Context, in *SharingKeyAddRequest, opts ...grpc.CallOption) (*SharingKeyAddResponse, error) {
 	out := new(SharingKeyAddResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/SharingKeyAdd", in, out, opts...)
@@ -231,6 +372,51 @@ func (c *controlClient) SharingKeyAdd(ctx context.Context, in *SharingKeyAddRequ
 	return out, nil
 }
 
+func (c *controlClient) SharingKeyList(ctx context.Context, in *SharingKeyListRequest, opts ...grpc.CallOption) (*SharingKeyListResponse, error) {
+	out := new(SharingKeyListResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/SharingKeyList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SharingKeyRotate(ctx context.Context, in *SharingKeyRotateRequest, opts ...grpc.CallOption) (*SharingKeyRotateResponse, error) {
+	out := new(SharingKeyRotateResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/SharingKeyRotate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SharingKeyRotation(ctx context.Context, in *SharingKeyRotationRequest, opts ...grpc.CallOption) (*SharingKeyRotationResponse, error) {
+	out := new(SharingKeyRotationResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/SharingKeyRotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SharingKeyExport(ctx context.Context, in *SharingKeyExportRequest, opts ...grpc.CallOption) (*SharingKeyExportResponse, error) {
+	out := new(SharingKeyExportResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/SharingKeyExport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SharingKeyImport(ctx context.Context, in *SharingKeyImportRequest, opts ...grpc.CallOption) (*SharingKeyImportResponse, error) {
+	out := new(SharingKeyImportResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/SharingKeyImport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) PeerAdd(ctx context.Context, in *PeerAddRequest, opts ...grpc.CallOption) (*PeerAddResponse, error) {
 	out := new(PeerAddResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerAdd", in, out, opts...)
@@ -240,6 +426,33 @@ func (c *controlClient) PeerAdd(ctx context.Context, in *PeerAddRequest, opts ..
 	return out, nil
 }
 
+func (c *controlClient) PeerRemove(ctx context.Context, in *PeerRemoveRequest, opts ...grpc.CallOption) (*PeerRemoveResponse, error) {
+	out := new(PeerRemoveResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerRemove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerList(ctx context.Context, in *PeerListRequest, opts ...grpc.CallOption) (*PeerListResponse, error) {
+	out := new(PeerListResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerGet(ctx context.Context, in *PeerGetRequest, opts ...grpc.CallOption) (*PeerGetResponse, error) {
+	out := new(PeerGetResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) PeerLocationSet(ctx context.Context, in *PeerLocationSetRequest, opts ...grpc.CallOption) (*PeerLocationSetResponse, error) {
 	out := new(PeerLocationSetResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerLocationSet", in, out, opts...)
@@ -249,6 +462,33 @@ func (c *controlClient) PeerLocationSet(ctx context.Context, in *PeerLocationSet
 	return out, nil
 }
 
+func (c *controlClient) PeerLocationAdd(ctx context.Context, in *PeerLocationAddRequest, opts ...grpc.CallOption) (*PeerLocationAddResponse, error) {
+	out := new(PeerLocationAddResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerLocationAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerLocationRemove(ctx context.Context, in *PeerLocationRemoveRequest, opts ...grpc.CallOption) (*PeerLocationRemoveResponse, error) {
+	out := new(PeerLocationRemoveResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerLocationRemove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerLocationList(ctx context.Context, in *PeerLocationListRequest, opts ...grpc.CallOption) (*PeerLocationListResponse, error) {
+	out := new(PeerLocationListResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerLocationList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controlClient) PeerStorageAllow(ctx context.Context, in *PeerStorageAllowRequest, opts ...grpc.CallOption) (*PeerStorageAllowResponse, error) {
 	out := new(PeerStorageAllowResponse)
 	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerStorageAllow", in, out, opts...)
@@ -267,20 +507,91 @@ func (c *controlClient) PeerVolumeAllow(ctx context.Context, in *PeerVolumeAllow
 	return out, nil
 }
 
+func (c *controlClient) PeerStatus(ctx context.Context, in *PeerStatusRequest, opts ...grpc.CallOption) (*PeerStatusResponse, error) {
+	out := new(PeerStatusResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerPair(ctx context.Context, in *PeerPairRequest, opts ...grpc.CallOption) (*PeerPairResponse, error) {
+	out := new(PeerPairResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerPair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerPairJoin(ctx context.Context, in *PeerPairJoinRequest, opts ...grpc.CallOption) (*PeerPairJoinResponse, error) {
+	out := new(PeerPairJoinResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerPairJoin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerPendingList(ctx context.Context, in *PeerPendingListRequest, opts ...grpc.CallOption) (*PeerPendingListResponse, error) {
+	out := new(PeerPendingListResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerPendingList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerApprove(ctx context.Context, in *PeerApproveRequest, opts ...grpc.CallOption) (*PeerApproveResponse, error) {
+	out := new(PeerApproveResponse)
+	err := c.cc.Invoke(ctx, "/bazil.control.Control/PeerApprove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControlServer is the server API for Control service.
 type ControlServer interface {
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	PublicKeyGet(context.Context, *PublicKeyGetRequest) (*PublicKeyGetResponse, error)
+	IdentityExport(context.Context, *IdentityExportRequest) (*IdentityExportResponse, error)
 	VolumeCreate(context.Context, *VolumeCreateRequest) (*VolumeCreateResponse, error)
+	VolumeList(context.Context, *VolumeListRequest) (*VolumeListResponse, error)
+	VolumeGet(context.Context, *VolumeGetRequest) (*VolumeGetResponse, error)
+	VolumeDelete(context.Context, *VolumeDeleteRequest) (*VolumeDeleteResponse, error)
+	VolumeRename(context.Context, *VolumeRenameRequest) (*VolumeRenameResponse, error)
 	VolumeConnect(context.Context, *VolumeConnectRequest) (*VolumeConnectResponse, error)
+	VolumeInvite(context.Context, *VolumeInviteRequest) (*VolumeInviteResponse, error)
+	VolumeJoin(context.Context, *VolumeJoinRequest) (*VolumeJoinResponse, error)
 	VolumeMount(context.Context, *VolumeMountRequest) (*VolumeMountResponse, error)
+	VolumeUnmount(context.Context, *VolumeUnmountRequest) (*VolumeUnmountResponse, error)
+	MountList(context.Context, *MountListRequest) (*MountListResponse, error)
 	VolumeStorageAdd(context.Context, *VolumeStorageAddRequest) (*VolumeStorageAddResponse, error)
 	VolumeSync(context.Context, *VolumeSyncRequest) (*VolumeSyncResponse, error)
+	StorageScrub(context.Context, *StorageScrubRequest) (*StorageScrubResponse, error)
 	SharingKeyAdd(context.Context, *SharingKeyAddRequest) (*SharingKeyAddResponse, error)
+	SharingKeyList(context.Context, *SharingKeyListRequest) (*SharingKeyListResponse, error)
+	SharingKeyRotate(context.Context, *SharingKeyRotateRequest) (*SharingKeyRotateResponse, error)
+	SharingKeyRotation(context.Context, *SharingKeyRotationRequest) (*SharingKeyRotationResponse, error)
+	SharingKeyExport(context.Context, *SharingKeyExportRequest) (*SharingKeyExportResponse, error)
+	SharingKeyImport(context.Context, *SharingKeyImportRequest) (*SharingKeyImportResponse, error)
 	PeerAdd(context.Context, *PeerAddRequest) (*PeerAddResponse, error)
+	PeerRemove(context.Context, *PeerRemoveRequest) (*PeerRemoveResponse, error)
+	PeerList(context.Context, *PeerListRequest) (*PeerListResponse, error)
+	PeerGet(context.Context, *PeerGetRequest) (*PeerGetResponse, error)
 	PeerLocationSet(context.Context, *PeerLocationSetRequest) (*PeerLocationSetResponse, error)
+	PeerLocationAdd(context.Context, *PeerLocationAddRequest) (*PeerLocationAddResponse, error)
+	PeerLocationRemove(context.Context, *PeerLocationRemoveRequest) (*PeerLocationRemoveResponse, error)
+	PeerLocationList(context.Context, *PeerLocationListRequest) (*PeerLocationListResponse, error)
 	PeerStorageAllow(context.Context, *PeerStorageAllowRequest) (*PeerStorageAllowResponse, error)
 	PeerVolumeAllow(context.Context, *PeerVolumeAllowRequest) (*PeerVolumeAllowResponse, error)
+	PeerStatus(context.Context, *PeerStatusRequest) (*PeerStatusResponse, error)
+	PeerPair(context.Context, *PeerPairRequest) (*PeerPairResponse, error)
+	PeerPairJoin(context.Context, *PeerPairJoinRequest) (*PeerPairJoinResponse, error)
+	PeerPendingList(context.Context, *PeerPendingListRequest) (*PeerPendingListResponse, error)
+	PeerApprove(context.Context, *PeerApproveRequest) (*PeerApproveResponse, error)
 }
 
 // UnimplementedControlServer can be embedded to have forward compatible implementations.
@@ -293,36 +604,114 @@ func (*UnimplementedControlServer) Ping(ctx context.Context, req *PingRequest) (
 func (*UnimplementedControlServer) PublicKeyGet(ctx context.Context, req *PublicKeyGetRequest) (*PublicKeyGetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublicKeyGet not implemented")
 }
+func (*UnimplementedControlServer) IdentityExport(ctx context.Context, req *IdentityExportRequest) (*IdentityExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IdentityExport not implemented")
+}
 func (*UnimplementedControlServer) VolumeCreate(ctx context.Context, req *VolumeCreateRequest) (*VolumeCreateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeCreate not implemented")
 }
+func (*UnimplementedControlServer) VolumeList(ctx context.Context, req *VolumeListRequest) (*VolumeListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeList not implemented")
+}
+func (*UnimplementedControlServer) VolumeGet(ctx context.Context, req *VolumeGetRequest) (*VolumeGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeGet not implemented")
+}
+func (*UnimplementedControlServer) VolumeDelete(ctx context.Context, req *VolumeDeleteRequest) (*VolumeDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeDelete not implemented")
+}
+func (*UnimplementedControlServer) VolumeRename(ctx context.Context, req *VolumeRenameRequest) (*VolumeRenameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeRename not implemented")
+}
 func (*UnimplementedControlServer) VolumeConnect(ctx context.Context, req *VolumeConnectRequest) (*VolumeConnectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeConnect not implemented")
 }
+func (*UnimplementedControlServer) VolumeInvite(ctx context.Context, req *VolumeInviteRequest) (*VolumeInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeInvite not implemented")
+}
+func (*UnimplementedControlServer) VolumeJoin(ctx context.Context, req *VolumeJoinRequest) (*VolumeJoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeJoin not implemented")
+}
 func (*UnimplementedControlServer) VolumeMount(ctx context.Context, req *VolumeMountRequest) (*VolumeMountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeMount not implemented")
 }
+func (*UnimplementedControlServer) VolumeUnmount(ctx context.Context, req *VolumeUnmountRequest) (*VolumeUnmountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeUnmount not implemented")
+}
+func (*UnimplementedControlServer) MountList(ctx context.Context, req *MountListRequest) (*MountListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MountList not implemented")
+}
 func (*UnimplementedControlServer) VolumeStorageAdd(ctx context.Context, req *VolumeStorageAddRequest) (*VolumeStorageAddResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeStorageAdd not implemented")
 }
 func (*UnimplementedControlServer) VolumeSync(ctx context.Context, req *VolumeSyncRequest) (*VolumeSyncResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeSync not implemented")
 }
+func (*UnimplementedControlServer) StorageScrub(ctx context.Context, req *StorageScrubRequest) (*StorageScrubResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StorageScrub not implemented")
+}
 func (*UnimplementedControlServer) SharingKeyAdd(ctx context.Context, req *SharingKeyAddRequest) (*SharingKeyAddResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SharingKeyAdd not implemented")
 }
+func (*UnimplementedControlServer) SharingKeyList(ctx context.Context, req *SharingKeyListRequest) (*SharingKeyListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharingKeyList not implemented")
+}
+func (*UnimplementedControlServer) SharingKeyRotate(ctx context.Context, req *SharingKeyRotateRequest) (*SharingKeyRotateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharingKeyRotate not implemented")
+}
+func (*UnimplementedControlServer) SharingKeyRotation(ctx context.Context, req *SharingKeyRotationRequest) (*SharingKeyRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharingKeyRotation not implemented")
+}
+func (*UnimplementedControlServer) SharingKeyExport(ctx context.Context, req *SharingKeyExportRequest) (*SharingKeyExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharingKeyExport not implemented")
+}
+func (*UnimplementedControlServer) SharingKeyImport(ctx context.Context, req *SharingKeyImportRequest) (*SharingKeyImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharingKeyImport not implemented")
+}
 func (*UnimplementedControlServer) PeerAdd(ctx context.Context, req *PeerAddRequest) (*PeerAddResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeerAdd not implemented")
 }
+func (*UnimplementedControlServer) PeerRemove(ctx context.Context, req *PeerRemoveRequest) (*PeerRemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerRemove not implemented")
+}
+func (*UnimplementedControlServer) PeerList(ctx context.Context, req *PeerListRequest) (*PeerListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerList not implemented")
+}
+func (*UnimplementedControlServer) PeerGet(ctx context.Context, req *PeerGetRequest) (*PeerGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerGet not implemented")
+}
 func (*UnimplementedControlServer) PeerLocationSet(ctx context.Context, req *PeerLocationSetRequest) (*PeerLocationSetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeerLocationSet not implemented")
 }
+func (*UnimplementedControlServer) PeerLocationAdd(ctx context.Context, req *PeerLocationAddRequest) (*PeerLocationAddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerLocationAdd not implemented")
+}
+func (*UnimplementedControlServer) PeerLocationRemove(ctx context.Context, req *PeerLocationRemoveRequest) (*PeerLocationRemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerLocationRemove not implemented")
+}
+func (*UnimplementedControlServer) PeerLocationList(ctx context.Context, req *PeerLocationListRequest) (*PeerLocationListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerLocationList not implemented")
+}
 func (*UnimplementedControlServer) PeerStorageAllow(ctx context.Context, req *PeerStorageAllowRequest) (*PeerStorageAllowResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeerStorageAllow not implemented")
 }
 func (*UnimplementedControlServer) PeerVolumeAllow(ctx context.Context, req *PeerVolumeAllowRequest) (*PeerVolumeAllowResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeerVolumeAllow not implemented")
 }
+func (*UnimplementedControlServer) PeerStatus(ctx context.Context, req *PeerStatusRequest) (*PeerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerStatus not implemented")
+}
+func (*UnimplementedControlServer) PeerPair(ctx context.Context, req *PeerPairRequest) (*PeerPairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerPair not implemented")
+}
+func (*UnimplementedControlServer) PeerPairJoin(ctx context.Context, req *PeerPairJoinRequest) (*PeerPairJoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerPairJoin not implemented")
+}
+func (*UnimplementedControlServer) PeerPendingList(ctx context.Context, req *PeerPendingListRequest) (*PeerPendingListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerPendingList not implemented")
+}
+func (*UnimplementedControlServer) PeerApprove(ctx context.Context, req *PeerApproveRequest) (*PeerApproveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerApprove not implemented")
+}
 
 func RegisterControlServer(s *grpc.Server, srv ControlServer) {
 	s.RegisterService(&_Control_serviceDesc, srv)
@@ -364,6 +753,24 @@ func _Control_PublicKeyGet_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Control_IdentityExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).IdentityExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/IdentityExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).IdentityExport(ctx, req.(*IdentityExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Control_VolumeCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VolumeCreateRequest)
 	if err := dec(in); err != nil {
@@ -382,192 +789,678 @@ func _Control_VolumeCreate_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_VolumeConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeConnectRequest)
+func _Control_VolumeList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).VolumeConnect(ctx, in)
+		return srv.(ControlServer).VolumeList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/VolumeConnect",
+		FullMethod: "/bazil.control.Control/VolumeList",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).VolumeConnect(ctx, req.(*VolumeConnectRequest))
+		return srv.(ControlServer).VolumeList(ctx, req.(*VolumeListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_VolumeMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeMountRequest)
+func _Control_VolumeGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeGetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).VolumeMount(ctx, in)
+		return srv.(ControlServer).VolumeGet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/VolumeMount",
+		FullMethod: "/bazil.control.Control/VolumeGet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).VolumeMount(ctx, req.(*VolumeMountRequest))
+		return srv.(ControlServer).VolumeGet(ctx, req.(*VolumeGetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_VolumeStorageAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeStorageAddRequest)
+func _Control_VolumeDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeDeleteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).VolumeStorageAdd(ctx, in)
+		return srv.(ControlServer).VolumeDelete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/VolumeStorageAdd",
+		FullMethod: "/bazil.control.Control/VolumeDelete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).VolumeStorageAdd(ctx, req.(*VolumeStorageAddRequest))
+		return srv.(ControlServer).VolumeDelete(ctx, req.(*VolumeDeleteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_VolumeSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VolumeSyncRequest)
+func _Control_VolumeRename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeRenameRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).VolumeSync(ctx, in)
+		return srv.(ControlServer).VolumeRename(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/VolumeSync",
+		FullMethod: "/bazil.control.Control/VolumeRename",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).VolumeSync(ctx, req.(*VolumeSyncRequest))
+		return srv.(ControlServer).VolumeRename(ctx, req.(*VolumeRenameRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_SharingKeyAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SharingKeyAddRequest)
+func _Control_VolumeConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeConnectRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).SharingKeyAdd(ctx, in)
+		return srv.(ControlServer).VolumeConnect(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/SharingKeyAdd",
+		FullMethod: "/bazil.control.Control/VolumeConnect",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).SharingKeyAdd(ctx, req.(*SharingKeyAddRequest))
+		return srv.(ControlServer).VolumeConnect(ctx, req.(*VolumeConnectRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_PeerAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PeerAddRequest)
+func _Control_VolumeInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeInviteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).PeerAdd(ctx, in)
+		return srv.(ControlServer).VolumeInvite(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/PeerAdd",
+		FullMethod: "/bazil.control.Control/VolumeInvite",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).PeerAdd(ctx, req.(*PeerAddRequest))
+		return srv.(ControlServer).VolumeInvite(ctx, req.(*VolumeInviteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_PeerLocationSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PeerLocationSetRequest)
+func _Control_VolumeJoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeJoinRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).PeerLocationSet(ctx, in)
+		return srv.(ControlServer).VolumeJoin(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/PeerLocationSet",
+		FullMethod: "/bazil.control.Control/VolumeJoin",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).PeerLocationSet(ctx, req.(*PeerLocationSetRequest))
+		return srv.(ControlServer).VolumeJoin(ctx, req.(*VolumeJoinRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_PeerStorageAllow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PeerStorageAllowRequest)
+func _Control_VolumeMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeMountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).PeerStorageAllow(ctx, in)
+		return srv.(ControlServer).VolumeMount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/PeerStorageAllow",
+		FullMethod: "/bazil.control.Control/VolumeMount",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).PeerStorageAllow(ctx, req.(*PeerStorageAllowRequest))
+		return srv.(ControlServer).VolumeMount(ctx, req.(*VolumeMountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Control_PeerVolumeAllow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PeerVolumeAllowRequest)
+func _Control_VolumeUnmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeUnmountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ControlServer).PeerVolumeAllow(ctx, in)
+		return srv.(ControlServer).VolumeUnmount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bazil.control.Control/PeerVolumeAllow",
+		FullMethod: "/bazil.control.Control/VolumeUnmount",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ControlServer).PeerVolumeAllow(ctx, req.(*PeerVolumeAllowRequest))
+		return srv.(ControlServer).VolumeUnmount(ctx, req.(*VolumeUnmountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Control_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "bazil.control.Control",
-	HandlerType: (*ControlServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Ping",
+func _Control_MountList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MountListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).MountList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/MountList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).MountList(ctx, req.(*MountListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_VolumeStorageAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeStorageAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).VolumeStorageAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/VolumeStorageAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).VolumeStorageAdd(ctx, req.(*VolumeStorageAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_VolumeSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeSyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).VolumeSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/VolumeSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).VolumeSync(ctx, req.(*VolumeSyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_StorageScrub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageScrubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).StorageScrub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/StorageScrub",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).StorageScrub(ctx, req.(*StorageScrubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SharingKeyAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharingKeyAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SharingKeyAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/SharingKeyAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SharingKeyAdd(ctx, req.(*SharingKeyAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SharingKeyList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharingKeyListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SharingKeyList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/SharingKeyList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SharingKeyList(ctx, req.(*SharingKeyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SharingKeyRotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharingKeyRotateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SharingKeyRotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/SharingKeyRotate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SharingKeyRotate(ctx, req.(*SharingKeyRotateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SharingKeyRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharingKeyRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SharingKeyRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/SharingKeyRotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SharingKeyRotation(ctx, req.(*SharingKeyRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SharingKeyExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharingKeyExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SharingKeyExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/SharingKeyExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SharingKeyExport(ctx, req.(*SharingKeyExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SharingKeyImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharingKeyImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SharingKeyImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/SharingKeyImport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SharingKeyImport(ctx, req.(*SharingKeyImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerAdd(ctx, req.(*PeerAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerRemove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerRemove(ctx, req.(*PeerRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerList(ctx, req.(*PeerListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerGet(ctx, req.(*PeerGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerLocationSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerLocationSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerLocationSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerLocationSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerLocationSet(ctx, req.(*PeerLocationSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerLocationAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerLocationAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerLocationAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerLocationAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerLocationAdd(ctx, req.(*PeerLocationAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerLocationRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerLocationRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerLocationRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerLocationRemove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerLocationRemove(ctx, req.(*PeerLocationRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerLocationList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerLocationListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerLocationList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerLocationList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerLocationList(ctx, req.(*PeerLocationListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerStorageAllow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerStorageAllowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerStorageAllow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerStorageAllow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerStorageAllow(ctx, req.(*PeerStorageAllowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerVolumeAllow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerVolumeAllowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerVolumeAllow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerVolumeAllow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerVolumeAllow(ctx, req.(*PeerVolumeAllowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerStatus(ctx, req.(*PeerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerPair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerPair(ctx, req.(*PeerPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerPairJoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerPairJoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerPairJoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerPairJoin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerPairJoin(ctx, req.(*PeerPairJoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerPendingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerPendingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerPendingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerPendingList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerPendingList(ctx, req.(*PeerPendingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerApprove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerApproveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerApprove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bazil.control.Control/PeerApprove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerApprove(ctx, req.(*PeerApproveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Control_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "bazil.control.Control",
+	HandlerType: (*ControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
 			Handler:    _Control_Ping_Handler,
 		},
 		{
 			MethodName: "PublicKeyGet",
 			Handler:    _Control_PublicKeyGet_Handler,
 		},
+		{
+			MethodName: "IdentityExport",
+			Handler:    _Control_IdentityExport_Handler,
+		},
 		{
 			MethodName: "VolumeCreate",
 			Handler:    _Control_VolumeCreate_Handler,
 		},
+		{
+			MethodName: "VolumeList",
+			Handler:    _Control_VolumeList_Handler,
+		},
+		{
+			MethodName: "VolumeGet",
+			Handler:    _Control_VolumeGet_Handler,
+		},
+		{
+			MethodName: "VolumeDelete",
+			Handler:    _Control_VolumeDelete_Handler,
+		},
+		{
+			MethodName: "VolumeRename",
+			Handler:    _Control_VolumeRename_Handler,
+		},
 		{
 			MethodName: "VolumeConnect",
 			Handler:    _Control_VolumeConnect_Handler,
 		},
+		{
+			MethodName: "VolumeInvite",
+			Handler:    _Control_VolumeInvite_Handler,
+		},
+		{
+			MethodName: "VolumeJoin",
+			Handler:    _Control_VolumeJoin_Handler,
+		},
 		{
 			MethodName: "VolumeMount",
 			Handler:    _Control_VolumeMount_Handler,
 		},
+		{
+			MethodName: "VolumeUnmount",
+			Handler:    _Control_VolumeUnmount_Handler,
+		},
+		{
+			MethodName: "MountList",
+			Handler:    _Control_MountList_Handler,
+		},
 		{
 			MethodName: "VolumeStorageAdd",
 			Handler:    _Control_VolumeStorageAdd_Handler,
@@ -576,18 +1469,66 @@ var _Control_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeSync",
 			Handler:    _Control_VolumeSync_Handler,
 		},
+		{
+			MethodName: "StorageScrub",
+			Handler:    _Control_StorageScrub_Handler,
+		},
 		{
 			MethodName: "SharingKeyAdd",
 			Handler:    _Control_SharingKeyAdd_Handler,
 		},
+		{
+			MethodName: "SharingKeyList",
+			Handler:    _Control_SharingKeyList_Handler,
+		},
+		{
+			MethodName: "SharingKeyRotate",
+			Handler:    _Control_SharingKeyRotate_Handler,
+		},
+		{
+			MethodName: "SharingKeyRotation",
+			Handler:    _Control_SharingKeyRotation_Handler,
+		},
+		{
+			MethodName: "SharingKeyExport",
+			Handler:    _Control_SharingKeyExport_Handler,
+		},
+		{
+			MethodName: "SharingKeyImport",
+			Handler:    _Control_SharingKeyImport_Handler,
+		},
 		{
 			MethodName: "PeerAdd",
 			Handler:    _Control_PeerAdd_Handler,
 		},
+		{
+			MethodName: "PeerRemove",
+			Handler:    _Control_PeerRemove_Handler,
+		},
+		{
+			MethodName: "PeerList",
+			Handler:    _Control_PeerList_Handler,
+		},
+		{
+			MethodName: "PeerGet",
+			Handler:    _Control_PeerGet_Handler,
+		},
 		{
 			MethodName: "PeerLocationSet",
 			Handler:    _Control_PeerLocationSet_Handler,
 		},
+		{
+			MethodName: "PeerLocationAdd",
+			Handler:    _Control_PeerLocationAdd_Handler,
+		},
+		{
+			MethodName: "PeerLocationRemove",
+			Handler:    _Control_PeerLocationRemove_Handler,
+		},
+		{
+			MethodName: "PeerLocationList",
+			Handler:    _Control_PeerLocationList_Handler,
+		},
 		{
 			MethodName: "PeerStorageAllow",
 			Handler:    _Control_PeerStorageAllow_Handler,
@@ -596,6 +1537,26 @@ var _Control_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PeerVolumeAllow",
 			Handler:    _Control_PeerVolumeAllow_Handler,
 		},
+		{
+			MethodName: "PeerStatus",
+			Handler:    _Control_PeerStatus_Handler,
+		},
+		{
+			MethodName: "PeerPair",
+			Handler:    _Control_PeerPair_Handler,
+		},
+		{
+			MethodName: "PeerPairJoin",
+			Handler:    _Control_PeerPairJoin_Handler,
+		},
+		{
+			MethodName: "PeerPendingList",
+			Handler:    _Control_PeerPendingList_Handler,
+		},
+		{
+			MethodName: "PeerApprove",
+			Handler:    _Control_PeerApprove_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bazil.org/bazil/server/control/wire/control.proto",
@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bazil.org/bazil/server/control/wire/identity.proto
+
+package wire
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type IdentityExportRequest struct {
+	// Passphrase to encrypt the exported identity with.
+	Passphrase           []byte   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IdentityExportRequest) Reset()         { *m = IdentityExportRequest{} }
+func (m *IdentityExportRequest) String() string { return proto.CompactTextString(m) }
+func (*IdentityExportRequest) ProtoMessage()    {}
+func (*IdentityExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4118ae2c4272e70, []int{0}
+}
+
+func (m *IdentityExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdentityExportRequest.Unmarshal(m, b)
+}
+func (m *IdentityExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdentityExportRequest.Marshal(b, m, deterministic)
+}
+func (m *IdentityExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentityExportRequest.Merge(m, src)
+}
+func (m *IdentityExportRequest) XXX_Size() int {
+	return xxx_messageInfo_IdentityExportRequest.Size(m)
+}
+func (m *IdentityExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentityExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdentityExportRequest proto.InternalMessageInfo
+
+func (m *IdentityExportRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+type IdentityExportResponse struct {
+	// PEM-armored, passphrase-encrypted private key.
+	Armored              []byte   `protobuf:"bytes,1,opt,name=armored,proto3" json:"armored,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IdentityExportResponse) Reset()         { *m = IdentityExportResponse{} }
+func (m *IdentityExportResponse) String() string { return proto.CompactTextString(m) }
+func (*IdentityExportResponse) ProtoMessage()    {}
+func (*IdentityExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4118ae2c4272e70, []int{1}
+}
+
+func (m *IdentityExportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdentityExportResponse.Unmarshal(m, b)
+}
+func (m *IdentityExportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdentityExportResponse.Marshal(b, m, deterministic)
+}
+func (m *IdentityExportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentityExportResponse.Merge(m, src)
+}
+func (m *IdentityExportResponse) XXX_Size() int {
+	return xxx_messageInfo_IdentityExportResponse.Size(m)
+}
+func (m *IdentityExportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentityExportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdentityExportResponse proto.InternalMessageInfo
+
+func (m *IdentityExportResponse) GetArmored() []byte {
+	if m != nil {
+		return m.Armored
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*IdentityExportRequest)(nil), "bazil.control.IdentityExportRequest")
+	proto.RegisterType((*IdentityExportResponse)(nil), "bazil.control.IdentityExportResponse")
+}
+
+func init() {
+	proto.RegisterFile("bazil.org/bazil/server/control/wire/identity.proto", fileDescriptor_a4118ae2c4272e70)
+}
+
+var fileDescriptor_a4118ae2c4272e70 = []byte{
+	// 157 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4a, 0x4a, 0xac, 0xca,
+	0xcc, 0xd1, 0xcb, 0x2f, 0x4a, 0xd7, 0x07, 0xb3, 0xf4, 0x8b, 0x53, 0x8b, 0xca, 0x52, 0x8b, 0xf4,
+	0x93, 0xf3, 0xf3, 0x4a, 0x8a, 0xf2, 0x73, 0xf4, 0xcb, 0x33, 0x8b, 0x52, 0xf5, 0x33, 0x53, 0x52,
+	0xf3, 0x4a, 0x32, 0x4b, 0x2a, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x78, 0x21, 0x7a, 0xa0,
+	0x4a, 0x94, 0xcc, 0xb9, 0x44, 0x3d, 0xa1, 0x0a, 0x5c, 0x2b, 0x0a, 0xf2, 0x8b, 0x4a, 0x82, 0x52,
+	0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x84, 0xe4, 0xb8, 0xb8, 0x0a, 0x12, 0x8b, 0x8b, 0x0b, 0x32, 0x8a,
+	0x12, 0x8b, 0x53, 0x25, 0x18, 0x15, 0x18, 0x35, 0x78, 0x82, 0x90, 0x44, 0x94, 0x8c, 0xb8, 0xc4,
+	0xd0, 0x35, 0x16, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x0a, 0x49, 0x70, 0xb1, 0x27, 0x16, 0xe5, 0xe6,
+	0x17, 0xa5, 0xa6, 0x40, 0xb5, 0xc1, 0xb8, 0x4e, 0x6c, 0x51, 0x2c, 0x20, 0x27, 0x25, 0xb1, 0x81,
+	0x9d, 0x62, 0x0c, 0x18, 0x00, 0x5b, 0x46, 0x52, 0x8f, 0xc0, 0x00, 0x00, 0x00,
+}
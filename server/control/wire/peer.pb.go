@@ -91,6 +91,77 @@ func (m *PeerAddResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PeerAddResponse proto.InternalMessageInfo
 
+type PeerRemoveRequest struct {
+	// Must be exactly 32 bytes long.
+	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerRemoveRequest) Reset()         { *m = PeerRemoveRequest{} }
+func (m *PeerRemoveRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerRemoveRequest) ProtoMessage()    {}
+func (*PeerRemoveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{2}
+}
+
+func (m *PeerRemoveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerRemoveRequest.Unmarshal(m, b)
+}
+func (m *PeerRemoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerRemoveRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerRemoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerRemoveRequest.Merge(m, src)
+}
+func (m *PeerRemoveRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerRemoveRequest.Size(m)
+}
+func (m *PeerRemoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerRemoveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerRemoveRequest proto.InternalMessageInfo
+
+func (m *PeerRemoveRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+type PeerRemoveResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerRemoveResponse) Reset()         { *m = PeerRemoveResponse{} }
+func (m *PeerRemoveResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerRemoveResponse) ProtoMessage()    {}
+func (*PeerRemoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{3}
+}
+
+func (m *PeerRemoveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerRemoveResponse.Unmarshal(m, b)
+}
+func (m *PeerRemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerRemoveResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerRemoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerRemoveResponse.Merge(m, src)
+}
+func (m *PeerRemoveResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerRemoveResponse.Size(m)
+}
+func (m *PeerRemoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerRemoveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerRemoveResponse proto.InternalMessageInfo
+
 type PeerLocationSetRequest struct {
 	// Must be exactly 32 bytes long.
 	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
@@ -104,7 +175,7 @@ func (m *PeerLocationSetRequest) Reset()         { *m = PeerLocationSetRequest{}
 func (m *PeerLocationSetRequest) String() string { return proto.CompactTextString(m) }
 func (*PeerLocationSetRequest) ProtoMessage()    {}
 func (*PeerLocationSetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7a982a125f60130, []int{2}
+	return fileDescriptor_a7a982a125f60130, []int{4}
 }
 
 func (m *PeerLocationSetRequest) XXX_Unmarshal(b []byte) error {
@@ -149,7 +220,7 @@ func (m *PeerLocationSetResponse) Reset()         { *m = PeerLocationSetResponse
 func (m *PeerLocationSetResponse) String() string { return proto.CompactTextString(m) }
 func (*PeerLocationSetResponse) ProtoMessage()    {}
 func (*PeerLocationSetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7a982a125f60130, []int{3}
+	return fileDescriptor_a7a982a125f60130, []int{5}
 }
 
 func (m *PeerLocationSetResponse) XXX_Unmarshal(b []byte) error {
@@ -170,6 +241,309 @@ func (m *PeerLocationSetResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PeerLocationSetResponse proto.InternalMessageInfo
 
+type PeerLocationAddRequest struct {
+	// Must be exactly 32 bytes long.
+	Pub    []byte `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	Netloc string `protobuf:"bytes,2,opt,name=netloc,proto3" json:"netloc,omitempty"`
+	// Locations with lower priority values are tried first.
+	Priority             uint32   `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerLocationAddRequest) Reset()         { *m = PeerLocationAddRequest{} }
+func (m *PeerLocationAddRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerLocationAddRequest) ProtoMessage()    {}
+func (*PeerLocationAddRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{6}
+}
+
+func (m *PeerLocationAddRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLocationAddRequest.Unmarshal(m, b)
+}
+func (m *PeerLocationAddRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLocationAddRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerLocationAddRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLocationAddRequest.Merge(m, src)
+}
+func (m *PeerLocationAddRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerLocationAddRequest.Size(m)
+}
+func (m *PeerLocationAddRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLocationAddRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLocationAddRequest proto.InternalMessageInfo
+
+func (m *PeerLocationAddRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *PeerLocationAddRequest) GetNetloc() string {
+	if m != nil {
+		return m.Netloc
+	}
+	return ""
+}
+
+func (m *PeerLocationAddRequest) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+type PeerLocationAddResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerLocationAddResponse) Reset()         { *m = PeerLocationAddResponse{} }
+func (m *PeerLocationAddResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerLocationAddResponse) ProtoMessage()    {}
+func (*PeerLocationAddResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{7}
+}
+
+func (m *PeerLocationAddResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLocationAddResponse.Unmarshal(m, b)
+}
+func (m *PeerLocationAddResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLocationAddResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerLocationAddResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLocationAddResponse.Merge(m, src)
+}
+func (m *PeerLocationAddResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerLocationAddResponse.Size(m)
+}
+func (m *PeerLocationAddResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLocationAddResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLocationAddResponse proto.InternalMessageInfo
+
+type PeerLocationRemoveRequest struct {
+	// Must be exactly 32 bytes long.
+	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	Netloc               string   `protobuf:"bytes,2,opt,name=netloc,proto3" json:"netloc,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerLocationRemoveRequest) Reset()         { *m = PeerLocationRemoveRequest{} }
+func (m *PeerLocationRemoveRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerLocationRemoveRequest) ProtoMessage()    {}
+func (*PeerLocationRemoveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{8}
+}
+
+func (m *PeerLocationRemoveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLocationRemoveRequest.Unmarshal(m, b)
+}
+func (m *PeerLocationRemoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLocationRemoveRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerLocationRemoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLocationRemoveRequest.Merge(m, src)
+}
+func (m *PeerLocationRemoveRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerLocationRemoveRequest.Size(m)
+}
+func (m *PeerLocationRemoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLocationRemoveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLocationRemoveRequest proto.InternalMessageInfo
+
+func (m *PeerLocationRemoveRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *PeerLocationRemoveRequest) GetNetloc() string {
+	if m != nil {
+		return m.Netloc
+	}
+	return ""
+}
+
+type PeerLocationRemoveResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerLocationRemoveResponse) Reset()         { *m = PeerLocationRemoveResponse{} }
+func (m *PeerLocationRemoveResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerLocationRemoveResponse) ProtoMessage()    {}
+func (*PeerLocationRemoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{9}
+}
+
+func (m *PeerLocationRemoveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLocationRemoveResponse.Unmarshal(m, b)
+}
+func (m *PeerLocationRemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLocationRemoveResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerLocationRemoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLocationRemoveResponse.Merge(m, src)
+}
+func (m *PeerLocationRemoveResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerLocationRemoveResponse.Size(m)
+}
+func (m *PeerLocationRemoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLocationRemoveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLocationRemoveResponse proto.InternalMessageInfo
+
+type PeerLocationInfo struct {
+	Netloc   string `protobuf:"bytes,1,opt,name=netloc,proto3" json:"netloc,omitempty"`
+	Priority uint32 `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Whether this is the location that last worked.
+	Last                 bool     `protobuf:"varint,3,opt,name=last,proto3" json:"last,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerLocationInfo) Reset()         { *m = PeerLocationInfo{} }
+func (m *PeerLocationInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerLocationInfo) ProtoMessage()    {}
+func (*PeerLocationInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{10}
+}
+
+func (m *PeerLocationInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLocationInfo.Unmarshal(m, b)
+}
+func (m *PeerLocationInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLocationInfo.Marshal(b, m, deterministic)
+}
+func (m *PeerLocationInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLocationInfo.Merge(m, src)
+}
+func (m *PeerLocationInfo) XXX_Size() int {
+	return xxx_messageInfo_PeerLocationInfo.Size(m)
+}
+func (m *PeerLocationInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLocationInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLocationInfo proto.InternalMessageInfo
+
+func (m *PeerLocationInfo) GetNetloc() string {
+	if m != nil {
+		return m.Netloc
+	}
+	return ""
+}
+
+func (m *PeerLocationInfo) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *PeerLocationInfo) GetLast() bool {
+	if m != nil {
+		return m.Last
+	}
+	return false
+}
+
+type PeerLocationListRequest struct {
+	// Must be exactly 32 bytes long.
+	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerLocationListRequest) Reset()         { *m = PeerLocationListRequest{} }
+func (m *PeerLocationListRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerLocationListRequest) ProtoMessage()    {}
+func (*PeerLocationListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{11}
+}
+
+func (m *PeerLocationListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLocationListRequest.Unmarshal(m, b)
+}
+func (m *PeerLocationListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLocationListRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerLocationListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLocationListRequest.Merge(m, src)
+}
+func (m *PeerLocationListRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerLocationListRequest.Size(m)
+}
+func (m *PeerLocationListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLocationListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLocationListRequest proto.InternalMessageInfo
+
+func (m *PeerLocationListRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+type PeerLocationListResponse struct {
+	// In the order they will be tried.
+	Locations            []*PeerLocationInfo `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PeerLocationListResponse) Reset()         { *m = PeerLocationListResponse{} }
+func (m *PeerLocationListResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerLocationListResponse) ProtoMessage()    {}
+func (*PeerLocationListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{12}
+}
+
+func (m *PeerLocationListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerLocationListResponse.Unmarshal(m, b)
+}
+func (m *PeerLocationListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerLocationListResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerLocationListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerLocationListResponse.Merge(m, src)
+}
+func (m *PeerLocationListResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerLocationListResponse.Size(m)
+}
+func (m *PeerLocationListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerLocationListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerLocationListResponse proto.InternalMessageInfo
+
+func (m *PeerLocationListResponse) GetLocations() []*PeerLocationInfo {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
 type PeerStorageAllowRequest struct {
 	// Must be exactly 32 bytes long.
 	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
@@ -183,7 +557,7 @@ func (m *PeerStorageAllowRequest) Reset()         { *m = PeerStorageAllowRequest
 func (m *PeerStorageAllowRequest) String() string { return proto.CompactTextString(m) }
 func (*PeerStorageAllowRequest) ProtoMessage()    {}
 func (*PeerStorageAllowRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7a982a125f60130, []int{4}
+	return fileDescriptor_a7a982a125f60130, []int{13}
 }
 
 func (m *PeerStorageAllowRequest) XXX_Unmarshal(b []byte) error {
@@ -228,7 +602,7 @@ func (m *PeerStorageAllowResponse) Reset()         { *m = PeerStorageAllowRespon
 func (m *PeerStorageAllowResponse) String() string { return proto.CompactTextString(m) }
 func (*PeerStorageAllowResponse) ProtoMessage()    {}
 func (*PeerStorageAllowResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7a982a125f60130, []int{5}
+	return fileDescriptor_a7a982a125f60130, []int{14}
 }
 
 func (m *PeerStorageAllowResponse) XXX_Unmarshal(b []byte) error {
@@ -251,8 +625,13 @@ var xxx_messageInfo_PeerStorageAllowResponse proto.InternalMessageInfo
 
 type PeerVolumeAllowRequest struct {
 	// Must be exactly 32 bytes long.
-	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
-	VolumeName           string   `protobuf:"bytes,2,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	Pub        []byte `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	VolumeName string `protobuf:"bytes,2,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Directories the peer may sync, relative to the volume root. If
+	// empty, the whole volume.
+	Paths []string `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`
+	// Never merge changes from the peer.
+	ReadOnly             bool     `protobuf:"varint,4,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -262,7 +641,7 @@ func (m *PeerVolumeAllowRequest) Reset()         { *m = PeerVolumeAllowRequest{}
 func (m *PeerVolumeAllowRequest) String() string { return proto.CompactTextString(m) }
 func (*PeerVolumeAllowRequest) ProtoMessage()    {}
 func (*PeerVolumeAllowRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7a982a125f60130, []int{6}
+	return fileDescriptor_a7a982a125f60130, []int{15}
 }
 
 func (m *PeerVolumeAllowRequest) XXX_Unmarshal(b []byte) error {
@@ -297,6 +676,20 @@ func (m *PeerVolumeAllowRequest) GetVolumeName() string {
 	return ""
 }
 
+func (m *PeerVolumeAllowRequest) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *PeerVolumeAllowRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
 type PeerVolumeAllowResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -307,7 +700,7 @@ func (m *PeerVolumeAllowResponse) Reset()         { *m = PeerVolumeAllowResponse
 func (m *PeerVolumeAllowResponse) String() string { return proto.CompactTextString(m) }
 func (*PeerVolumeAllowResponse) ProtoMessage()    {}
 func (*PeerVolumeAllowResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7a982a125f60130, []int{7}
+	return fileDescriptor_a7a982a125f60130, []int{16}
 }
 
 func (m *PeerVolumeAllowResponse) XXX_Unmarshal(b []byte) error {
@@ -328,15 +721,871 @@ func (m *PeerVolumeAllowResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PeerVolumeAllowResponse proto.InternalMessageInfo
 
+type PeerVolumeInfo struct {
+	// Exactly 64 bytes long.
+	VolumeID []byte `protobuf:"bytes,1,opt,name=volumeID,proto3" json:"volumeID,omitempty"`
+	// Empty if the volume is not known locally.
+	VolumeName string `protobuf:"bytes,2,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Empty if the peer may use the whole volume.
+	Paths                []string `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`
+	ReadOnly             bool     `protobuf:"varint,4,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerVolumeInfo) Reset()         { *m = PeerVolumeInfo{} }
+func (m *PeerVolumeInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerVolumeInfo) ProtoMessage()    {}
+func (*PeerVolumeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{17}
+}
+
+func (m *PeerVolumeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerVolumeInfo.Unmarshal(m, b)
+}
+func (m *PeerVolumeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerVolumeInfo.Marshal(b, m, deterministic)
+}
+func (m *PeerVolumeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerVolumeInfo.Merge(m, src)
+}
+func (m *PeerVolumeInfo) XXX_Size() int {
+	return xxx_messageInfo_PeerVolumeInfo.Size(m)
+}
+func (m *PeerVolumeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerVolumeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerVolumeInfo proto.InternalMessageInfo
+
+func (m *PeerVolumeInfo) GetVolumeID() []byte {
+	if m != nil {
+		return m.VolumeID
+	}
+	return nil
+}
+
+func (m *PeerVolumeInfo) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *PeerVolumeInfo) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *PeerVolumeInfo) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type PeerInfo struct {
+	// Exactly 32 bytes long.
+	Pub                  []byte            `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	Id                   uint32            `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Locations            []string          `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"`
+	Storage              []string          `protobuf:"bytes,4,rep,name=storage,proto3" json:"storage,omitempty"`
+	Volumes              []*PeerVolumeInfo `protobuf:"bytes,5,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
+func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerInfo) ProtoMessage()    {}
+func (*PeerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{18}
+}
+
+func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerInfo.Unmarshal(m, b)
+}
+func (m *PeerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerInfo.Marshal(b, m, deterministic)
+}
+func (m *PeerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerInfo.Merge(m, src)
+}
+func (m *PeerInfo) XXX_Size() int {
+	return xxx_messageInfo_PeerInfo.Size(m)
+}
+func (m *PeerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerInfo proto.InternalMessageInfo
+
+func (m *PeerInfo) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *PeerInfo) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *PeerInfo) GetLocations() []string {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+func (m *PeerInfo) GetStorage() []string {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+func (m *PeerInfo) GetVolumes() []*PeerVolumeInfo {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+type PeerListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerListRequest) Reset()         { *m = PeerListRequest{} }
+func (m *PeerListRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerListRequest) ProtoMessage()    {}
+func (*PeerListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{19}
+}
+
+func (m *PeerListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerListRequest.Unmarshal(m, b)
+}
+func (m *PeerListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerListRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerListRequest.Merge(m, src)
+}
+func (m *PeerListRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerListRequest.Size(m)
+}
+func (m *PeerListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerListRequest proto.InternalMessageInfo
+
+type PeerListResponse struct {
+	Peers                []*PeerInfo `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PeerListResponse) Reset()         { *m = PeerListResponse{} }
+func (m *PeerListResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerListResponse) ProtoMessage()    {}
+func (*PeerListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{20}
+}
+
+func (m *PeerListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerListResponse.Unmarshal(m, b)
+}
+func (m *PeerListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerListResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerListResponse.Merge(m, src)
+}
+func (m *PeerListResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerListResponse.Size(m)
+}
+func (m *PeerListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerListResponse proto.InternalMessageInfo
+
+func (m *PeerListResponse) GetPeers() []*PeerInfo {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type PeerGetRequest struct {
+	// Must be exactly 32 bytes long.
+	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerGetRequest) Reset()         { *m = PeerGetRequest{} }
+func (m *PeerGetRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerGetRequest) ProtoMessage()    {}
+func (*PeerGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{21}
+}
+
+func (m *PeerGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerGetRequest.Unmarshal(m, b)
+}
+func (m *PeerGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerGetRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerGetRequest.Merge(m, src)
+}
+func (m *PeerGetRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerGetRequest.Size(m)
+}
+func (m *PeerGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerGetRequest proto.InternalMessageInfo
+
+func (m *PeerGetRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+type PeerGetResponse struct {
+	Peer                 *PeerInfo `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PeerGetResponse) Reset()         { *m = PeerGetResponse{} }
+func (m *PeerGetResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerGetResponse) ProtoMessage()    {}
+func (*PeerGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{22}
+}
+
+func (m *PeerGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerGetResponse.Unmarshal(m, b)
+}
+func (m *PeerGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerGetResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerGetResponse.Merge(m, src)
+}
+func (m *PeerGetResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerGetResponse.Size(m)
+}
+func (m *PeerGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerGetResponse proto.InternalMessageInfo
+
+func (m *PeerGetResponse) GetPeer() *PeerInfo {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+type PeerStatusRequest struct {
+	// If empty, all peers. Otherwise must be exactly 32 bytes long.
+	Pub []byte `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	// Ping the peers first, instead of only reporting past calls.
+	Ping                 bool     `protobuf:"varint,2,opt,name=ping,proto3" json:"ping,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerStatusRequest) Reset()         { *m = PeerStatusRequest{} }
+func (m *PeerStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerStatusRequest) ProtoMessage()    {}
+func (*PeerStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{23}
+}
+
+func (m *PeerStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerStatusRequest.Unmarshal(m, b)
+}
+func (m *PeerStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStatusRequest.Merge(m, src)
+}
+func (m *PeerStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerStatusRequest.Size(m)
+}
+func (m *PeerStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerStatusRequest proto.InternalMessageInfo
+
+func (m *PeerStatusRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *PeerStatusRequest) GetPing() bool {
+	if m != nil {
+		return m.Ping
+	}
+	return false
+}
+
+type PeerStatus struct {
+	// Exactly 32 bytes long.
+	Pub []byte `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	// Whether there is an open connection to the peer.
+	Connected bool `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	// State of the connection, if connected.
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// Number of users of the connection.
+	InUse uint32 `protobuf:"varint,4,opt,name=inUse,proto3" json:"inUse,omitempty"`
+	// Seconds since Unix epoch when the peer last answered, or 0 if
+	// never.
+	LastSeen int64 `protobuf:"varint,5,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	// Why the last call failed, or empty if it succeeded.
+	LastError string `protobuf:"bytes,6,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	// Round trip time of the last successful ping, in microseconds.
+	LatencyMicros        int64    `protobuf:"varint,7,opt,name=latencyMicros,proto3" json:"latencyMicros,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerStatus) Reset()         { *m = PeerStatus{} }
+func (m *PeerStatus) String() string { return proto.CompactTextString(m) }
+func (*PeerStatus) ProtoMessage()    {}
+func (*PeerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{24}
+}
+
+func (m *PeerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerStatus.Unmarshal(m, b)
+}
+func (m *PeerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerStatus.Marshal(b, m, deterministic)
+}
+func (m *PeerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStatus.Merge(m, src)
+}
+func (m *PeerStatus) XXX_Size() int {
+	return xxx_messageInfo_PeerStatus.Size(m)
+}
+func (m *PeerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerStatus proto.InternalMessageInfo
+
+func (m *PeerStatus) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *PeerStatus) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *PeerStatus) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *PeerStatus) GetInUse() uint32 {
+	if m != nil {
+		return m.InUse
+	}
+	return 0
+}
+
+func (m *PeerStatus) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *PeerStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *PeerStatus) GetLatencyMicros() int64 {
+	if m != nil {
+		return m.LatencyMicros
+	}
+	return 0
+}
+
+type PeerStatusResponse struct {
+	Peers                []*PeerStatus `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PeerStatusResponse) Reset()         { *m = PeerStatusResponse{} }
+func (m *PeerStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerStatusResponse) ProtoMessage()    {}
+func (*PeerStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{25}
+}
+
+func (m *PeerStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerStatusResponse.Unmarshal(m, b)
+}
+func (m *PeerStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStatusResponse.Merge(m, src)
+}
+func (m *PeerStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerStatusResponse.Size(m)
+}
+func (m *PeerStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerStatusResponse proto.InternalMessageInfo
+
+func (m *PeerStatusResponse) GetPeers() []*PeerStatus {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type PeerPairRequest struct {
+	// How long to stay in pairing mode, in seconds.
+	Seconds              uint32   `protobuf:"varint,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerPairRequest) Reset()         { *m = PeerPairRequest{} }
+func (m *PeerPairRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerPairRequest) ProtoMessage()    {}
+func (*PeerPairRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{26}
+}
+
+func (m *PeerPairRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPairRequest.Unmarshal(m, b)
+}
+func (m *PeerPairRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPairRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerPairRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPairRequest.Merge(m, src)
+}
+func (m *PeerPairRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerPairRequest.Size(m)
+}
+func (m *PeerPairRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPairRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPairRequest proto.InternalMessageInfo
+
+func (m *PeerPairRequest) GetSeconds() uint32 {
+	if m != nil {
+		return m.Seconds
+	}
+	return 0
+}
+
+type PeerPairResponse struct {
+	// Code to give to the peers that are to pair.
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerPairResponse) Reset()         { *m = PeerPairResponse{} }
+func (m *PeerPairResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerPairResponse) ProtoMessage()    {}
+func (*PeerPairResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{27}
+}
+
+func (m *PeerPairResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPairResponse.Unmarshal(m, b)
+}
+func (m *PeerPairResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPairResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerPairResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPairResponse.Merge(m, src)
+}
+func (m *PeerPairResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerPairResponse.Size(m)
+}
+func (m *PeerPairResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPairResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPairResponse proto.InternalMessageInfo
+
+func (m *PeerPairResponse) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type PeerPairJoinRequest struct {
+	// Address of the peer in pairing mode.
+	Netloc string `protobuf:"bytes,1,opt,name=netloc,proto3" json:"netloc,omitempty"`
+	// Pairing code given by the operator of that peer.
+	Code                 string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerPairJoinRequest) Reset()         { *m = PeerPairJoinRequest{} }
+func (m *PeerPairJoinRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerPairJoinRequest) ProtoMessage()    {}
+func (*PeerPairJoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{28}
+}
+
+func (m *PeerPairJoinRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPairJoinRequest.Unmarshal(m, b)
+}
+func (m *PeerPairJoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPairJoinRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerPairJoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPairJoinRequest.Merge(m, src)
+}
+func (m *PeerPairJoinRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerPairJoinRequest.Size(m)
+}
+func (m *PeerPairJoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPairJoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPairJoinRequest proto.InternalMessageInfo
+
+func (m *PeerPairJoinRequest) GetNetloc() string {
+	if m != nil {
+		return m.Netloc
+	}
+	return ""
+}
+
+func (m *PeerPairJoinRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type PeerPairJoinResponse struct {
+	// Public key of the peer paired with.
+	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerPairJoinResponse) Reset()         { *m = PeerPairJoinResponse{} }
+func (m *PeerPairJoinResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerPairJoinResponse) ProtoMessage()    {}
+func (*PeerPairJoinResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{29}
+}
+
+func (m *PeerPairJoinResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPairJoinResponse.Unmarshal(m, b)
+}
+func (m *PeerPairJoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPairJoinResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerPairJoinResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPairJoinResponse.Merge(m, src)
+}
+func (m *PeerPairJoinResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerPairJoinResponse.Size(m)
+}
+func (m *PeerPairJoinResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPairJoinResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPairJoinResponse proto.InternalMessageInfo
+
+func (m *PeerPairJoinResponse) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+type PeerPendingListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerPendingListRequest) Reset()         { *m = PeerPendingListRequest{} }
+func (m *PeerPendingListRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerPendingListRequest) ProtoMessage()    {}
+func (*PeerPendingListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{30}
+}
+
+func (m *PeerPendingListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPendingListRequest.Unmarshal(m, b)
+}
+func (m *PeerPendingListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPendingListRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerPendingListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPendingListRequest.Merge(m, src)
+}
+func (m *PeerPendingListRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerPendingListRequest.Size(m)
+}
+func (m *PeerPendingListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPendingListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPendingListRequest proto.InternalMessageInfo
+
+type PeerPending struct {
+	// Exactly 32 bytes long.
+	Pub []byte `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	// Seconds since Unix epoch when the peer paired.
+	Since                int64    `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerPending) Reset()         { *m = PeerPending{} }
+func (m *PeerPending) String() string { return proto.CompactTextString(m) }
+func (*PeerPending) ProtoMessage()    {}
+func (*PeerPending) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{31}
+}
+
+func (m *PeerPending) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPending.Unmarshal(m, b)
+}
+func (m *PeerPending) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPending.Marshal(b, m, deterministic)
+}
+func (m *PeerPending) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPending.Merge(m, src)
+}
+func (m *PeerPending) XXX_Size() int {
+	return xxx_messageInfo_PeerPending.Size(m)
+}
+func (m *PeerPending) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPending.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPending proto.InternalMessageInfo
+
+func (m *PeerPending) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *PeerPending) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+type PeerPendingListResponse struct {
+	Peers                []*PeerPending `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PeerPendingListResponse) Reset()         { *m = PeerPendingListResponse{} }
+func (m *PeerPendingListResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerPendingListResponse) ProtoMessage()    {}
+func (*PeerPendingListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{32}
+}
+
+func (m *PeerPendingListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerPendingListResponse.Unmarshal(m, b)
+}
+func (m *PeerPendingListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerPendingListResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerPendingListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerPendingListResponse.Merge(m, src)
+}
+func (m *PeerPendingListResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerPendingListResponse.Size(m)
+}
+func (m *PeerPendingListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerPendingListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerPendingListResponse proto.InternalMessageInfo
+
+func (m *PeerPendingListResponse) GetPeers() []*PeerPending {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type PeerApproveRequest struct {
+	// Must be exactly 32 bytes long.
+	Pub                  []byte   `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerApproveRequest) Reset()         { *m = PeerApproveRequest{} }
+func (m *PeerApproveRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerApproveRequest) ProtoMessage()    {}
+func (*PeerApproveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{33}
+}
+
+func (m *PeerApproveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerApproveRequest.Unmarshal(m, b)
+}
+func (m *PeerApproveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerApproveRequest.Marshal(b, m, deterministic)
+}
+func (m *PeerApproveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerApproveRequest.Merge(m, src)
+}
+func (m *PeerApproveRequest) XXX_Size() int {
+	return xxx_messageInfo_PeerApproveRequest.Size(m)
+}
+func (m *PeerApproveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerApproveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerApproveRequest proto.InternalMessageInfo
+
+func (m *PeerApproveRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+type PeerApproveResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerApproveResponse) Reset()         { *m = PeerApproveResponse{} }
+func (m *PeerApproveResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerApproveResponse) ProtoMessage()    {}
+func (*PeerApproveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7a982a125f60130, []int{34}
+}
+
+func (m *PeerApproveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerApproveResponse.Unmarshal(m, b)
+}
+func (m *PeerApproveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerApproveResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerApproveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerApproveResponse.Merge(m, src)
+}
+func (m *PeerApproveResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerApproveResponse.Size(m)
+}
+func (m *PeerApproveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerApproveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerApproveResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*PeerAddRequest)(nil), "bazil.control.PeerAddRequest")
 	proto.RegisterType((*PeerAddResponse)(nil), "bazil.control.PeerAddResponse")
+	proto.RegisterType((*PeerRemoveRequest)(nil), "bazil.control.PeerRemoveRequest")
+	proto.RegisterType((*PeerRemoveResponse)(nil), "bazil.control.PeerRemoveResponse")
 	proto.RegisterType((*PeerLocationSetRequest)(nil), "bazil.control.PeerLocationSetRequest")
 	proto.RegisterType((*PeerLocationSetResponse)(nil), "bazil.control.PeerLocationSetResponse")
+	proto.RegisterType((*PeerLocationAddRequest)(nil), "bazil.control.PeerLocationAddRequest")
+	proto.RegisterType((*PeerLocationAddResponse)(nil), "bazil.control.PeerLocationAddResponse")
+	proto.RegisterType((*PeerLocationRemoveRequest)(nil), "bazil.control.PeerLocationRemoveRequest")
+	proto.RegisterType((*PeerLocationRemoveResponse)(nil), "bazil.control.PeerLocationRemoveResponse")
+	proto.RegisterType((*PeerLocationInfo)(nil), "bazil.control.PeerLocationInfo")
+	proto.RegisterType((*PeerLocationListRequest)(nil), "bazil.control.PeerLocationListRequest")
+	proto.RegisterType((*PeerLocationListResponse)(nil), "bazil.control.PeerLocationListResponse")
 	proto.RegisterType((*PeerStorageAllowRequest)(nil), "bazil.control.PeerStorageAllowRequest")
 	proto.RegisterType((*PeerStorageAllowResponse)(nil), "bazil.control.PeerStorageAllowResponse")
 	proto.RegisterType((*PeerVolumeAllowRequest)(nil), "bazil.control.PeerVolumeAllowRequest")
 	proto.RegisterType((*PeerVolumeAllowResponse)(nil), "bazil.control.PeerVolumeAllowResponse")
+	proto.RegisterType((*PeerVolumeInfo)(nil), "bazil.control.PeerVolumeInfo")
+	proto.RegisterType((*PeerInfo)(nil), "bazil.control.PeerInfo")
+	proto.RegisterType((*PeerListRequest)(nil), "bazil.control.PeerListRequest")
+	proto.RegisterType((*PeerListResponse)(nil), "bazil.control.PeerListResponse")
+	proto.RegisterType((*PeerGetRequest)(nil), "bazil.control.PeerGetRequest")
+	proto.RegisterType((*PeerGetResponse)(nil), "bazil.control.PeerGetResponse")
+	proto.RegisterType((*PeerStatusRequest)(nil), "bazil.control.PeerStatusRequest")
+	proto.RegisterType((*PeerStatus)(nil), "bazil.control.PeerStatus")
+	proto.RegisterType((*PeerStatusResponse)(nil), "bazil.control.PeerStatusResponse")
+	proto.RegisterType((*PeerPairRequest)(nil), "bazil.control.PeerPairRequest")
+	proto.RegisterType((*PeerPairResponse)(nil), "bazil.control.PeerPairResponse")
+	proto.RegisterType((*PeerPairJoinRequest)(nil), "bazil.control.PeerPairJoinRequest")
+	proto.RegisterType((*PeerPairJoinResponse)(nil), "bazil.control.PeerPairJoinResponse")
+	proto.RegisterType((*PeerPendingListRequest)(nil), "bazil.control.PeerPendingListRequest")
+	proto.RegisterType((*PeerPending)(nil), "bazil.control.PeerPending")
+	proto.RegisterType((*PeerPendingListResponse)(nil), "bazil.control.PeerPendingListResponse")
+	proto.RegisterType((*PeerApproveRequest)(nil), "bazil.control.PeerApproveRequest")
+	proto.RegisterType((*PeerApproveResponse)(nil), "bazil.control.PeerApproveResponse")
 }
 
 func init() {
@@ -344,21 +1593,55 @@ func init() {
 }
 
 var fileDescriptor_a7a982a125f60130 = []byte{
-	// 244 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0xcd, 0x4a, 0xc4, 0x30,
-	0x10, 0x80, 0x59, 0x95, 0x8a, 0x83, 0xbf, 0x3d, 0xac, 0xd5, 0x83, 0x48, 0x4e, 0x9e, 0x9a, 0x83,
-	0x4f, 0xb0, 0x0b, 0x5e, 0x44, 0x44, 0xba, 0xe0, 0xc1, 0x5b, 0xda, 0x0e, 0x4b, 0x31, 0x9b, 0x89,
-	0x93, 0x74, 0x17, 0x7c, 0x7a, 0x69, 0x92, 0x5d, 0xd4, 0x22, 0xde, 0xe6, 0xf7, 0xfb, 0x98, 0x04,
-	0xca, 0x5a, 0x7d, 0x76, 0xba, 0x24, 0x5e, 0xca, 0x10, 0x49, 0x87, 0xbc, 0x46, 0x96, 0x0d, 0x19,
-	0xcf, 0xa4, 0xe5, 0xa6, 0x63, 0x94, 0x16, 0x91, 0x4b, 0xcb, 0xe4, 0x29, 0x3f, 0x89, 0xf3, 0xa9,
-	0x2d, 0x04, 0x9c, 0xbe, 0x20, 0xf2, 0xac, 0x6d, 0x2b, 0xfc, 0xe8, 0xd1, 0xf9, 0xfc, 0x1c, 0xf6,
-	0x6d, 0x5f, 0x17, 0x7b, 0xb7, 0x93, 0xbb, 0xe3, 0x6a, 0x08, 0xc5, 0x05, 0x9c, 0xed, 0x66, 0x9c,
-	0x25, 0xe3, 0x50, 0xcc, 0x61, 0x3a, 0x94, 0x9e, 0xa8, 0x51, 0xbe, 0x23, 0xb3, 0x40, 0xff, 0x6b,
-	0x7d, 0xb2, 0x5b, 0xcf, 0xa7, 0x90, 0x19, 0xf4, 0x9a, 0x9a, 0xc0, 0x3c, 0xaa, 0x52, 0x26, 0xae,
-	0xe0, 0x72, 0xc4, 0x48, 0xf8, 0x87, 0xd8, 0x5a, 0x78, 0x62, 0xb5, 0xc4, 0x99, 0xd6, 0xb4, 0xf9,
-	0x9b, 0x5f, 0xc0, 0x61, 0xad, 0x9a, 0x77, 0x34, 0x6d, 0x12, 0x6c, 0x53, 0x71, 0x0d, 0xc5, 0x18,
-	0x93, 0x14, 0x8f, 0xf1, 0x82, 0x57, 0xd2, 0xfd, 0xea, 0x3f, 0xc3, 0x0d, 0xc0, 0x3a, 0xcc, 0x3d,
-	0xab, 0x15, 0x26, 0xc9, 0xb7, 0xca, 0xf6, 0x92, 0x1f, 0xac, 0xa8, 0x99, 0x67, 0x6f, 0x07, 0xc3,
-	0x0f, 0xd4, 0x59, 0x78, 0xfd, 0xfb, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x70, 0xf1, 0x7b, 0xeb,
-	0xaf, 0x01, 0x00, 0x00,
+	// 786 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5f, 0x6b, 0x13, 0x41,
+	0x10, 0xe7, 0xf2, 0xaf, 0xc9, 0xd4, 0xb4, 0xe9, 0xb5, 0xb6, 0xd7, 0x50, 0x35, 0x2c, 0x5a, 0x02,
+	0xc5, 0x44, 0x14, 0x11, 0x1f, 0x14, 0x52, 0x0c, 0x52, 0xad, 0x5a, 0x2e, 0x28, 0xd8, 0x07, 0xe1,
+	0x72, 0x37, 0xc6, 0xc3, 0xeb, 0xee, 0xb9, 0xbb, 0x69, 0xa9, 0x88, 0xdf, 0xc4, 0xef, 0xe3, 0xc7,
+	0x92, 0xdb, 0xdd, 0xcb, 0xdd, 0x35, 0x49, 0xfb, 0xe4, 0xdb, 0xce, 0xec, 0xcc, 0x6f, 0x76, 0x7e,
+	0xf3, 0xcb, 0xe4, 0xa0, 0x37, 0xf6, 0x7e, 0x86, 0x51, 0x8f, 0xf1, 0x49, 0x5f, 0x9d, 0xfa, 0x02,
+	0xf9, 0x39, 0xf2, 0xbe, 0xcf, 0xa8, 0xe4, 0x2c, 0xea, 0x5f, 0x84, 0x1c, 0xfb, 0x31, 0x22, 0xef,
+	0xc5, 0x9c, 0x49, 0x66, 0x37, 0x75, 0xbc, 0xb9, 0x26, 0x04, 0xd6, 0x4e, 0x10, 0xf9, 0x20, 0x08,
+	0x5c, 0xfc, 0x31, 0x45, 0x21, 0xed, 0x16, 0x94, 0xe3, 0xe9, 0xd8, 0x29, 0x75, 0xac, 0xee, 0x2d,
+	0x37, 0x39, 0x92, 0x0d, 0x58, 0x9f, 0xc5, 0x88, 0x98, 0x51, 0x81, 0xe4, 0x01, 0x6c, 0x24, 0x2e,
+	0x17, 0xcf, 0xd8, 0x39, 0x5e, 0xc9, 0xb4, 0xb2, 0xcc, 0x2d, 0xb0, 0xf3, 0x61, 0x26, 0xf9, 0x10,
+	0xb6, 0x13, 0xef, 0x31, 0xf3, 0x3d, 0x19, 0x32, 0x3a, 0x42, 0xb9, 0x14, 0xc1, 0xde, 0x86, 0x1a,
+	0x45, 0x19, 0x31, 0x5f, 0x3d, 0xa8, 0xe1, 0x1a, 0x8b, 0xec, 0xc2, 0xce, 0x1c, 0x86, 0x81, 0xff,
+	0x52, 0x84, 0x9f, 0x6f, 0xed, 0x66, 0x78, 0xbb, 0x0d, 0xf5, 0x98, 0x87, 0x8c, 0x87, 0xf2, 0xd2,
+	0x29, 0x77, 0xac, 0x6e, 0xd3, 0x9d, 0xd9, 0x57, 0x4b, 0xe7, 0x69, 0x19, 0xc2, 0x6e, 0xfe, 0xea,
+	0x06, 0x7a, 0x96, 0x36, 0xb7, 0x07, 0xed, 0x45, 0x30, 0xa6, 0xc8, 0x29, 0xb4, 0xf2, 0xb7, 0x47,
+	0xf4, 0x2b, 0xcb, 0x21, 0x59, 0x4b, 0xfb, 0x28, 0x15, 0xfb, 0xb0, 0x6d, 0xa8, 0x44, 0x9e, 0x90,
+	0xaa, 0xbf, 0xba, 0xab, 0xce, 0xe4, 0xa0, 0xd8, 0xdb, 0x71, 0x28, 0x96, 0xcf, 0x86, 0x7c, 0x06,
+	0x67, 0x3e, 0x58, 0x3f, 0xd2, 0x7e, 0x01, 0x8d, 0xc8, 0xf8, 0x85, 0x63, 0x75, 0xca, 0xdd, 0xd5,
+	0xc7, 0xf7, 0x7a, 0x05, 0xe9, 0xf5, 0xae, 0x36, 0xe1, 0x66, 0x19, 0x64, 0xa8, 0xdf, 0x31, 0x92,
+	0x8c, 0x7b, 0x13, 0x1c, 0x44, 0x11, 0xbb, 0x58, 0x4e, 0xa3, 0x03, 0x2b, 0x63, 0xcf, 0xff, 0x8e,
+	0x34, 0x30, 0x3c, 0xa6, 0x26, 0x69, 0x83, 0x33, 0x0f, 0x63, 0x68, 0xfc, 0xa5, 0x65, 0xf2, 0x89,
+	0x45, 0xd3, 0xb3, 0x9b, 0x2a, 0xdc, 0x05, 0x38, 0x57, 0x71, 0xef, 0xbd, 0x33, 0x34, 0x45, 0x72,
+	0x1e, 0x7b, 0x0b, 0xaa, 0xb1, 0x27, 0xbf, 0x09, 0xa7, 0xdc, 0x29, 0x77, 0x1b, 0xae, 0x36, 0x12,
+	0xf2, 0x39, 0x7a, 0xc1, 0x07, 0x1a, 0x5d, 0x3a, 0x15, 0x45, 0xf2, 0xcc, 0x4e, 0x45, 0x54, 0xa8,
+	0x6e, 0x1e, 0xf6, 0x1b, 0xd6, 0xb2, 0x2b, 0x35, 0xdd, 0x36, 0xd4, 0x75, 0xb1, 0xa3, 0x57, 0xe6,
+	0x55, 0x33, 0xfb, 0x3f, 0x3c, 0xed, 0x8f, 0x05, 0xf5, 0xe4, 0x01, 0xaa, 0xf4, 0x3c, 0x17, 0x6b,
+	0x50, 0x0a, 0x03, 0x23, 0xa6, 0x52, 0x18, 0xd8, 0x7b, 0xf9, 0x49, 0xeb, 0x22, 0x99, 0x23, 0x99,
+	0x8d, 0xd0, 0xec, 0x3b, 0x15, 0x75, 0x97, 0x9a, 0xf6, 0x33, 0x58, 0xd1, 0xcf, 0x14, 0x4e, 0x55,
+	0xe9, 0xe3, 0xce, 0x02, 0x7d, 0x64, 0x24, 0xb8, 0x69, 0x74, 0xba, 0x8e, 0x72, 0xda, 0x24, 0x03,
+	0x68, 0x65, 0x2e, 0xa3, 0xc0, 0x87, 0x50, 0x8d, 0x11, 0x79, 0xaa, 0xbe, 0x9d, 0x05, 0xe8, 0x0a,
+	0x57, 0x47, 0xa5, 0x8b, 0xf0, 0xf5, 0x35, 0xcb, 0x88, 0xbc, 0x84, 0xf5, 0x59, 0x8c, 0xa9, 0x72,
+	0x00, 0x95, 0x24, 0x5f, 0x45, 0x5d, 0x53, 0x44, 0x05, 0x91, 0xe7, 0x7a, 0x6b, 0x8e, 0xa4, 0x27,
+	0xa7, 0x62, 0xb9, 0xda, 0x6c, 0xa8, 0xc4, 0x21, 0x9d, 0x28, 0x8e, 0xeb, 0xae, 0x3a, 0x93, 0xbf,
+	0x16, 0x40, 0x96, 0xbb, 0x20, 0x69, 0x0f, 0x1a, 0x3e, 0xa3, 0x14, 0x7d, 0x89, 0x81, 0xc9, 0xcc,
+	0x1c, 0x89, 0x0a, 0x84, 0xf4, 0x24, 0xaa, 0x1f, 0x7b, 0xc3, 0xd5, 0x46, 0xe2, 0x0d, 0xe9, 0x47,
+	0x81, 0x4a, 0x02, 0x4d, 0x57, 0x1b, 0x89, 0x36, 0x92, 0x5d, 0x30, 0x42, 0xa4, 0x4e, 0xb5, 0x63,
+	0x75, 0xcb, 0xee, 0xcc, 0x56, 0xc3, 0xf6, 0x84, 0x1c, 0x72, 0xce, 0xb8, 0x53, 0x53, 0x58, 0x99,
+	0xc3, 0xbe, 0x0f, 0xcd, 0xc8, 0x93, 0x48, 0xfd, 0xcb, 0x77, 0xa1, 0xcf, 0x99, 0x70, 0x56, 0x54,
+	0x7a, 0xd1, 0x49, 0x86, 0x60, 0x67, 0x9d, 0xcc, 0x88, 0xec, 0x17, 0xc7, 0xb5, 0xbb, 0x80, 0x49,
+	0x93, 0x61, 0x06, 0x76, 0xa0, 0x87, 0x71, 0xe2, 0x85, 0x3c, 0xa5, 0x32, 0x11, 0x1b, 0xfa, 0x8c,
+	0x06, 0x42, 0x31, 0xd3, 0x74, 0x53, 0x93, 0xec, 0x43, 0x2b, 0x0b, 0x36, 0x15, 0x6d, 0xa8, 0xf8,
+	0x2c, 0x40, 0xb3, 0x31, 0xd5, 0x99, 0x0c, 0x60, 0x33, 0x8d, 0x7b, 0xc3, 0x42, 0x9a, 0x02, 0x2f,
+	0x5b, 0xaf, 0x29, 0x44, 0x29, 0x07, 0xd1, 0x85, 0xad, 0x22, 0x84, 0x29, 0x37, 0x2f, 0x27, 0x47,
+	0x6f, 0xa0, 0x13, 0xa4, 0x41, 0x48, 0x27, 0x79, 0x3d, 0x3f, 0x85, 0xd5, 0xdc, 0xcd, 0x82, 0x69,
+	0x27, 0xf3, 0x0c, 0xa9, 0xaf, 0x2b, 0x97, 0x5d, 0x6d, 0x90, 0xb7, 0xb0, 0x93, 0x4b, 0x2b, 0xfc,
+	0x1a, 0x1e, 0x15, 0xe9, 0x6d, 0x2f, 0xa0, 0xd7, 0xa4, 0xa5, 0xfc, 0xee, 0xeb, 0x31, 0x0d, 0xe2,
+	0x98, 0x5f, 0xfb, 0x1f, 0x7f, 0x1b, 0x36, 0x0b, 0x71, 0xba, 0xe0, 0x61, 0xed, 0xb4, 0x92, 0x7c,
+	0x7a, 0x8c, 0x6b, 0xea, 0xb3, 0xe3, 0xc9, 0xbf, 0x01, 0x00, 0xbf, 0xfb, 0x89, 0xd5, 0xa8, 0x08,
+	0x00, 0x00,
 }
@@ -20,10 +20,87 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// SharingKeyKDF describes how a sharing key is derived from a
+// passphrase, using scrypt.
+type SharingKeyKDF struct {
+	Salt []byte `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	// scrypt cost parameter N is 1<<logN.
+	LogN                 uint32   `protobuf:"varint,2,opt,name=logN,proto3" json:"logN,omitempty"`
+	R                    uint32   `protobuf:"varint,3,opt,name=r,proto3" json:"r,omitempty"`
+	P                    uint32   `protobuf:"varint,4,opt,name=p,proto3" json:"p,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyKDF) Reset()         { *m = SharingKeyKDF{} }
+func (m *SharingKeyKDF) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyKDF) ProtoMessage()    {}
+func (*SharingKeyKDF) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{0}
+}
+
+func (m *SharingKeyKDF) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyKDF.Unmarshal(m, b)
+}
+func (m *SharingKeyKDF) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyKDF.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyKDF) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyKDF.Merge(m, src)
+}
+func (m *SharingKeyKDF) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyKDF.Size(m)
+}
+func (m *SharingKeyKDF) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyKDF.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyKDF proto.InternalMessageInfo
+
+func (m *SharingKeyKDF) GetSalt() []byte {
+	if m != nil {
+		return m.Salt
+	}
+	return nil
+}
+
+func (m *SharingKeyKDF) GetLogN() uint32 {
+	if m != nil {
+		return m.LogN
+	}
+	return 0
+}
+
+func (m *SharingKeyKDF) GetR() uint32 {
+	if m != nil {
+		return m.R
+	}
+	return 0
+}
+
+func (m *SharingKeyKDF) GetP() uint32 {
+	if m != nil {
+		return m.P
+	}
+	return 0
+}
+
 type SharingKeyAddRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Must be exactly 32 bytes long.
-	Secret               []byte   `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Must be exactly 32 bytes long. Exactly one of secret and
+	// passphrase must be set.
+	Secret []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Derive the secret from this passphrase.
+	Passphrase []byte `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// Parameters for deriving the secret from passphrase. To derive
+	// the same secret as elsewhere, pass the parameters used there. If
+	// not set, a random salt and default costs are used.
+	Kdf *SharingKeyKDF `protobuf:"bytes,4,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	// Pad data encrypted with this sharing key, to hide its size.
+	// One of "none", "pow2", "padme"; empty means none. Cannot be
+	// changed later, except by rotating the sharing key.
+	Padding              string   `protobuf:"bytes,5,opt,name=padding,proto3" json:"padding,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -33,7 +110,7 @@ func (m *SharingKeyAddRequest) Reset()         { *m = SharingKeyAddRequest{} }
 func (m *SharingKeyAddRequest) String() string { return proto.CompactTextString(m) }
 func (*SharingKeyAddRequest) ProtoMessage()    {}
 func (*SharingKeyAddRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca3dca729318981e, []int{0}
+	return fileDescriptor_ca3dca729318981e, []int{1}
 }
 
 func (m *SharingKeyAddRequest) XXX_Unmarshal(b []byte) error {
@@ -68,17 +145,40 @@ func (m *SharingKeyAddRequest) GetSecret() []byte {
 	return nil
 }
 
+func (m *SharingKeyAddRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *SharingKeyAddRequest) GetKdf() *SharingKeyKDF {
+	if m != nil {
+		return m.Kdf
+	}
+	return nil
+}
+
+func (m *SharingKeyAddRequest) GetPadding() string {
+	if m != nil {
+		return m.Padding
+	}
+	return ""
+}
+
 type SharingKeyAddResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Set if the secret was derived from a passphrase.
+	Kdf                  *SharingKeyKDF `protobuf:"bytes,1,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *SharingKeyAddResponse) Reset()         { *m = SharingKeyAddResponse{} }
 func (m *SharingKeyAddResponse) String() string { return proto.CompactTextString(m) }
 func (*SharingKeyAddResponse) ProtoMessage()    {}
 func (*SharingKeyAddResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca3dca729318981e, []int{1}
+	return fileDescriptor_ca3dca729318981e, []int{2}
 }
 
 func (m *SharingKeyAddResponse) XXX_Unmarshal(b []byte) error {
@@ -99,9 +199,540 @@ func (m *SharingKeyAddResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SharingKeyAddResponse proto.InternalMessageInfo
 
+func (m *SharingKeyAddResponse) GetKdf() *SharingKeyKDF {
+	if m != nil {
+		return m.Kdf
+	}
+	return nil
+}
+
+type SharingKeyInfo struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Set if the secret was derived from a passphrase.
+	Kdf *SharingKeyKDF `protobuf:"bytes,2,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	// Padding mode, empty for none.
+	Padding              string   `protobuf:"bytes,3,opt,name=padding,proto3" json:"padding,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyInfo) Reset()         { *m = SharingKeyInfo{} }
+func (m *SharingKeyInfo) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyInfo) ProtoMessage()    {}
+func (*SharingKeyInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{3}
+}
+
+func (m *SharingKeyInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyInfo.Unmarshal(m, b)
+}
+func (m *SharingKeyInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyInfo.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyInfo.Merge(m, src)
+}
+func (m *SharingKeyInfo) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyInfo.Size(m)
+}
+func (m *SharingKeyInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyInfo proto.InternalMessageInfo
+
+func (m *SharingKeyInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SharingKeyInfo) GetKdf() *SharingKeyKDF {
+	if m != nil {
+		return m.Kdf
+	}
+	return nil
+}
+
+func (m *SharingKeyInfo) GetPadding() string {
+	if m != nil {
+		return m.Padding
+	}
+	return ""
+}
+
+type SharingKeyListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyListRequest) Reset()         { *m = SharingKeyListRequest{} }
+func (m *SharingKeyListRequest) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyListRequest) ProtoMessage()    {}
+func (*SharingKeyListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{4}
+}
+
+func (m *SharingKeyListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyListRequest.Unmarshal(m, b)
+}
+func (m *SharingKeyListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyListRequest.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyListRequest.Merge(m, src)
+}
+func (m *SharingKeyListRequest) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyListRequest.Size(m)
+}
+func (m *SharingKeyListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyListRequest proto.InternalMessageInfo
+
+type SharingKeyListResponse struct {
+	SharingKeys          []*SharingKeyInfo `protobuf:"bytes,1,rep,name=sharingKeys,proto3" json:"sharingKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SharingKeyListResponse) Reset()         { *m = SharingKeyListResponse{} }
+func (m *SharingKeyListResponse) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyListResponse) ProtoMessage()    {}
+func (*SharingKeyListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{5}
+}
+
+func (m *SharingKeyListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyListResponse.Unmarshal(m, b)
+}
+func (m *SharingKeyListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyListResponse.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyListResponse.Merge(m, src)
+}
+func (m *SharingKeyListResponse) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyListResponse.Size(m)
+}
+func (m *SharingKeyListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyListResponse proto.InternalMessageInfo
+
+func (m *SharingKeyListResponse) GetSharingKeys() []*SharingKeyInfo {
+	if m != nil {
+		return m.SharingKeys
+	}
+	return nil
+}
+
+type SharingKeyRotateRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Padding mode for the new sharing key. If empty, the padding of
+	// the old sharing key is kept.
+	Padding              string   `protobuf:"bytes,2,opt,name=padding,proto3" json:"padding,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyRotateRequest) Reset()         { *m = SharingKeyRotateRequest{} }
+func (m *SharingKeyRotateRequest) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyRotateRequest) ProtoMessage()    {}
+func (*SharingKeyRotateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{6}
+}
+
+func (m *SharingKeyRotateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyRotateRequest.Unmarshal(m, b)
+}
+func (m *SharingKeyRotateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyRotateRequest.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyRotateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyRotateRequest.Merge(m, src)
+}
+func (m *SharingKeyRotateRequest) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyRotateRequest.Size(m)
+}
+func (m *SharingKeyRotateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyRotateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyRotateRequest proto.InternalMessageInfo
+
+func (m *SharingKeyRotateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SharingKeyRotateRequest) GetPadding() string {
+	if m != nil {
+		return m.Padding
+	}
+	return ""
+}
+
+type SharingKeyRotateResponse struct {
+	// Name of the new generation of the sharing key.
+	NewName              string   `protobuf:"bytes,1,opt,name=newName,proto3" json:"newName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyRotateResponse) Reset()         { *m = SharingKeyRotateResponse{} }
+func (m *SharingKeyRotateResponse) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyRotateResponse) ProtoMessage()    {}
+func (*SharingKeyRotateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{7}
+}
+
+func (m *SharingKeyRotateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyRotateResponse.Unmarshal(m, b)
+}
+func (m *SharingKeyRotateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyRotateResponse.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyRotateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyRotateResponse.Merge(m, src)
+}
+func (m *SharingKeyRotateResponse) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyRotateResponse.Size(m)
+}
+func (m *SharingKeyRotateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyRotateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyRotateResponse proto.InternalMessageInfo
+
+func (m *SharingKeyRotateResponse) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type SharingKeyRotationRequest struct {
+	// Name of the sharing key being replaced.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyRotationRequest) Reset()         { *m = SharingKeyRotationRequest{} }
+func (m *SharingKeyRotationRequest) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyRotationRequest) ProtoMessage()    {}
+func (*SharingKeyRotationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{8}
+}
+
+func (m *SharingKeyRotationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyRotationRequest.Unmarshal(m, b)
+}
+func (m *SharingKeyRotationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyRotationRequest.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyRotationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyRotationRequest.Merge(m, src)
+}
+func (m *SharingKeyRotationRequest) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyRotationRequest.Size(m)
+}
+func (m *SharingKeyRotationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyRotationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyRotationRequest proto.InternalMessageInfo
+
+func (m *SharingKeyRotationRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SharingKeyRotationResponse struct {
+	NewName              string   `protobuf:"bytes,1,opt,name=newName,proto3" json:"newName,omitempty"`
+	Volumes              uint32   `protobuf:"varint,2,opt,name=volumes,proto3" json:"volumes,omitempty"`
+	VolumesDone          uint32   `protobuf:"varint,3,opt,name=volumesDone,proto3" json:"volumesDone,omitempty"`
+	Chunks               uint64   `protobuf:"varint,4,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Done                 bool     `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyRotationResponse) Reset()         { *m = SharingKeyRotationResponse{} }
+func (m *SharingKeyRotationResponse) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyRotationResponse) ProtoMessage()    {}
+func (*SharingKeyRotationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{9}
+}
+
+func (m *SharingKeyRotationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyRotationResponse.Unmarshal(m, b)
+}
+func (m *SharingKeyRotationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyRotationResponse.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyRotationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyRotationResponse.Merge(m, src)
+}
+func (m *SharingKeyRotationResponse) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyRotationResponse.Size(m)
+}
+func (m *SharingKeyRotationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyRotationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyRotationResponse proto.InternalMessageInfo
+
+func (m *SharingKeyRotationResponse) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+func (m *SharingKeyRotationResponse) GetVolumes() uint32 {
+	if m != nil {
+		return m.Volumes
+	}
+	return 0
+}
+
+func (m *SharingKeyRotationResponse) GetVolumesDone() uint32 {
+	if m != nil {
+		return m.VolumesDone
+	}
+	return 0
+}
+
+func (m *SharingKeyRotationResponse) GetChunks() uint64 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *SharingKeyRotationResponse) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+type SharingKeyExportRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Passphrase to encrypt the exported sharing key with.
+	Passphrase           []byte   `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyExportRequest) Reset()         { *m = SharingKeyExportRequest{} }
+func (m *SharingKeyExportRequest) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyExportRequest) ProtoMessage()    {}
+func (*SharingKeyExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{10}
+}
+
+func (m *SharingKeyExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyExportRequest.Unmarshal(m, b)
+}
+func (m *SharingKeyExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyExportRequest.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyExportRequest.Merge(m, src)
+}
+func (m *SharingKeyExportRequest) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyExportRequest.Size(m)
+}
+func (m *SharingKeyExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyExportRequest proto.InternalMessageInfo
+
+func (m *SharingKeyExportRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SharingKeyExportRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+type SharingKeyExportResponse struct {
+	// PEM-armored, passphrase-encrypted sharing key.
+	Armored              []byte   `protobuf:"bytes,1,opt,name=armored,proto3" json:"armored,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyExportResponse) Reset()         { *m = SharingKeyExportResponse{} }
+func (m *SharingKeyExportResponse) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyExportResponse) ProtoMessage()    {}
+func (*SharingKeyExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{11}
+}
+
+func (m *SharingKeyExportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyExportResponse.Unmarshal(m, b)
+}
+func (m *SharingKeyExportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyExportResponse.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyExportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyExportResponse.Merge(m, src)
+}
+func (m *SharingKeyExportResponse) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyExportResponse.Size(m)
+}
+func (m *SharingKeyExportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyExportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyExportResponse proto.InternalMessageInfo
+
+func (m *SharingKeyExportResponse) GetArmored() []byte {
+	if m != nil {
+		return m.Armored
+	}
+	return nil
+}
+
+type SharingKeyImportRequest struct {
+	// As returned by SharingKeyExport.
+	Armored    []byte `protobuf:"bytes,1,opt,name=armored,proto3" json:"armored,omitempty"`
+	Passphrase []byte `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// Name to store the sharing key as. If empty, the name from the
+	// export is used.
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyImportRequest) Reset()         { *m = SharingKeyImportRequest{} }
+func (m *SharingKeyImportRequest) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyImportRequest) ProtoMessage()    {}
+func (*SharingKeyImportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{12}
+}
+
+func (m *SharingKeyImportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyImportRequest.Unmarshal(m, b)
+}
+func (m *SharingKeyImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyImportRequest.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyImportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyImportRequest.Merge(m, src)
+}
+func (m *SharingKeyImportRequest) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyImportRequest.Size(m)
+}
+func (m *SharingKeyImportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyImportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyImportRequest proto.InternalMessageInfo
+
+func (m *SharingKeyImportRequest) GetArmored() []byte {
+	if m != nil {
+		return m.Armored
+	}
+	return nil
+}
+
+func (m *SharingKeyImportRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *SharingKeyImportRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SharingKeyImportResponse struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKeyImportResponse) Reset()         { *m = SharingKeyImportResponse{} }
+func (m *SharingKeyImportResponse) String() string { return proto.CompactTextString(m) }
+func (*SharingKeyImportResponse) ProtoMessage()    {}
+func (*SharingKeyImportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca3dca729318981e, []int{13}
+}
+
+func (m *SharingKeyImportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKeyImportResponse.Unmarshal(m, b)
+}
+func (m *SharingKeyImportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKeyImportResponse.Marshal(b, m, deterministic)
+}
+func (m *SharingKeyImportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKeyImportResponse.Merge(m, src)
+}
+func (m *SharingKeyImportResponse) XXX_Size() int {
+	return xxx_messageInfo_SharingKeyImportResponse.Size(m)
+}
+func (m *SharingKeyImportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKeyImportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKeyImportResponse proto.InternalMessageInfo
+
+func (m *SharingKeyImportResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterType((*SharingKeyKDF)(nil), "bazil.control.SharingKeyKDF")
 	proto.RegisterType((*SharingKeyAddRequest)(nil), "bazil.control.SharingKeyAddRequest")
 	proto.RegisterType((*SharingKeyAddResponse)(nil), "bazil.control.SharingKeyAddResponse")
+	proto.RegisterType((*SharingKeyInfo)(nil), "bazil.control.SharingKeyInfo")
+	proto.RegisterType((*SharingKeyListRequest)(nil), "bazil.control.SharingKeyListRequest")
+	proto.RegisterType((*SharingKeyListResponse)(nil), "bazil.control.SharingKeyListResponse")
+	proto.RegisterType((*SharingKeyRotateRequest)(nil), "bazil.control.SharingKeyRotateRequest")
+	proto.RegisterType((*SharingKeyRotateResponse)(nil), "bazil.control.SharingKeyRotateResponse")
+	proto.RegisterType((*SharingKeyRotationRequest)(nil), "bazil.control.SharingKeyRotationRequest")
+	proto.RegisterType((*SharingKeyRotationResponse)(nil), "bazil.control.SharingKeyRotationResponse")
+	proto.RegisterType((*SharingKeyExportRequest)(nil), "bazil.control.SharingKeyExportRequest")
+	proto.RegisterType((*SharingKeyExportResponse)(nil), "bazil.control.SharingKeyExportResponse")
+	proto.RegisterType((*SharingKeyImportRequest)(nil), "bazil.control.SharingKeyImportRequest")
+	proto.RegisterType((*SharingKeyImportResponse)(nil), "bazil.control.SharingKeyImportResponse")
 }
 
 func init() {
@@ -109,15 +740,35 @@ func init() {
 }
 
 var fileDescriptor_ca3dca729318981e = []byte{
-	// 157 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4c, 0x4a, 0xac, 0xca,
-	0xcc, 0xd1, 0xcb, 0x2f, 0x4a, 0xd7, 0x07, 0xb3, 0xf4, 0x8b, 0x53, 0x8b, 0xca, 0x52, 0x8b, 0xf4,
-	0x93, 0xf3, 0xf3, 0x4a, 0x8a, 0xf2, 0x73, 0xf4, 0xcb, 0x33, 0x8b, 0x52, 0xf5, 0x8b, 0x33, 0x12,
-	0x8b, 0x32, 0xf3, 0xd2, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x78, 0x21, 0x5a, 0xa0, 0x2a,
-	0x94, 0x9c, 0xb8, 0x44, 0x82, 0x21, 0xf2, 0xde, 0xa9, 0x95, 0x8e, 0x29, 0x29, 0x41, 0xa9, 0x85,
-	0xa5, 0xa9, 0xc5, 0x25, 0x42, 0x42, 0x5c, 0x2c, 0x79, 0x89, 0xb9, 0xa9, 0x12, 0x8c, 0x0a, 0x8c,
-	0x1a, 0x9c, 0x41, 0x60, 0xb6, 0x90, 0x18, 0x17, 0x5b, 0x71, 0x6a, 0x72, 0x51, 0x6a, 0x89, 0x04,
-	0x93, 0x02, 0xa3, 0x06, 0x4f, 0x10, 0x94, 0xa7, 0x24, 0xce, 0x25, 0x8a, 0x66, 0x46, 0x71, 0x41,
-	0x7e, 0x5e, 0x71, 0xaa, 0x13, 0x5b, 0x14, 0x0b, 0xc8, 0x05, 0x49, 0x6c, 0x60, 0xab, 0x8d, 0x01,
-	0x01, 0x00, 0x00, 0xff, 0xff, 0xbf, 0x52, 0x9a, 0xa6, 0xaf, 0x00, 0x00, 0x00,
+	// 476 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xc1, 0x72, 0xd3, 0x30,
+	0x10, 0x1d, 0xc5, 0x21, 0x85, 0x4d, 0xc2, 0x41, 0x03, 0xad, 0x60, 0x80, 0xc9, 0xe8, 0x94, 0x93,
+	0x3d, 0x14, 0xee, 0x0c, 0x4c, 0xa1, 0xd3, 0x29, 0xf4, 0xa0, 0x9e, 0xe0, 0xa6, 0xc6, 0xaa, 0xe3,
+	0xa9, 0x23, 0x19, 0x49, 0x69, 0x81, 0x8f, 0xe1, 0x0b, 0xf8, 0x48, 0x46, 0x8a, 0x5c, 0xcb, 0x69,
+	0x9a, 0x30, 0xdc, 0xf6, 0xed, 0x7a, 0xdf, 0xbe, 0x5d, 0xef, 0x0a, 0x5e, 0x5f, 0xf0, 0x5f, 0x65,
+	0x95, 0x2a, 0x5d, 0x64, 0xde, 0xca, 0x8c, 0xd0, 0xd7, 0x42, 0x67, 0x33, 0x25, 0xad, 0x56, 0x55,
+	0x76, 0x53, 0x6a, 0x91, 0x99, 0x39, 0xd7, 0xa5, 0x2c, 0xd2, 0x5a, 0x2b, 0xab, 0xf0, 0x78, 0x95,
+	0x12, 0xbe, 0xa0, 0xe7, 0x30, 0x3e, 0x5f, 0xc5, 0x4f, 0xc5, 0xcf, 0xd3, 0xa3, 0x4f, 0x18, 0x43,
+	0xdf, 0xf0, 0xca, 0x12, 0x34, 0x41, 0xd3, 0x11, 0xf3, 0xb6, 0xf3, 0x55, 0xaa, 0x38, 0x23, 0xbd,
+	0x09, 0x9a, 0x8e, 0x99, 0xb7, 0xf1, 0x08, 0x90, 0x26, 0x89, 0x77, 0x20, 0xed, 0x50, 0x4d, 0xfa,
+	0x2b, 0x54, 0xd3, 0x3f, 0x08, 0x9e, 0xb4, 0xac, 0xef, 0xf3, 0x9c, 0x89, 0xef, 0x4b, 0x61, 0x3c,
+	0x91, 0xe4, 0x0b, 0xe1, 0xc9, 0x1f, 0x31, 0x6f, 0xe3, 0x7d, 0x18, 0x18, 0x31, 0xd3, 0xc2, 0x7a,
+	0xfa, 0x11, 0x0b, 0x08, 0xbf, 0x02, 0xa8, 0xb9, 0x31, 0xf5, 0x5c, 0x73, 0x23, 0x7c, 0xa5, 0x11,
+	0x8b, 0x3c, 0x38, 0x85, 0xe4, 0x2a, 0xbf, 0xf4, 0x45, 0x87, 0x87, 0x2f, 0xd2, 0x4e, 0x5b, 0x69,
+	0xa7, 0x27, 0xe6, 0x3e, 0xc4, 0x04, 0xf6, 0x6a, 0x9e, 0xe7, 0xa5, 0x2c, 0xc8, 0x03, 0x5f, 0xbe,
+	0x81, 0xf4, 0x18, 0x9e, 0xae, 0xa9, 0x35, 0xb5, 0x92, 0x6d, 0x09, 0xf4, 0x8f, 0x25, 0xa8, 0x84,
+	0xc7, 0xad, 0xf7, 0x44, 0x5e, 0xaa, 0x8d, 0x0d, 0x07, 0xd6, 0xde, 0x7f, 0x08, 0x4f, 0xba, 0xc2,
+	0x0f, 0x62, 0xe1, 0x9f, 0x4b, 0x63, 0xc3, 0x9c, 0xe9, 0x57, 0xd8, 0x5f, 0x0f, 0x84, 0x96, 0xde,
+	0xc1, 0xd0, 0xdc, 0x46, 0x0c, 0x41, 0x93, 0x64, 0x3a, 0x3c, 0x7c, 0x79, 0xaf, 0x08, 0xd7, 0x04,
+	0x8b, 0x33, 0xe8, 0x31, 0x1c, 0xb4, 0x61, 0xa6, 0x2c, 0xb7, 0x62, 0xdb, 0xdf, 0x8d, 0xc4, 0xf7,
+	0xba, 0xe2, 0xdf, 0x02, 0xb9, 0x4b, 0x14, 0x54, 0x12, 0xd8, 0x93, 0xe2, 0xe6, 0xac, 0x25, 0x6b,
+	0x20, 0xcd, 0xe0, 0xd9, 0x5a, 0x56, 0xa9, 0xe4, 0x16, 0x01, 0xf4, 0x37, 0x82, 0xe7, 0x9b, 0x32,
+	0x76, 0x55, 0x72, 0x91, 0x6b, 0x55, 0x2d, 0x17, 0xc2, 0x84, 0xbd, 0x6f, 0x20, 0x9e, 0xc0, 0x30,
+	0x98, 0x47, 0x4a, 0x8a, 0x70, 0x04, 0xb1, 0xcb, 0xed, 0xf4, 0x6c, 0xbe, 0x94, 0x57, 0xc6, 0xaf,
+	0x67, 0x9f, 0x05, 0xe4, 0x04, 0xe6, 0x2e, 0xc5, 0x2d, 0xe0, 0x43, 0xe6, 0x6d, 0xfa, 0x25, 0x1e,
+	0xe8, 0xc7, 0x1f, 0xb5, 0xd2, 0x76, 0xdb, 0x40, 0xbb, 0x67, 0xd1, 0x5b, 0x3f, 0x8b, 0xee, 0x58,
+	0x1b, 0xba, 0xb6, 0x59, 0xae, 0x17, 0x4a, 0x8b, 0x3c, 0x9c, 0x77, 0x03, 0x69, 0x11, 0x8b, 0x38,
+	0x59, 0xc4, 0x22, 0xee, 0x4d, 0xda, 0x25, 0xe5, 0x56, 0x7e, 0x12, 0xfd, 0x8e, 0x14, 0xc8, 0xdd,
+	0x42, 0x41, 0xde, 0x86, 0x76, 0x3f, 0x0c, 0xbe, 0xf5, 0xdd, 0x23, 0x76, 0x31, 0xf0, 0xaf, 0xd7,
+	0x9b, 0xbf, 0x03, 0x00, 0x85, 0xd2, 0xd3, 0xef, 0xf2, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bazil.org/bazil/server/control/wire/storage.proto
+
+package wire
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type StorageScrubRequest struct {
+	// Scrub only this volume. If empty, scrub all volumes.
+	VolumeName string `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Rewrite bad chunks from an intact copy in another storage of
+	// the volume.
+	Repair               bool     `protobuf:"varint,2,opt,name=repair,proto3" json:"repair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageScrubRequest) Reset()         { *m = StorageScrubRequest{} }
+func (m *StorageScrubRequest) String() string { return proto.CompactTextString(m) }
+func (*StorageScrubRequest) ProtoMessage()    {}
+func (*StorageScrubRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20d8c80d254f7576, []int{0}
+}
+
+func (m *StorageScrubRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageScrubRequest.Unmarshal(m, b)
+}
+func (m *StorageScrubRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageScrubRequest.Marshal(b, m, deterministic)
+}
+func (m *StorageScrubRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageScrubRequest.Merge(m, src)
+}
+func (m *StorageScrubRequest) XXX_Size() int {
+	return xxx_messageInfo_StorageScrubRequest.Size(m)
+}
+func (m *StorageScrubRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageScrubRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageScrubRequest proto.InternalMessageInfo
+
+func (m *StorageScrubRequest) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *StorageScrubRequest) GetRepair() bool {
+	if m != nil {
+		return m.Repair
+	}
+	return false
+}
+
+type StorageScrubProblem struct {
+	VolumeName string `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Name of the volume storage the chunk is bad in. Empty if no
+	// intact copy was found in any storage.
+	Storage string `protobuf:"bytes,2,opt,name=storage,proto3" json:"storage,omitempty"`
+	Key     []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Type    string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Level   uint32 `protobuf:"varint,5,opt,name=level,proto3" json:"level,omitempty"`
+	Error   string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Files and directories using the chunk.
+	Paths                []string `protobuf:"bytes,7,rep,name=paths,proto3" json:"paths,omitempty"`
+	Repaired             bool     `protobuf:"varint,8,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageScrubProblem) Reset()         { *m = StorageScrubProblem{} }
+func (m *StorageScrubProblem) String() string { return proto.CompactTextString(m) }
+func (*StorageScrubProblem) ProtoMessage()    {}
+func (*StorageScrubProblem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20d8c80d254f7576, []int{1}
+}
+
+func (m *StorageScrubProblem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageScrubProblem.Unmarshal(m, b)
+}
+func (m *StorageScrubProblem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageScrubProblem.Marshal(b, m, deterministic)
+}
+func (m *StorageScrubProblem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageScrubProblem.Merge(m, src)
+}
+func (m *StorageScrubProblem) XXX_Size() int {
+	return xxx_messageInfo_StorageScrubProblem.Size(m)
+}
+func (m *StorageScrubProblem) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageScrubProblem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageScrubProblem proto.InternalMessageInfo
+
+func (m *StorageScrubProblem) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *StorageScrubProblem) GetStorage() string {
+	if m != nil {
+		return m.Storage
+	}
+	return ""
+}
+
+func (m *StorageScrubProblem) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StorageScrubProblem) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *StorageScrubProblem) GetLevel() uint32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *StorageScrubProblem) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *StorageScrubProblem) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *StorageScrubProblem) GetRepaired() bool {
+	if m != nil {
+		return m.Repaired
+	}
+	return false
+}
+
+type StorageScrubResponse struct {
+	// Number of distinct chunks checked.
+	Chunks               uint64                 `protobuf:"varint,1,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Problems             []*StorageScrubProblem `protobuf:"bytes,2,rep,name=problems,proto3" json:"problems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *StorageScrubResponse) Reset()         { *m = StorageScrubResponse{} }
+func (m *StorageScrubResponse) String() string { return proto.CompactTextString(m) }
+func (*StorageScrubResponse) ProtoMessage()    {}
+func (*StorageScrubResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20d8c80d254f7576, []int{2}
+}
+
+func (m *StorageScrubResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageScrubResponse.Unmarshal(m, b)
+}
+func (m *StorageScrubResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageScrubResponse.Marshal(b, m, deterministic)
+}
+func (m *StorageScrubResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageScrubResponse.Merge(m, src)
+}
+func (m *StorageScrubResponse) XXX_Size() int {
+	return xxx_messageInfo_StorageScrubResponse.Size(m)
+}
+func (m *StorageScrubResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageScrubResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageScrubResponse proto.InternalMessageInfo
+
+func (m *StorageScrubResponse) GetChunks() uint64 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *StorageScrubResponse) GetProblems() []*StorageScrubProblem {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StorageScrubRequest)(nil), "bazil.control.StorageScrubRequest")
+	proto.RegisterType((*StorageScrubProblem)(nil), "bazil.control.StorageScrubProblem")
+	proto.RegisterType((*StorageScrubResponse)(nil), "bazil.control.StorageScrubResponse")
+}
+
+func init() {
+	proto.RegisterFile("bazil.org/bazil/server/control/wire/storage.proto", fileDescriptor_20d8c80d254f7576)
+}
+
+var fileDescriptor_20d8c80d254f7576 = []byte{
+	// 293 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x41, 0x4b, 0xc3, 0x40,
+	0x10, 0x85, 0x49, 0x93, 0xa6, 0xe9, 0x68, 0x41, 0xd6, 0x22, 0x8b, 0x07, 0x59, 0x72, 0xca, 0x29,
+	0x41, 0xbd, 0x7b, 0xf0, 0xae, 0xc8, 0xf6, 0xe6, 0x2d, 0xa9, 0x43, 0x5b, 0xba, 0xcd, 0xae, 0xb3,
+	0x9b, 0x4a, 0xfd, 0x9b, 0xfe, 0x21, 0xc9, 0x6e, 0x2c, 0x16, 0x04, 0x6f, 0xf3, 0xbd, 0x9d, 0x19,
+	0xf6, 0xbd, 0x81, 0xdb, 0xa6, 0xfe, 0xdc, 0xa8, 0x52, 0xd3, 0xaa, 0xf2, 0x55, 0x65, 0x91, 0xf6,
+	0x48, 0xd5, 0x52, 0xb7, 0x8e, 0xb4, 0xaa, 0x3e, 0x36, 0x84, 0x95, 0x75, 0x9a, 0xea, 0x15, 0x96,
+	0x86, 0xb4, 0xd3, 0x6c, 0x16, 0x46, 0x86, 0x8e, 0xfc, 0x09, 0x2e, 0x17, 0xe1, 0x7d, 0xb1, 0xa4,
+	0xae, 0x91, 0xf8, 0xde, 0xa1, 0x75, 0xec, 0x06, 0x60, 0xaf, 0x55, 0xb7, 0xc3, 0xe7, 0x7a, 0x87,
+	0x3c, 0x12, 0x51, 0x31, 0x95, 0xbf, 0x14, 0x76, 0x05, 0x29, 0xa1, 0xa9, 0x37, 0xc4, 0x47, 0x22,
+	0x2a, 0x32, 0x39, 0x50, 0xfe, 0x15, 0x9d, 0xee, 0x7b, 0x21, 0xdd, 0x28, 0xdc, 0xfd, 0xbb, 0x8f,
+	0xc3, 0x64, 0xf8, 0xa6, 0x5f, 0x38, 0x95, 0x3f, 0xc8, 0x2e, 0x20, 0xde, 0xe2, 0x81, 0xc7, 0x22,
+	0x2a, 0xce, 0x65, 0x5f, 0x32, 0x06, 0x89, 0x3b, 0x18, 0xe4, 0x89, 0x6f, 0xf4, 0x35, 0x9b, 0xc3,
+	0x58, 0xe1, 0x1e, 0x15, 0x1f, 0x8b, 0xa8, 0x98, 0xc9, 0x00, 0xbd, 0x8a, 0x44, 0x9a, 0x78, 0xea,
+	0x5b, 0x03, 0xf4, 0xaa, 0xa9, 0xdd, 0xda, 0xf2, 0x89, 0x88, 0x7b, 0xd5, 0x03, 0xbb, 0x86, 0x2c,
+	0x78, 0xc0, 0x37, 0x9e, 0x79, 0x4f, 0x47, 0xce, 0x5b, 0x98, 0x9f, 0x86, 0x64, 0x8d, 0x6e, 0xad,
+	0x4f, 0x61, 0xb9, 0xee, 0xda, 0xad, 0xf5, 0x8e, 0x12, 0x39, 0x10, 0x7b, 0x80, 0xcc, 0x04, 0xe3,
+	0x96, 0x8f, 0x44, 0x5c, 0x9c, 0xdd, 0xe5, 0xe5, 0x49, 0xec, 0xe5, 0x1f, 0x19, 0xc9, 0xe3, 0xcc,
+	0x63, 0xfa, 0x9a, 0xf4, 0x97, 0x6b, 0x52, 0x7f, 0xb2, 0xfb, 0xef, 0x01, 0x00, 0x38, 0x02, 0x68,
+	0xb0, 0xe7, 0x01, 0x00, 0x00,
+}
@@ -98,6 +98,202 @@ func (m *VolumeMountResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_VolumeMountResponse proto.InternalMessageInfo
 
+type VolumeUnmountRequest struct {
+	VolumeName           string   `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeUnmountRequest) Reset()         { *m = VolumeUnmountRequest{} }
+func (m *VolumeUnmountRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeUnmountRequest) ProtoMessage()    {}
+func (*VolumeUnmountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{2}
+}
+
+func (m *VolumeUnmountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeUnmountRequest.Unmarshal(m, b)
+}
+func (m *VolumeUnmountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeUnmountRequest.Marshal(b, m, deterministic)
+}
+func (m *VolumeUnmountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeUnmountRequest.Merge(m, src)
+}
+func (m *VolumeUnmountRequest) XXX_Size() int {
+	return xxx_messageInfo_VolumeUnmountRequest.Size(m)
+}
+func (m *VolumeUnmountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeUnmountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeUnmountRequest proto.InternalMessageInfo
+
+func (m *VolumeUnmountRequest) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+type VolumeUnmountResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeUnmountResponse) Reset()         { *m = VolumeUnmountResponse{} }
+func (m *VolumeUnmountResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeUnmountResponse) ProtoMessage()    {}
+func (*VolumeUnmountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{3}
+}
+
+func (m *VolumeUnmountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeUnmountResponse.Unmarshal(m, b)
+}
+func (m *VolumeUnmountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeUnmountResponse.Marshal(b, m, deterministic)
+}
+func (m *VolumeUnmountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeUnmountResponse.Merge(m, src)
+}
+func (m *VolumeUnmountResponse) XXX_Size() int {
+	return xxx_messageInfo_VolumeUnmountResponse.Size(m)
+}
+func (m *VolumeUnmountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeUnmountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeUnmountResponse proto.InternalMessageInfo
+
+type MountInfo struct {
+	VolumeName string `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Exactly 64 bytes long.
+	VolumeID             []byte   `protobuf:"bytes,2,opt,name=volumeID,proto3" json:"volumeID,omitempty"`
+	Mountpoint           string   `protobuf:"bytes,3,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MountInfo) Reset()         { *m = MountInfo{} }
+func (m *MountInfo) String() string { return proto.CompactTextString(m) }
+func (*MountInfo) ProtoMessage()    {}
+func (*MountInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{4}
+}
+
+func (m *MountInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MountInfo.Unmarshal(m, b)
+}
+func (m *MountInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MountInfo.Marshal(b, m, deterministic)
+}
+func (m *MountInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MountInfo.Merge(m, src)
+}
+func (m *MountInfo) XXX_Size() int {
+	return xxx_messageInfo_MountInfo.Size(m)
+}
+func (m *MountInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_MountInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MountInfo proto.InternalMessageInfo
+
+func (m *MountInfo) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *MountInfo) GetVolumeID() []byte {
+	if m != nil {
+		return m.VolumeID
+	}
+	return nil
+}
+
+func (m *MountInfo) GetMountpoint() string {
+	if m != nil {
+		return m.Mountpoint
+	}
+	return ""
+}
+
+type MountListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MountListRequest) Reset()         { *m = MountListRequest{} }
+func (m *MountListRequest) String() string { return proto.CompactTextString(m) }
+func (*MountListRequest) ProtoMessage()    {}
+func (*MountListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{5}
+}
+
+func (m *MountListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MountListRequest.Unmarshal(m, b)
+}
+func (m *MountListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MountListRequest.Marshal(b, m, deterministic)
+}
+func (m *MountListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MountListRequest.Merge(m, src)
+}
+func (m *MountListRequest) XXX_Size() int {
+	return xxx_messageInfo_MountListRequest.Size(m)
+}
+func (m *MountListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MountListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MountListRequest proto.InternalMessageInfo
+
+type MountListResponse struct {
+	Mounts               []*MountInfo `protobuf:"bytes,1,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *MountListResponse) Reset()         { *m = MountListResponse{} }
+func (m *MountListResponse) String() string { return proto.CompactTextString(m) }
+func (*MountListResponse) ProtoMessage()    {}
+func (*MountListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{6}
+}
+
+func (m *MountListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MountListResponse.Unmarshal(m, b)
+}
+func (m *MountListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MountListResponse.Marshal(b, m, deterministic)
+}
+func (m *MountListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MountListResponse.Merge(m, src)
+}
+func (m *MountListResponse) XXX_Size() int {
+	return xxx_messageInfo_MountListResponse.Size(m)
+}
+func (m *MountListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MountListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MountListResponse proto.InternalMessageInfo
+
+func (m *MountListResponse) GetMounts() []*MountInfo {
+	if m != nil {
+		return m.Mounts
+	}
+	return nil
+}
+
 type VolumeCreateRequest struct {
 	VolumeName           string   `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
 	Backend              string   `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"`
@@ -111,7 +307,7 @@ func (m *VolumeCreateRequest) Reset()         { *m = VolumeCreateRequest{} }
 func (m *VolumeCreateRequest) String() string { return proto.CompactTextString(m) }
 func (*VolumeCreateRequest) ProtoMessage()    {}
 func (*VolumeCreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{2}
+	return fileDescriptor_98399f9af98d1082, []int{7}
 }
 
 func (m *VolumeCreateRequest) XXX_Unmarshal(b []byte) error {
@@ -163,7 +359,7 @@ func (m *VolumeCreateResponse) Reset()         { *m = VolumeCreateResponse{} }
 func (m *VolumeCreateResponse) String() string { return proto.CompactTextString(m) }
 func (*VolumeCreateResponse) ProtoMessage()    {}
 func (*VolumeCreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{3}
+	return fileDescriptor_98399f9af98d1082, []int{8}
 }
 
 func (m *VolumeCreateResponse) XXX_Unmarshal(b []byte) error {
@@ -200,7 +396,7 @@ func (m *VolumeConnectRequest) Reset()         { *m = VolumeConnectRequest{} }
 func (m *VolumeConnectRequest) String() string { return proto.CompactTextString(m) }
 func (*VolumeConnectRequest) ProtoMessage()    {}
 func (*VolumeConnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{4}
+	return fileDescriptor_98399f9af98d1082, []int{9}
 }
 
 func (m *VolumeConnectRequest) XXX_Unmarshal(b []byte) error {
@@ -266,7 +462,7 @@ func (m *VolumeConnectResponse) Reset()         { *m = VolumeConnectResponse{} }
 func (m *VolumeConnectResponse) String() string { return proto.CompactTextString(m) }
 func (*VolumeConnectResponse) ProtoMessage()    {}
 func (*VolumeConnectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{5}
+	return fileDescriptor_98399f9af98d1082, []int{10}
 }
 
 func (m *VolumeConnectResponse) XXX_Unmarshal(b []byte) error {
@@ -287,6 +483,235 @@ func (m *VolumeConnectResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_VolumeConnectResponse proto.InternalMessageInfo
 
+type VolumeInviteRequest struct {
+	VolumeName string `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Public key of the server to invite. Must be exactly 32 bytes
+	// long.
+	Pub []byte `protobuf:"bytes,2,opt,name=pub,proto3" json:"pub,omitempty"`
+	// Network locations where the invitee can reach this server.
+	Locations []string `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Storage backend to offer to the invitee, or empty for none.
+	Backend string `protobuf:"bytes,4,opt,name=backend,proto3" json:"backend,omitempty"`
+	// How long the invitation is valid for.
+	ValidSeconds         int64    `protobuf:"varint,5,opt,name=validSeconds,proto3" json:"validSeconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeInviteRequest) Reset()         { *m = VolumeInviteRequest{} }
+func (m *VolumeInviteRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeInviteRequest) ProtoMessage()    {}
+func (*VolumeInviteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{11}
+}
+
+func (m *VolumeInviteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeInviteRequest.Unmarshal(m, b)
+}
+func (m *VolumeInviteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeInviteRequest.Marshal(b, m, deterministic)
+}
+func (m *VolumeInviteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeInviteRequest.Merge(m, src)
+}
+func (m *VolumeInviteRequest) XXX_Size() int {
+	return xxx_messageInfo_VolumeInviteRequest.Size(m)
+}
+func (m *VolumeInviteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeInviteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeInviteRequest proto.InternalMessageInfo
+
+func (m *VolumeInviteRequest) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *VolumeInviteRequest) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *VolumeInviteRequest) GetLocations() []string {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+func (m *VolumeInviteRequest) GetBackend() string {
+	if m != nil {
+		return m.Backend
+	}
+	return ""
+}
+
+func (m *VolumeInviteRequest) GetValidSeconds() int64 {
+	if m != nil {
+		return m.ValidSeconds
+	}
+	return 0
+}
+
+type VolumeInviteResponse struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeInviteResponse) Reset()         { *m = VolumeInviteResponse{} }
+func (m *VolumeInviteResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeInviteResponse) ProtoMessage()    {}
+func (*VolumeInviteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{12}
+}
+
+func (m *VolumeInviteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeInviteResponse.Unmarshal(m, b)
+}
+func (m *VolumeInviteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeInviteResponse.Marshal(b, m, deterministic)
+}
+func (m *VolumeInviteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeInviteResponse.Merge(m, src)
+}
+func (m *VolumeInviteResponse) XXX_Size() int {
+	return xxx_messageInfo_VolumeInviteResponse.Size(m)
+}
+func (m *VolumeInviteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeInviteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeInviteResponse proto.InternalMessageInfo
+
+func (m *VolumeInviteResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type VolumeJoinRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Local name for the volume. If empty, the name used by the
+	// inviter.
+	LocalVolumeName string `protobuf:"bytes,2,opt,name=localVolumeName,proto3" json:"localVolumeName,omitempty"`
+	Backend         string `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"`
+	// Local name for the sharing key. If empty, a name is picked
+	// based on the one used by the inviter.
+	SharingKeyName       string   `protobuf:"bytes,4,opt,name=sharingKeyName,proto3" json:"sharingKeyName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeJoinRequest) Reset()         { *m = VolumeJoinRequest{} }
+func (m *VolumeJoinRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeJoinRequest) ProtoMessage()    {}
+func (*VolumeJoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{13}
+}
+
+func (m *VolumeJoinRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeJoinRequest.Unmarshal(m, b)
+}
+func (m *VolumeJoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeJoinRequest.Marshal(b, m, deterministic)
+}
+func (m *VolumeJoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeJoinRequest.Merge(m, src)
+}
+func (m *VolumeJoinRequest) XXX_Size() int {
+	return xxx_messageInfo_VolumeJoinRequest.Size(m)
+}
+func (m *VolumeJoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeJoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeJoinRequest proto.InternalMessageInfo
+
+func (m *VolumeJoinRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *VolumeJoinRequest) GetLocalVolumeName() string {
+	if m != nil {
+		return m.LocalVolumeName
+	}
+	return ""
+}
+
+func (m *VolumeJoinRequest) GetBackend() string {
+	if m != nil {
+		return m.Backend
+	}
+	return ""
+}
+
+func (m *VolumeJoinRequest) GetSharingKeyName() string {
+	if m != nil {
+		return m.SharingKeyName
+	}
+	return ""
+}
+
+type VolumeJoinResponse struct {
+	VolumeName           string   `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	SharingKeyName       string   `protobuf:"bytes,2,opt,name=sharingKeyName,proto3" json:"sharingKeyName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeJoinResponse) Reset()         { *m = VolumeJoinResponse{} }
+func (m *VolumeJoinResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeJoinResponse) ProtoMessage()    {}
+func (*VolumeJoinResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{14}
+}
+
+func (m *VolumeJoinResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeJoinResponse.Unmarshal(m, b)
+}
+func (m *VolumeJoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeJoinResponse.Marshal(b, m, deterministic)
+}
+func (m *VolumeJoinResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeJoinResponse.Merge(m, src)
+}
+func (m *VolumeJoinResponse) XXX_Size() int {
+	return xxx_messageInfo_VolumeJoinResponse.Size(m)
+}
+func (m *VolumeJoinResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeJoinResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeJoinResponse proto.InternalMessageInfo
+
+func (m *VolumeJoinResponse) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *VolumeJoinResponse) GetSharingKeyName() string {
+	if m != nil {
+		return m.SharingKeyName
+	}
+	return ""
+}
+
 type VolumeStorageAddRequest struct {
 	VolumeName           string   `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -301,7 +726,7 @@ func (m *VolumeStorageAddRequest) Reset()         { *m = VolumeStorageAddRequest
 func (m *VolumeStorageAddRequest) String() string { return proto.CompactTextString(m) }
 func (*VolumeStorageAddRequest) ProtoMessage()    {}
 func (*VolumeStorageAddRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{6}
+	return fileDescriptor_98399f9af98d1082, []int{15}
 }
 
 func (m *VolumeStorageAddRequest) XXX_Unmarshal(b []byte) error {
@@ -360,7 +785,7 @@ func (m *VolumeStorageAddResponse) Reset()         { *m = VolumeStorageAddRespon
 func (m *VolumeStorageAddResponse) String() string { return proto.CompactTextString(m) }
 func (*VolumeStorageAddResponse) ProtoMessage()    {}
 func (*VolumeStorageAddResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{7}
+	return fileDescriptor_98399f9af98d1082, []int{16}
 }
 
 func (m *VolumeStorageAddResponse) XXX_Unmarshal(b []byte) error {
@@ -395,7 +820,7 @@ func (m *VolumeSyncRequest) Reset()         { *m = VolumeSyncRequest{} }
 func (m *VolumeSyncRequest) String() string { return proto.CompactTextString(m) }
 func (*VolumeSyncRequest) ProtoMessage()    {}
 func (*VolumeSyncRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{8}
+	return fileDescriptor_98399f9af98d1082, []int{17}
 }
 
 func (m *VolumeSyncRequest) XXX_Unmarshal(b []byte) error {
@@ -447,7 +872,7 @@ func (m *VolumeSyncResponse) Reset()         { *m = VolumeSyncResponse{} }
 func (m *VolumeSyncResponse) String() string { return proto.CompactTextString(m) }
 func (*VolumeSyncResponse) ProtoMessage()    {}
 func (*VolumeSyncResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_98399f9af98d1082, []int{9}
+	return fileDescriptor_98399f9af98d1082, []int{18}
 }
 
 func (m *VolumeSyncResponse) XXX_Unmarshal(b []byte) error {
@@ -468,17 +893,453 @@ func (m *VolumeSyncResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_VolumeSyncResponse proto.InternalMessageInfo
 
+type VolumeStorageInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Backend              string   `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"`
+	SharingKeyName       string   `protobuf:"bytes,3,opt,name=sharingKeyName,proto3" json:"sharingKeyName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeStorageInfo) Reset()         { *m = VolumeStorageInfo{} }
+func (m *VolumeStorageInfo) String() string { return proto.CompactTextString(m) }
+func (*VolumeStorageInfo) ProtoMessage()    {}
+func (*VolumeStorageInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{19}
+}
+
+func (m *VolumeStorageInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeStorageInfo.Unmarshal(m, b)
+}
+func (m *VolumeStorageInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeStorageInfo.Marshal(b, m, deterministic)
+}
+func (m *VolumeStorageInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeStorageInfo.Merge(m, src)
+}
+func (m *VolumeStorageInfo) XXX_Size() int {
+	return xxx_messageInfo_VolumeStorageInfo.Size(m)
+}
+func (m *VolumeStorageInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeStorageInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeStorageInfo proto.InternalMessageInfo
+
+func (m *VolumeStorageInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VolumeStorageInfo) GetBackend() string {
+	if m != nil {
+		return m.Backend
+	}
+	return ""
+}
+
+func (m *VolumeStorageInfo) GetSharingKeyName() string {
+	if m != nil {
+		return m.SharingKeyName
+	}
+	return ""
+}
+
+type VolumeInfo struct {
+	VolumeName string `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Exactly 64 bytes long.
+	VolumeID []byte               `protobuf:"bytes,2,opt,name=volumeID,proto3" json:"volumeID,omitempty"`
+	Storage  []*VolumeStorageInfo `protobuf:"bytes,3,rep,name=storage,proto3" json:"storage,omitempty"`
+	// Public keys of peers allowed to use this volume. Each is exactly
+	// 32 bytes long.
+	Peers                [][]byte `protobuf:"bytes,4,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeInfo) Reset()         { *m = VolumeInfo{} }
+func (m *VolumeInfo) String() string { return proto.CompactTextString(m) }
+func (*VolumeInfo) ProtoMessage()    {}
+func (*VolumeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{20}
+}
+
+func (m *VolumeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeInfo.Unmarshal(m, b)
+}
+func (m *VolumeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeInfo.Marshal(b, m, deterministic)
+}
+func (m *VolumeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeInfo.Merge(m, src)
+}
+func (m *VolumeInfo) XXX_Size() int {
+	return xxx_messageInfo_VolumeInfo.Size(m)
+}
+func (m *VolumeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeInfo proto.InternalMessageInfo
+
+func (m *VolumeInfo) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *VolumeInfo) GetVolumeID() []byte {
+	if m != nil {
+		return m.VolumeID
+	}
+	return nil
+}
+
+func (m *VolumeInfo) GetStorage() []*VolumeStorageInfo {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+func (m *VolumeInfo) GetPeers() [][]byte {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type VolumeListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeListRequest) Reset()         { *m = VolumeListRequest{} }
+func (m *VolumeListRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeListRequest) ProtoMessage()    {}
+func (*VolumeListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{21}
+}
+
+func (m *VolumeListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeListRequest.Unmarshal(m, b)
+}
+func (m *VolumeListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeListRequest.Marshal(b, m, deterministic)
+}
+func (m *VolumeListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeListRequest.Merge(m, src)
+}
+func (m *VolumeListRequest) XXX_Size() int {
+	return xxx_messageInfo_VolumeListRequest.Size(m)
+}
+func (m *VolumeListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeListRequest proto.InternalMessageInfo
+
+type VolumeListResponse struct {
+	Volumes              []*VolumeInfo `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *VolumeListResponse) Reset()         { *m = VolumeListResponse{} }
+func (m *VolumeListResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeListResponse) ProtoMessage()    {}
+func (*VolumeListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{22}
+}
+
+func (m *VolumeListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeListResponse.Unmarshal(m, b)
+}
+func (m *VolumeListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeListResponse.Marshal(b, m, deterministic)
+}
+func (m *VolumeListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeListResponse.Merge(m, src)
+}
+func (m *VolumeListResponse) XXX_Size() int {
+	return xxx_messageInfo_VolumeListResponse.Size(m)
+}
+func (m *VolumeListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeListResponse proto.InternalMessageInfo
+
+func (m *VolumeListResponse) GetVolumes() []*VolumeInfo {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+type VolumeGetRequest struct {
+	VolumeName           string   `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeGetRequest) Reset()         { *m = VolumeGetRequest{} }
+func (m *VolumeGetRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeGetRequest) ProtoMessage()    {}
+func (*VolumeGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{23}
+}
+
+func (m *VolumeGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeGetRequest.Unmarshal(m, b)
+}
+func (m *VolumeGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeGetRequest.Marshal(b, m, deterministic)
+}
+func (m *VolumeGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeGetRequest.Merge(m, src)
+}
+func (m *VolumeGetRequest) XXX_Size() int {
+	return xxx_messageInfo_VolumeGetRequest.Size(m)
+}
+func (m *VolumeGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeGetRequest proto.InternalMessageInfo
+
+func (m *VolumeGetRequest) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+type VolumeGetResponse struct {
+	Volume               *VolumeInfo `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *VolumeGetResponse) Reset()         { *m = VolumeGetResponse{} }
+func (m *VolumeGetResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeGetResponse) ProtoMessage()    {}
+func (*VolumeGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{24}
+}
+
+func (m *VolumeGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeGetResponse.Unmarshal(m, b)
+}
+func (m *VolumeGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeGetResponse.Marshal(b, m, deterministic)
+}
+func (m *VolumeGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeGetResponse.Merge(m, src)
+}
+func (m *VolumeGetResponse) XXX_Size() int {
+	return xxx_messageInfo_VolumeGetResponse.Size(m)
+}
+func (m *VolumeGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeGetResponse proto.InternalMessageInfo
+
+func (m *VolumeGetResponse) GetVolume() *VolumeInfo {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type VolumeDeleteRequest struct {
+	VolumeName           string   `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeDeleteRequest) Reset()         { *m = VolumeDeleteRequest{} }
+func (m *VolumeDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeDeleteRequest) ProtoMessage()    {}
+func (*VolumeDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{25}
+}
+
+func (m *VolumeDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeDeleteRequest.Unmarshal(m, b)
+}
+func (m *VolumeDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *VolumeDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeDeleteRequest.Merge(m, src)
+}
+func (m *VolumeDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_VolumeDeleteRequest.Size(m)
+}
+func (m *VolumeDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeDeleteRequest proto.InternalMessageInfo
+
+func (m *VolumeDeleteRequest) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+type VolumeDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeDeleteResponse) Reset()         { *m = VolumeDeleteResponse{} }
+func (m *VolumeDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeDeleteResponse) ProtoMessage()    {}
+func (*VolumeDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{26}
+}
+
+func (m *VolumeDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeDeleteResponse.Unmarshal(m, b)
+}
+func (m *VolumeDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeDeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *VolumeDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeDeleteResponse.Merge(m, src)
+}
+func (m *VolumeDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_VolumeDeleteResponse.Size(m)
+}
+func (m *VolumeDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeDeleteResponse proto.InternalMessageInfo
+
+type VolumeRenameRequest struct {
+	VolumeName           string   `protobuf:"bytes,1,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	NewName              string   `protobuf:"bytes,2,opt,name=newName,proto3" json:"newName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeRenameRequest) Reset()         { *m = VolumeRenameRequest{} }
+func (m *VolumeRenameRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeRenameRequest) ProtoMessage()    {}
+func (*VolumeRenameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{27}
+}
+
+func (m *VolumeRenameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeRenameRequest.Unmarshal(m, b)
+}
+func (m *VolumeRenameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeRenameRequest.Marshal(b, m, deterministic)
+}
+func (m *VolumeRenameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeRenameRequest.Merge(m, src)
+}
+func (m *VolumeRenameRequest) XXX_Size() int {
+	return xxx_messageInfo_VolumeRenameRequest.Size(m)
+}
+func (m *VolumeRenameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeRenameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeRenameRequest proto.InternalMessageInfo
+
+func (m *VolumeRenameRequest) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *VolumeRenameRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type VolumeRenameResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeRenameResponse) Reset()         { *m = VolumeRenameResponse{} }
+func (m *VolumeRenameResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeRenameResponse) ProtoMessage()    {}
+func (*VolumeRenameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98399f9af98d1082, []int{28}
+}
+
+func (m *VolumeRenameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeRenameResponse.Unmarshal(m, b)
+}
+func (m *VolumeRenameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeRenameResponse.Marshal(b, m, deterministic)
+}
+func (m *VolumeRenameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeRenameResponse.Merge(m, src)
+}
+func (m *VolumeRenameResponse) XXX_Size() int {
+	return xxx_messageInfo_VolumeRenameResponse.Size(m)
+}
+func (m *VolumeRenameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeRenameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeRenameResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*VolumeMountRequest)(nil), "bazil.control.VolumeMountRequest")
 	proto.RegisterType((*VolumeMountResponse)(nil), "bazil.control.VolumeMountResponse")
+	proto.RegisterType((*VolumeUnmountRequest)(nil), "bazil.control.VolumeUnmountRequest")
+	proto.RegisterType((*VolumeUnmountResponse)(nil), "bazil.control.VolumeUnmountResponse")
+	proto.RegisterType((*MountInfo)(nil), "bazil.control.MountInfo")
+	proto.RegisterType((*MountListRequest)(nil), "bazil.control.MountListRequest")
+	proto.RegisterType((*MountListResponse)(nil), "bazil.control.MountListResponse")
 	proto.RegisterType((*VolumeCreateRequest)(nil), "bazil.control.VolumeCreateRequest")
 	proto.RegisterType((*VolumeCreateResponse)(nil), "bazil.control.VolumeCreateResponse")
 	proto.RegisterType((*VolumeConnectRequest)(nil), "bazil.control.VolumeConnectRequest")
 	proto.RegisterType((*VolumeConnectResponse)(nil), "bazil.control.VolumeConnectResponse")
+	proto.RegisterType((*VolumeInviteRequest)(nil), "bazil.control.VolumeInviteRequest")
+	proto.RegisterType((*VolumeInviteResponse)(nil), "bazil.control.VolumeInviteResponse")
+	proto.RegisterType((*VolumeJoinRequest)(nil), "bazil.control.VolumeJoinRequest")
+	proto.RegisterType((*VolumeJoinResponse)(nil), "bazil.control.VolumeJoinResponse")
 	proto.RegisterType((*VolumeStorageAddRequest)(nil), "bazil.control.VolumeStorageAddRequest")
 	proto.RegisterType((*VolumeStorageAddResponse)(nil), "bazil.control.VolumeStorageAddResponse")
 	proto.RegisterType((*VolumeSyncRequest)(nil), "bazil.control.VolumeSyncRequest")
 	proto.RegisterType((*VolumeSyncResponse)(nil), "bazil.control.VolumeSyncResponse")
+	proto.RegisterType((*VolumeStorageInfo)(nil), "bazil.control.VolumeStorageInfo")
+	proto.RegisterType((*VolumeInfo)(nil), "bazil.control.VolumeInfo")
+	proto.RegisterType((*VolumeListRequest)(nil), "bazil.control.VolumeListRequest")
+	proto.RegisterType((*VolumeListResponse)(nil), "bazil.control.VolumeListResponse")
+	proto.RegisterType((*VolumeGetRequest)(nil), "bazil.control.VolumeGetRequest")
+	proto.RegisterType((*VolumeGetResponse)(nil), "bazil.control.VolumeGetResponse")
+	proto.RegisterType((*VolumeDeleteRequest)(nil), "bazil.control.VolumeDeleteRequest")
+	proto.RegisterType((*VolumeDeleteResponse)(nil), "bazil.control.VolumeDeleteResponse")
+	proto.RegisterType((*VolumeRenameRequest)(nil), "bazil.control.VolumeRenameRequest")
+	proto.RegisterType((*VolumeRenameResponse)(nil), "bazil.control.VolumeRenameResponse")
 }
 
 func init() {
@@ -486,27 +1347,47 @@ func init() {
 }
 
 var fileDescriptor_98399f9af98d1082 = []byte{
-	// 341 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xcf, 0x4e, 0xf2, 0x40,
-	0x14, 0xc5, 0x53, 0xe8, 0xc7, 0x17, 0x6f, 0xfc, 0x3b, 0x82, 0x34, 0x2e, 0x8c, 0xe9, 0xc2, 0xb0,
-	0xa2, 0x26, 0x3e, 0x81, 0xba, 0x34, 0xba, 0x40, 0x43, 0xa2, 0xbb, 0x69, 0xb9, 0x81, 0xc6, 0x32,
-	0xb7, 0x4e, 0xa7, 0x10, 0x7c, 0x09, 0x5f, 0xc6, 0x07, 0x34, 0x9d, 0x19, 0x28, 0x14, 0x12, 0xba,
-	0xbb, 0x3d, 0xe7, 0xce, 0xdc, 0xdf, 0x99, 0xe9, 0xc0, 0x6d, 0xc8, 0xbf, 0xe3, 0xa4, 0x4f, 0x72,
-	0x1c, 0xe8, 0x2a, 0xc8, 0x50, 0xce, 0x50, 0x06, 0x11, 0x09, 0x25, 0x29, 0x09, 0xe6, 0xb1, 0xc4,
-	0x60, 0x46, 0x49, 0x3e, 0xc5, 0x7e, 0x2a, 0x49, 0x11, 0x3b, 0x32, 0x2b, 0x6c, 0x83, 0xff, 0x06,
-	0x6c, 0xa8, 0xed, 0x67, 0xca, 0x85, 0x1a, 0xe0, 0x57, 0x8e, 0x99, 0x62, 0x57, 0x00, 0x66, 0xd1,
-	0x0b, 0x9f, 0xa2, 0xe7, 0x5c, 0x3b, 0xbd, 0x83, 0xc1, 0x9a, 0x52, 0xf8, 0xd3, 0xa2, 0x3f, 0xa5,
-	0x58, 0x28, 0xaf, 0x61, 0xfc, 0x52, 0xf1, 0x3b, 0x70, 0xbe, 0xb1, 0x6b, 0x96, 0x92, 0xc8, 0xd0,
-	0x9f, 0x2f, 0xe5, 0x47, 0x89, 0x5c, 0x61, 0xdd, 0x69, 0x1e, 0xfc, 0x0f, 0x79, 0xf4, 0x89, 0x62,
-	0x64, 0x47, 0x2d, 0x3f, 0xd9, 0x0d, 0x1c, 0x67, 0x13, 0x2e, 0x63, 0x31, 0x7e, 0xc2, 0x85, 0x5e,
-	0xdd, 0xd4, 0x0d, 0x15, 0xd5, 0xbf, 0x80, 0xf6, 0xe6, 0x60, 0x0b, 0xf4, 0xeb, 0xac, 0x0c, 0x12,
-	0x02, 0xa3, 0xd5, 0x01, 0x9c, 0x42, 0x33, 0xcd, 0x43, 0xcd, 0x72, 0x38, 0x28, 0xca, 0x0a, 0x64,
-	0x63, 0x0b, 0xb2, 0x07, 0x27, 0x09, 0x45, 0x3c, 0x19, 0x96, 0x4d, 0x86, 0xa5, 0x2a, 0xaf, 0xc7,
-	0x71, 0xf7, 0xc5, 0xf9, 0xb7, 0x33, 0x4e, 0x17, 0x3a, 0x15, 0x6a, 0x9b, 0xe7, 0xc7, 0x81, 0xae,
-	0x71, 0x5e, 0x15, 0x49, 0x3e, 0xc6, 0xfb, 0xd1, 0xa8, 0xee, 0x29, 0x33, 0x70, 0x45, 0x19, 0x4d,
-	0xd7, 0xeb, 0xa8, 0xcd, 0x7d, 0xa8, 0xee, 0x4e, 0xd4, 0x4b, 0xf0, 0xb6, 0x81, 0x2c, 0xed, 0x3b,
-	0x9c, 0x59, 0x6f, 0x21, 0xa2, 0xba, 0x98, 0xf6, 0x66, 0x1a, 0xe5, 0xcd, 0x30, 0x70, 0x53, 0xae,
-	0x26, 0x96, 0x50, 0xd7, 0x7e, 0x7b, 0xf9, 0x5b, 0x9b, 0xad, 0xcd, 0xc0, 0x87, 0xd6, 0x87, 0x5b,
-	0x3c, 0x88, 0xb0, 0xa5, 0x9f, 0xc2, 0xdd, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd7, 0xa7, 0x69,
-	0x48, 0x3e, 0x03, 0x00, 0x00,
+	// 666 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x96, 0x63, 0x37, 0x25, 0xd3, 0x00, 0x8d, 0x9b, 0x52, 0x53, 0x21, 0x14, 0xed, 0x01, 0xe5,
+	0x80, 0x92, 0xd2, 0x0a, 0x0e, 0xdc, 0x80, 0x02, 0x0a, 0xbf, 0x92, 0x03, 0x95, 0x40, 0x5c, 0x1c,
+	0x67, 0x48, 0xad, 0x3a, 0xbb, 0xc6, 0x76, 0x12, 0x95, 0x97, 0xe0, 0xc4, 0x85, 0x2b, 0xaf, 0xc0,
+	0x03, 0x22, 0xef, 0x8f, 0xff, 0x62, 0x35, 0x69, 0xe1, 0xe6, 0x9d, 0x9d, 0x99, 0xef, 0xfb, 0x66,
+	0xc6, 0x63, 0xc3, 0xc1, 0xc8, 0xf9, 0xee, 0xf9, 0x3d, 0x16, 0x4e, 0xfa, 0xfc, 0xa9, 0x1f, 0x61,
+	0x38, 0xc7, 0xb0, 0xef, 0x32, 0x1a, 0x87, 0xcc, 0xef, 0x2f, 0xbc, 0x10, 0xfb, 0x73, 0xe6, 0xcf,
+	0xa6, 0xd8, 0x0b, 0x42, 0x16, 0x33, 0xf3, 0xba, 0x88, 0x90, 0x0e, 0xe4, 0x03, 0x98, 0x27, 0xfc,
+	0xfa, 0x2d, 0x9b, 0xd1, 0xd8, 0xc6, 0x6f, 0x33, 0x8c, 0x62, 0xf3, 0x2e, 0x80, 0x08, 0x7a, 0xe7,
+	0x4c, 0xd1, 0xd2, 0x3a, 0x5a, 0xb7, 0x61, 0xe7, 0x2c, 0xc9, 0xfd, 0x34, 0xf1, 0x0f, 0x98, 0x47,
+	0x63, 0xab, 0x26, 0xee, 0x33, 0x0b, 0xd9, 0x85, 0x9d, 0x42, 0xd6, 0x28, 0x60, 0x34, 0x42, 0xf2,
+	0x08, 0xda, 0xc2, 0xfc, 0x91, 0x4e, 0x2f, 0x01, 0x47, 0xf6, 0x60, 0xb7, 0x14, 0x27, 0x13, 0x4e,
+	0xa0, 0xc1, 0x11, 0x06, 0xf4, 0x2b, 0x5b, 0x49, 0x7a, 0x1f, 0xae, 0x89, 0xd3, 0xe0, 0x98, 0x53,
+	0x6e, 0xda, 0xe9, 0xb9, 0x24, 0x48, 0x5f, 0x12, 0x64, 0xc2, 0x36, 0x07, 0x7a, 0xe3, 0x45, 0x8a,
+	0x35, 0x79, 0x0e, 0xad, 0x9c, 0x4d, 0x30, 0x32, 0x0f, 0xa0, 0xce, 0xc3, 0x22, 0x4b, 0xeb, 0xe8,
+	0xdd, 0xad, 0x43, 0xab, 0x57, 0xa8, 0x77, 0x2f, 0xa5, 0x6b, 0x4b, 0x3f, 0xb2, 0x50, 0xb5, 0x7a,
+	0x16, 0xa2, 0x13, 0xe3, 0xba, 0x2d, 0xb0, 0x60, 0x73, 0xe4, 0xb8, 0x67, 0x48, 0xc7, 0xb2, 0xfe,
+	0xea, 0x68, 0xde, 0x83, 0x1b, 0xd1, 0xa9, 0x13, 0x7a, 0x74, 0xf2, 0x1a, 0xcf, 0x79, 0xb4, 0xd0,
+	0x53, 0xb2, 0x92, 0x5b, 0xd0, 0x2e, 0x02, 0xcb, 0xa2, 0xfe, 0xd1, 0xd2, 0x0b, 0x46, 0x29, 0xba,
+	0x69, 0x9b, 0xb6, 0x41, 0x0f, 0x66, 0x23, 0xce, 0xa5, 0x69, 0x27, 0x8f, 0x25, 0x92, 0xb5, 0x25,
+	0x92, 0x5d, 0xb8, 0xe9, 0x33, 0xd7, 0xf1, 0x4f, 0x32, 0x27, 0xc1, 0xa5, 0x6c, 0xce, 0xcb, 0x31,
+	0x56, 0xc9, 0xd9, 0xa8, 0x94, 0x93, 0x0e, 0x49, 0xca, 0x5a, 0xea, 0xf9, 0xad, 0xa9, 0x0a, 0x0f,
+	0xe8, 0xdc, 0x5b, 0xbf, 0xc2, 0x52, 0x6e, 0x2d, 0x93, 0x7b, 0x07, 0x1a, 0x09, 0xef, 0xd8, 0x63,
+	0x34, 0xb2, 0xf4, 0x8e, 0xde, 0x6d, 0xd8, 0x99, 0xe1, 0x02, 0x09, 0x04, 0x9a, 0x73, 0xc7, 0xf7,
+	0xc6, 0x43, 0x74, 0x19, 0x1d, 0x47, 0x5c, 0x80, 0x6e, 0x17, 0x6c, 0xe4, 0x3e, 0xb4, 0x8b, 0x24,
+	0xe5, 0x40, 0xb5, 0x61, 0x23, 0x66, 0x67, 0x48, 0x25, 0x41, 0x71, 0x20, 0x3f, 0x35, 0x68, 0x09,
+	0xf7, 0x57, 0xcc, 0xa3, 0x4a, 0x51, 0xa5, 0x6f, 0x55, 0x13, 0x6a, 0x2b, 0x9b, 0xa0, 0xaf, 0x6a,
+	0x82, 0x51, 0xd9, 0x84, 0x2f, 0x60, 0xe6, 0x69, 0x49, 0x0d, 0xab, 0x2a, 0xbd, 0x9c, 0xbd, 0x56,
+	0x99, 0xfd, 0x87, 0x06, 0x7b, 0x22, 0xfd, 0x30, 0x66, 0xa1, 0x33, 0xc1, 0x27, 0xe3, 0xf1, 0xba,
+	0xdd, 0x34, 0xc1, 0xa0, 0x59, 0x66, 0x83, 0xfe, 0x1f, 0xbd, 0xfb, 0x60, 0x2d, 0x13, 0x92, 0x73,
+	0xf7, 0x49, 0xb5, 0x68, 0x78, 0x4e, 0xdd, 0xab, 0x0f, 0x9d, 0x09, 0x46, 0xe0, 0xc4, 0xa7, 0x92,
+	0x21, 0x7f, 0x26, 0x6d, 0x30, 0xf3, 0xa9, 0x25, 0xa0, 0x07, 0xad, 0x02, 0x19, 0xbe, 0x15, 0x95,
+	0x6e, 0xad, 0x5a, 0xf7, 0x15, 0x77, 0xc7, 0x2f, 0x0d, 0x40, 0x8d, 0xeb, 0x3f, 0xae, 0xde, 0xc7,
+	0xb0, 0x19, 0x09, 0xbe, 0xfc, 0x95, 0xda, 0x3a, 0xec, 0x94, 0x56, 0xe6, 0x92, 0x26, 0x5b, 0x05,
+	0x24, 0x03, 0x1f, 0x20, 0x86, 0x91, 0x65, 0x74, 0xf4, 0x6e, 0xd3, 0x16, 0x07, 0xb2, 0xa3, 0xea,
+	0x90, 0xdf, 0xd6, 0x03, 0x30, 0xf3, 0x46, 0x39, 0x99, 0x47, 0xb0, 0x29, 0x88, 0xa8, 0x7d, 0x7d,
+	0xbb, 0x12, 0x5c, 0xa0, 0x4a, 0x4f, 0x72, 0x08, 0xdb, 0xc2, 0xfc, 0x12, 0xd7, 0xfe, 0x84, 0xbd,
+	0x80, 0x56, 0x2e, 0x46, 0xa2, 0x3f, 0x80, 0xba, 0x70, 0xe1, 0x01, 0x17, 0x82, 0x4b, 0x47, 0xf2,
+	0x50, 0xed, 0xb2, 0x63, 0xf4, 0x71, 0xed, 0x5d, 0x96, 0xed, 0x7a, 0x15, 0x26, 0x47, 0xe6, 0xbd,
+	0x4a, 0x67, 0x63, 0x32, 0x19, 0x97, 0xf8, 0xf8, 0x50, 0x5c, 0xe4, 0xde, 0x54, 0x75, 0xcc, 0x80,
+	0x54, 0x42, 0x01, 0xf4, 0xb4, 0xfe, 0xd9, 0x48, 0xfe, 0x45, 0x46, 0x75, 0xfe, 0x17, 0x72, 0xf4,
+	0x77, 0x00, 0xf6, 0x92, 0x16, 0x99, 0xb9, 0x08, 0x00, 0x00,
 }
@@ -0,0 +1,334 @@
+// Package discovery finds peers on the local network.
+//
+// Servers periodically announce their public key and peer port to a
+// UDP multicast group. Announcements are signed with the announcing
+// server's key, and carry the time they were made. When a fresh
+// announcement from a known peer is heard, its address becomes the
+// discovered network location of that peer, replacing any location
+// discovered earlier.
+//
+// Announcements are only a hint on where to connect to; the peer
+// connection itself is authenticated as usual.
+package discovery
+
+import (
+	"errors"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/discovery/wire"
+	"bazil.org/bazil/tokens"
+	"github.com/agl/ed25519"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/ipv4"
+)
+
+// DefaultInterval is how often the server announces itself, unless
+// configured otherwise.
+const DefaultInterval = 30 * time.Second
+
+// LocationPriority is the priority given to locations learned via
+// discovery. Manually added locations default to priority 0, and are
+// thus tried first.
+const LocationPriority = 100
+
+// Maximum size of an announcement packet we care to receive.
+const maxPacketSize = 1024
+
+// Announcements made longer ago than this, or this far in the
+// future, are ignored. Allows for some clock skew between servers.
+const maxAnnouncementAge = 2 * time.Minute
+
+type Config struct {
+	// Multicast group to announce to and listen on. If nil, uses
+	// tokens.DiscoveryGroup.
+	Group *net.UDPAddr
+	// Network interface to use. If nil, the system default is used.
+	Interface *net.Interface
+	// How often to announce. If zero, DefaultInterval is used.
+	Interval time.Duration
+}
+
+type Discovery struct {
+	app      *server.App
+	port     int
+	group    *net.UDPAddr
+	interval time.Duration
+
+	recv *net.UDPConn
+	send *net.UDPConn
+
+	stop      chan struct{}
+	closeOnce sync.Once
+
+	// Time of the last announcement accepted from each known peer.
+	// Only used by the goroutine running Serve.
+	seen map[peer.PublicKey]int64
+}
+
+// New creates a discovery service announcing that peers can connect
+// to this server at the given TCP port. Caller is expected to call
+// Discovery.Serve to actually announce and listen, and
+// Discovery.Close to clean up.
+func New(app *server.App, port int, config *Config) (*Discovery, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	group := config.Group
+	if group == nil {
+		g, err := net.ResolveUDPAddr("udp4", tokens.DiscoveryGroup)
+		if err != nil {
+			return nil, err
+		}
+		group = g
+	}
+	if !group.IP.IsMulticast() {
+		return nil, errors.New("discovery group is not a multicast address")
+	}
+	interval := config.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	recv, err := net.ListenMulticastUDP("udp4", config.Interface, group)
+	if err != nil {
+		return nil, err
+	}
+	send, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		recv.Close()
+		return nil, err
+	}
+	if config.Interface != nil {
+		p := ipv4.NewPacketConn(send)
+		if err := p.SetMulticastInterface(config.Interface); err != nil {
+			recv.Close()
+			send.Close()
+			return nil, err
+		}
+	}
+
+	d := &Discovery{
+		app:      app,
+		port:     port,
+		group:    group,
+		interval: interval,
+		recv:     recv,
+		send:     send,
+		stop:     make(chan struct{}),
+		seen:     make(map[peer.PublicKey]int64),
+	}
+	return d, nil
+}
+
+func (d *Discovery) Close() {
+	d.closeOnce.Do(func() {
+		close(d.stop)
+		_ = d.recv.Close()
+		_ = d.send.Close()
+	})
+}
+
+// Serve announces this server and listens for announcements from
+// others, until Close is called.
+func (d *Discovery) Serve() error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.announce()
+	}()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, from, err := d.recv.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-d.stop:
+				return nil
+			default:
+			}
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				continue
+			}
+			d.Close()
+			return err
+		}
+		d.handle(buf[:n], from)
+	}
+}
+
+func (d *Discovery) announcement(now time.Time) ([]byte, error) {
+	keys := d.app.Keys.Sign
+	a := &wire.Announcement{
+		Pub:  keys.Pub[:],
+		Port: uint32(d.port),
+		Time: now.UnixNano(),
+	}
+	buf, err := proto.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	sig := ed25519.Sign(keys.Priv, buf)
+	signed := &wire.SignedAnnouncement{
+		Announcement: buf,
+		Signature:    sig[:],
+	}
+	return proto.Marshal(signed)
+}
+
+func (d *Discovery) announce() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		// signed anew every time, with the current time
+		msg, err := d.announcement(time.Now())
+		if err != nil {
+			log.Printf("discovery announce error: %v", err)
+		} else if _, err := d.send.WriteToUDP(msg, d.group); err != nil {
+			select {
+			case <-d.stop:
+				return
+			default:
+			}
+			log.Printf("discovery announce error: %v", err)
+		}
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Discovery) handle(buf []byte, from *net.UDPAddr) {
+	var signed wire.SignedAnnouncement
+	if err := proto.Unmarshal(buf, &signed); err != nil {
+		return
+	}
+	if len(signed.Signature) != ed25519.SignatureSize {
+		return
+	}
+	var a wire.Announcement
+	if err := proto.Unmarshal(signed.Announcement, &a); err != nil {
+		return
+	}
+	var pub peer.PublicKey
+	if err := pub.UnmarshalBinary(a.Pub); err != nil {
+		return
+	}
+	if pub == *(*peer.PublicKey)(d.app.Keys.Sign.Pub) {
+		// hearing ourselves
+		return
+	}
+	if a.Port == 0 || a.Port > 65535 {
+		return
+	}
+	var sig [ed25519.SignatureSize]byte
+	copy(sig[:], signed.Signature)
+	if !ed25519.Verify((*[ed25519.PublicKeySize]byte)(&pub), signed.Announcement, &sig) {
+		return
+	}
+
+	// The source address is not authenticated, so anyone could
+	// resend a captured announcement from elsewhere. Only accept
+	// recent ones, and each one once.
+	age := time.Since(time.Unix(0, a.Time))
+	if age > maxAnnouncementAge || age < -maxAnnouncementAge {
+		return
+	}
+	if last, ok := d.seen[pub]; ok && a.Time <= last {
+		return
+	}
+
+	addr := net.JoinHostPort(from.IP.String(), strconv.Itoa(int(a.Port)))
+	known, err := d.learn(&pub, addr)
+	if err != nil {
+		log.Printf("discovery: cannot remember location of peer: %v", err)
+		return
+	}
+	if known {
+		// only for known peers, so strangers cannot fill the map
+		d.seen[pub] = a.Time
+	}
+}
+
+// learn makes addr the discovered location of pub, if pub is a known
+// peer. Locations with other priorities than LocationPriority were
+// added by hand, and are left untouched. Locations discovered
+// earlier are removed, so a peer has at most one.
+func (d *Discovery) learn(pub *peer.PublicKey, addr string) (known bool, err error) {
+	// changes returns the discovered locations to remove, and
+	// whether addr needs to be added
+	changes := func(p *db.Peer) (remove []string, add bool) {
+		add = true
+		c := p.Locations().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			a := item.Addr()
+			if a == addr {
+				add = false
+				continue
+			}
+			if item.Priority() == LocationPriority {
+				remove = append(remove, a)
+			}
+		}
+		return remove, add
+	}
+
+	needWrite := false
+	check := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(pub)
+		if err == db.ErrPeerNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		known = true
+		remove, add := changes(p)
+		needWrite = len(remove) > 0 || add
+		return nil
+	}
+	if err := d.app.DB.View(check); err != nil {
+		return false, err
+	}
+	if !needWrite {
+		// nothing to do; avoid write transactions for every packet
+		return known, nil
+	}
+
+	update := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(pub)
+		if err == db.ErrPeerNotFound {
+			// removed in the meanwhile
+			known = false
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		remove, add := changes(p)
+		locs := p.Locations()
+		for _, a := range remove {
+			if err := locs.Remove(a); err != nil {
+				return err
+			}
+		}
+		if add {
+			return locs.Add(addr, LocationPriority)
+		}
+		return nil
+	}
+	if err := d.app.DB.Update(update); err != nil {
+		return false, err
+	}
+	return known, nil
+}
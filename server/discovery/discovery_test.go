@@ -0,0 +1,125 @@
+package discovery_test
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"bazil.org/bazil/db"
+	bazfstestutil "bazil.org/bazil/fs/fstestutil"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/discovery"
+	"bazil.org/bazil/util/tempdir"
+)
+
+// testConfig returns a config using the loopback interface and a
+// port unlikely to collide with other tests or real servers.
+func testConfig(t testing.TB) *discovery.Config {
+	lo, err := net.InterfaceByName("lo")
+	if err != nil {
+		t.Skipf("no loopback interface: %v", err)
+	}
+	l, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.LocalAddr().(*net.UDPAddr).Port
+	l.Close()
+
+	conf := &discovery.Config{
+		Group:     &net.UDPAddr{IP: net.IPv4(239, 255, 42, 11), Port: port},
+		Interface: lo,
+		Interval:  20 * time.Millisecond,
+	}
+	return conf
+}
+
+func TestDiscovery(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+	setup1 := func(tx *db.Tx) error {
+		if _, err := tx.Peers().Make(pub2); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := app1.DB.Update(setup1); err != nil {
+		t.Fatalf("app1 setup: %v", err)
+	}
+
+	conf := testConfig(t)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	d1, err := discovery.New(app1, 1111, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d1.Close()
+	d2, err := discovery.New(app2, 2222, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d2.Close()
+	for _, d := range []*discovery.Discovery{d1, d2} {
+		wg.Add(1)
+		go func(d *discovery.Discovery) {
+			defer wg.Done()
+			if err := d.Serve(); err != nil {
+				t.Errorf("discovery serve: %v", err)
+			}
+		}(d)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var locs []string
+		check := func(tx *db.Tx) error {
+			p, err := tx.Peers().Get(pub2)
+			if err != nil {
+				return err
+			}
+			locs = p.Locations().List()
+			return nil
+		}
+		if err := app1.DB.View(check); err != nil {
+			t.Fatal(err)
+		}
+		if len(locs) > 0 {
+			_, port, err := net.SplitHostPort(locs[0])
+			if err != nil {
+				t.Fatalf("bad location: %v", err)
+			}
+			if g, e := port, strconv.Itoa(2222); g != e {
+				t.Errorf("wrong port discovered: %v != %v", g, e)
+			}
+			if g, e := len(locs), 1; g != e {
+				t.Errorf("wrong number of locations: %v != %v: %q", g, e, locs)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("peer was not discovered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// app2 does not know app1, so must not have learned anything
+	check2 := func(tx *db.Tx) error {
+		c := tx.Peers().Cursor()
+		if p := c.First(); p != nil {
+			t.Errorf("unknown peer was added: %v", p.Pub())
+		}
+		return nil
+	}
+	if err := app2.DB.View(check2); err != nil {
+		t.Fatal(err)
+	}
+}
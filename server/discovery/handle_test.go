@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"bazil.org/bazil/db"
+	bazfstestutil "bazil.org/bazil/fs/fstestutil"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestHandleReplay(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+	setup := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(pub2)
+		if err != nil {
+			return err
+		}
+		return p.Locations().Add("manual.example.com:1234", 0)
+	}
+	if err := app1.DB.Update(setup); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	d1 := &Discovery{app: app1, seen: make(map[peer.PublicKey]int64)}
+	d2 := &Discovery{app: app2, port: 2222}
+	locations := func() []string {
+		var locs []string
+		get := func(tx *db.Tx) error {
+			p, err := tx.Peers().Get(pub2)
+			if err != nil {
+				return err
+			}
+			locs = p.Locations().List()
+			return nil
+		}
+		if err := app1.DB.View(get); err != nil {
+			t.Fatal(err)
+		}
+		return locs
+	}
+	announce := func(when time.Time, ip string) {
+		msg, err := d2.announcement(when)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d1.handle(msg, &net.UDPAddr{IP: net.ParseIP(ip), Port: 9999})
+	}
+	check := func(want ...string) {
+		t.Helper()
+		if g := locations(); !reflect.DeepEqual(g, want) {
+			t.Errorf("wrong locations: %q != %q", g, want)
+		}
+	}
+
+	now := time.Now()
+	first, err := d2.announcement(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d1.handle(first, &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 9999})
+	check("manual.example.com:1234", "192.0.2.1:2222")
+
+	// replayed from elsewhere
+	d1.handle(first, &net.UDPAddr{IP: net.ParseIP("192.0.2.66"), Port: 9999})
+	check("manual.example.com:1234", "192.0.2.1:2222")
+
+	// too old, or from the future
+	announce(now.Add(-time.Hour), "192.0.2.66")
+	announce(now.Add(time.Hour), "192.0.2.66")
+	check("manual.example.com:1234", "192.0.2.1:2222")
+
+	// a newer announcement replaces the discovered location
+	announce(now.Add(time.Second), "192.0.2.2")
+	check("manual.example.com:1234", "192.0.2.2:2222")
+}
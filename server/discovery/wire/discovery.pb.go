@@ -0,0 +1,158 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bazil.org/bazil/server/discovery/wire/discovery.proto
+
+package wire
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Announcement struct {
+	// Public key of the announcing server. Must be exactly 32 bytes
+	// long.
+	Pub []byte `protobuf:"bytes,1,opt,name=pub,proto3" json:"pub,omitempty"`
+	// TCP port the server accepts peer connections on. The host is
+	// taken from the source address of the packet.
+	Port uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// When the announcement was made, in nanoseconds since the Unix
+	// epoch. Stale and repeated announcements are ignored, so they
+	// cannot be replayed from elsewhere.
+	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Announcement) Reset()         { *m = Announcement{} }
+func (m *Announcement) String() string { return proto.CompactTextString(m) }
+func (*Announcement) ProtoMessage()    {}
+func (*Announcement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_112bcf2daa675400, []int{0}
+}
+
+func (m *Announcement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Announcement.Unmarshal(m, b)
+}
+func (m *Announcement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Announcement.Marshal(b, m, deterministic)
+}
+func (m *Announcement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Announcement.Merge(m, src)
+}
+func (m *Announcement) XXX_Size() int {
+	return xxx_messageInfo_Announcement.Size(m)
+}
+func (m *Announcement) XXX_DiscardUnknown() {
+	xxx_messageInfo_Announcement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Announcement proto.InternalMessageInfo
+
+func (m *Announcement) GetPub() []byte {
+	if m != nil {
+		return m.Pub
+	}
+	return nil
+}
+
+func (m *Announcement) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Announcement) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type SignedAnnouncement struct {
+	// Marshaled Announcement.
+	Announcement []byte `protobuf:"bytes,1,opt,name=announcement,proto3" json:"announcement,omitempty"`
+	// Signature of announcement, made with the key in it. Must be
+	// exactly 64 bytes long.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedAnnouncement) Reset()         { *m = SignedAnnouncement{} }
+func (m *SignedAnnouncement) String() string { return proto.CompactTextString(m) }
+func (*SignedAnnouncement) ProtoMessage()    {}
+func (*SignedAnnouncement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_112bcf2daa675400, []int{1}
+}
+
+func (m *SignedAnnouncement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedAnnouncement.Unmarshal(m, b)
+}
+func (m *SignedAnnouncement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedAnnouncement.Marshal(b, m, deterministic)
+}
+func (m *SignedAnnouncement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedAnnouncement.Merge(m, src)
+}
+func (m *SignedAnnouncement) XXX_Size() int {
+	return xxx_messageInfo_SignedAnnouncement.Size(m)
+}
+func (m *SignedAnnouncement) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedAnnouncement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedAnnouncement proto.InternalMessageInfo
+
+func (m *SignedAnnouncement) GetAnnouncement() []byte {
+	if m != nil {
+		return m.Announcement
+	}
+	return nil
+}
+
+func (m *SignedAnnouncement) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Announcement)(nil), "bazil.discovery.Announcement")
+	proto.RegisterType((*SignedAnnouncement)(nil), "bazil.discovery.SignedAnnouncement")
+}
+
+func init() {
+	proto.RegisterFile("bazil.org/bazil/server/discovery/wire/discovery.proto", fileDescriptor_112bcf2daa675400)
+}
+
+var fileDescriptor_112bcf2daa675400 = []byte{
+	// 186 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4d, 0x4a, 0xac, 0xca,
+	0xcc, 0xd1, 0xcb, 0x2f, 0x4a, 0xd7, 0x07, 0xb3, 0xf4, 0x8b, 0x53, 0x8b, 0xca, 0x52, 0x8b, 0xf4,
+	0x53, 0x32, 0x8b, 0x93, 0xf3, 0xcb, 0x52, 0x8b, 0x2a, 0xf5, 0xcb, 0x33, 0x8b, 0x52, 0x11, 0x5c,
+	0xbd, 0x82, 0xa2, 0xfc, 0x92, 0x7c, 0x21, 0x7e, 0x88, 0x36, 0xb8, 0xb0, 0x92, 0x07, 0x17, 0x8f,
+	0x63, 0x5e, 0x5e, 0x7e, 0x69, 0x5e, 0x72, 0x6a, 0x6e, 0x6a, 0x5e, 0x89, 0x90, 0x00, 0x17, 0x73,
+	0x41, 0x69, 0x92, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0x4f, 0x10, 0x88, 0x29, 0x24, 0xc4, 0xc5, 0x52,
+	0x90, 0x5f, 0x54, 0x22, 0xc1, 0xa4, 0xc0, 0xa8, 0xc1, 0x1b, 0x04, 0x66, 0x83, 0xc4, 0x4a, 0x32,
+	0x73, 0x53, 0x25, 0x98, 0x15, 0x18, 0x35, 0x98, 0x83, 0xc0, 0x6c, 0xa5, 0x30, 0x2e, 0xa1, 0xe0,
+	0xcc, 0xf4, 0xbc, 0xd4, 0x14, 0x14, 0xf3, 0x94, 0xb8, 0x78, 0x12, 0x91, 0xf8, 0x50, 0x83, 0x51,
+	0xc4, 0x84, 0x64, 0xb8, 0x38, 0x8b, 0x33, 0xd3, 0xf3, 0x12, 0x4b, 0x4a, 0x8b, 0x52, 0xc1, 0xd6,
+	0xf0, 0x04, 0x21, 0x04, 0x9c, 0xd8, 0xa2, 0x58, 0x40, 0x5e, 0x49, 0x62, 0x03, 0xfb, 0xc0, 0x18,
+	0x30, 0x00, 0x71, 0x51, 0xec, 0x19, 0xfa, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net"
+	nethttp "net/http"
+
+	"bazil.org/bazil/server"
+)
+
+// Metrics serves the metrics of the server in the Prometheus text
+// format, at /metrics. It does no authentication, and is meant to
+// be listened on locally only.
+type Metrics struct {
+	app      *server.App
+	listener net.Listener
+}
+
+func NewMetrics(app *server.App, listener net.Listener) (*Metrics, error) {
+	m := &Metrics{
+		app:      app,
+		listener: listener,
+	}
+	return m, nil
+}
+
+func (m *Metrics) Close() {
+	_ = m.listener.Close()
+}
+
+func (m *Metrics) Serve() error {
+	mux := nethttp.NewServeMux()
+	mux.Handle("/metrics", m.app.Metrics())
+	srv := &nethttp.Server{
+		Handler: mux,
+	}
+	return srv.Serve(m.listener)
+}
+
+func (m *Metrics) Addr() net.Addr {
+	return m.listener.Addr()
+}
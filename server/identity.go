@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/util/passbox"
+	"github.com/agl/ed25519"
+)
+
+// PEM block type of exported identities.
+const identityArmorType = "BAZIL IDENTITY"
+
+var ErrIdentityCorrupt = errors.New("identity key is corrupt")
+
+// ExportIdentity encrypts the private key of the server identity with
+// the passphrase, and encodes it as PEM-armored text. The result can
+// be passed to ImportIdentity.
+func ExportIdentity(keys *CryptoKeys, passphrase []byte) ([]byte, error) {
+	headers := map[string]string{
+		"Public-Key": (*peer.PublicKey)(keys.Sign.Pub).String(),
+	}
+	return passbox.Seal(identityArmorType, headers, keys.Sign.Priv[:], passphrase)
+}
+
+// ImportIdentity decrypts an identity exported with ExportIdentity.
+// The returned key can be passed to the Identity option of New.
+func ImportIdentity(armored []byte, passphrase []byte) (*[ed25519.PrivateKeySize]byte, error) {
+	plain, err := passbox.Open(identityArmorType, armored, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(plain) != ed25519.PrivateKeySize {
+		return nil, ErrIdentityCorrupt
+	}
+	var priv [ed25519.PrivateKeySize]byte
+	copy(priv[:], plain)
+
+	// make sure the public half matches the private half
+	msg := []byte("bazil identity check")
+	sig := ed25519.Sign(&priv, msg)
+	if !ed25519.Verify(extractEd25519Pubkey(&priv), msg, sig) {
+		return nil, ErrIdentityCorrupt
+	}
+	return &priv, nil
+}
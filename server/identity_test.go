@@ -0,0 +1,91 @@
+package server_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/util/tempdir"
+	"github.com/agl/ed25519"
+)
+
+func TestIdentityExportImport(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Subdir("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	armored, err := server.ExportIdentity(app.Keys, []byte("s3kr1t"))
+	pub := *app.Keys.Sign.Pub
+	app.Close()
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	if _, err := server.ImportIdentity(armored, []byte("wrong")); err == nil {
+		t.Fatal("expected error with wrong passphrase")
+	}
+	priv, err := server.ImportIdentity(armored, []byte("s3kr1t"))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	// fresh data directory
+	dirB := tmp.Subdir("b")
+	app2, err := server.New(dirB, server.Identity(priv, false))
+	if err != nil {
+		t.Fatalf("new with identity failed: %v", err)
+	}
+	if g, e := *app2.Keys.Sign.Pub, pub; g != e {
+		t.Errorf("wrong identity: %x != %x", g, e)
+	}
+	app2.Close()
+
+	// identity is remembered
+	app2, err = server.New(dirB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := *app2.Keys.Sign.Pub, pub; g != e {
+		t.Errorf("identity not persisted: %x != %x", g, e)
+	}
+	app2.Close()
+}
+
+func TestIdentityNoOverwrite(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := *app.Keys.Sign.Pub
+	app.Close()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.New(tmp.Path, server.Identity(priv, false)); err != server.ErrIdentityExists {
+		t.Fatalf("expected ErrIdentityExists, got %v", err)
+	}
+	app, err = server.New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := *app.Keys.Sign.Pub, orig; g != e {
+		t.Errorf("identity changed on refused import: %x != %x", g, e)
+	}
+	app.Close()
+
+	app, err = server.New(tmp.Path, server.Identity(priv, true))
+	if err != nil {
+		t.Fatalf("replacing identity failed: %v", err)
+	}
+	defer app.Close()
+	if *app.Keys.Sign.Priv != *priv {
+		t.Errorf("identity not replaced")
+	}
+}
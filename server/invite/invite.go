@@ -0,0 +1,173 @@
+// Package invite implements tokens that let a server join a volume
+// hosted by another server.
+//
+// An invitation is created by the server hosting the volume, for a
+// specific invitee public key. It is signed by the inviter, carries
+// the sharing key secret encrypted for the invitee, and expires
+// after a while. Invitations are exchanged out of band as text.
+package invite
+
+import (
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/kv/untrusted"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/invite/wire"
+	"github.com/agl/ed25519"
+	"github.com/agl/ed25519/extra25519"
+	"github.com/golang/protobuf/proto"
+	"github.com/tv42/zbase32"
+	"golang.org/x/crypto/nacl/box"
+)
+
+var (
+	ErrMalformed    = errors.New("malformed invitation")
+	ErrBadSignature = errors.New("invitation signature does not verify")
+	ErrExpired      = errors.New("invitation has expired")
+	ErrNotForUs     = errors.New("invitation is for another server")
+)
+
+type Invitation struct {
+	VolumeID       db.VolumeID
+	VolumeName     string
+	Inviter        peer.PublicKey
+	Invitee        peer.PublicKey
+	Locations      []string
+	SharingKeyName string
+	SharingKey     [32]byte
+	// Padding mode used with the sharing key, empty for none.
+	SharingKeyPadding string
+	Expires           time.Time
+}
+
+const nonceSize = 24
+
+// boxKey converts an ed25519 public key to the matching curve25519
+// key used for boxing.
+func boxKey(pub *peer.PublicKey) (*[32]byte, error) {
+	var out [32]byte
+	if !extra25519.PublicKeyToCurve25519(&out, (*[ed25519.PublicKeySize]byte)(pub)) {
+		return nil, errors.New("cannot convert public key for encryption")
+	}
+	return &out, nil
+}
+
+// Seal signs the invitation with the given keys, and encodes it as
+// text. inv.Inviter must be the public key of keys.
+func Seal(inv *Invitation, keys *server.CryptoKeys) (string, error) {
+	if inv.Inviter != *(*peer.PublicKey)(keys.Sign.Pub) {
+		return "", errors.New("invitation must be sealed by the inviter")
+	}
+	peerBox, err := boxKey(&inv.Invitee)
+	if err != nil {
+		return "", err
+	}
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+	boxed := box.Seal(nonce[:], inv.SharingKey[:], &nonce, peerBox, keys.Box.Priv)
+
+	msg := &wire.Invitation{
+		VolumeID:          inv.VolumeID[:],
+		VolumeName:        inv.VolumeName,
+		Inviter:           inv.Inviter[:],
+		Invitee:           inv.Invitee[:],
+		Locations:         inv.Locations,
+		SharingKeyName:    inv.SharingKeyName,
+		SharingKey:        boxed,
+		Expires:           inv.Expires.Unix(),
+		SharingKeyPadding: inv.SharingKeyPadding,
+	}
+	buf, err := proto.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	sig := ed25519.Sign(keys.Sign.Priv, buf)
+	signed := &wire.SignedInvitation{
+		Invitation: buf,
+		Signature:  sig[:],
+	}
+	out, err := proto.Marshal(signed)
+	if err != nil {
+		return "", err
+	}
+	return zbase32.EncodeToString(out), nil
+}
+
+// Open decodes and verifies an invitation addressed to the owner of
+// keys, decrypting the sharing key.
+//
+// The signature only proves the invitation was made by the key it
+// names as the inviter; the caller is trusting whoever handed over
+// the token.
+func Open(token string, keys *server.CryptoKeys, now time.Time) (*Invitation, error) {
+	buf, err := zbase32.DecodeString(token)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var signed wire.SignedInvitation
+	if err := proto.Unmarshal(buf, &signed); err != nil {
+		return nil, ErrMalformed
+	}
+	var msg wire.Invitation
+	if err := proto.Unmarshal(signed.Invitation, &msg); err != nil {
+		return nil, ErrMalformed
+	}
+
+	inv := &Invitation{
+		VolumeName:        msg.VolumeName,
+		Locations:         msg.Locations,
+		SharingKeyName:    msg.SharingKeyName,
+		SharingKeyPadding: msg.SharingKeyPadding,
+		Expires:           time.Unix(msg.Expires, 0),
+	}
+	if _, err := untrusted.ParsePadding(inv.SharingKeyPadding); err != nil {
+		return nil, ErrMalformed
+	}
+	if err := inv.VolumeID.UnmarshalBinary(msg.VolumeID); err != nil {
+		return nil, ErrMalformed
+	}
+	if err := inv.Inviter.UnmarshalBinary(msg.Inviter); err != nil {
+		return nil, ErrMalformed
+	}
+	if err := inv.Invitee.UnmarshalBinary(msg.Invitee); err != nil {
+		return nil, ErrMalformed
+	}
+
+	if len(signed.Signature) != ed25519.SignatureSize {
+		return nil, ErrBadSignature
+	}
+	var sig [ed25519.SignatureSize]byte
+	copy(sig[:], signed.Signature)
+	if !ed25519.Verify((*[ed25519.PublicKeySize]byte)(&inv.Inviter), signed.Invitation, &sig) {
+		return nil, ErrBadSignature
+	}
+
+	if inv.Invitee != *(*peer.PublicKey)(keys.Sign.Pub) {
+		return nil, ErrNotForUs
+	}
+	if now.After(inv.Expires) {
+		return nil, ErrExpired
+	}
+
+	if len(msg.SharingKey) < nonceSize {
+		return nil, ErrMalformed
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], msg.SharingKey)
+	peerBox, err := boxKey(&inv.Inviter)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	secret, ok := box.Open(nil, msg.SharingKey[nonceSize:], &nonce, peerBox, keys.Box.Priv)
+	if !ok || len(secret) != len(inv.SharingKey) {
+		return nil, ErrMalformed
+	}
+	copy(inv.SharingKey[:], secret)
+	return inv, nil
+}
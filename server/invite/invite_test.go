@@ -0,0 +1,108 @@
+package invite_test
+
+import (
+	"testing"
+	"time"
+
+	"bazil.org/bazil/db"
+	bazfstestutil "bazil.org/bazil/fs/fstestutil"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server/invite"
+	"bazil.org/bazil/util/tempdir"
+	"github.com/tv42/zbase32"
+)
+
+func TestRoundtrip(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	now := time.Now()
+	inv := &invite.Invitation{
+		VolumeID:       db.VolumeID{1, 2, 3},
+		VolumeName:     "foo",
+		Inviter:        *(*peer.PublicKey)(app1.Keys.Sign.Pub),
+		Invitee:        *(*peer.PublicKey)(app2.Keys.Sign.Pub),
+		Locations:      []string{"192.0.2.1:1234"},
+		SharingKeyName: "bar",
+		SharingKey:     [32]byte{4, 5, 6},
+		Expires:        now.Add(time.Hour),
+	}
+	token, err := invite.Seal(inv, app1.Keys)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	got, err := invite.Open(token, app2.Keys, now)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if g, e := got.VolumeID, inv.VolumeID; g != e {
+		t.Errorf("wrong volume ID: %v != %v", g, e)
+	}
+	if g, e := got.VolumeName, inv.VolumeName; g != e {
+		t.Errorf("wrong volume name: %q != %q", g, e)
+	}
+	if g, e := got.Inviter, inv.Inviter; g != e {
+		t.Errorf("wrong inviter: %v != %v", g, e)
+	}
+	if g, e := len(got.Locations), 1; g != e {
+		t.Fatalf("wrong number of locations: %v != %v", g, e)
+	}
+	if g, e := got.Locations[0], inv.Locations[0]; g != e {
+		t.Errorf("wrong location: %q != %q", g, e)
+	}
+	if g, e := got.SharingKeyName, inv.SharingKeyName; g != e {
+		t.Errorf("wrong sharing key name: %q != %q", g, e)
+	}
+	if g, e := got.SharingKey, inv.SharingKey; g != e {
+		t.Errorf("wrong sharing key: %x != %x", g, e)
+	}
+	if g, e := got.Expires.Unix(), inv.Expires.Unix(); g != e {
+		t.Errorf("wrong expiry: %v != %v", g, e)
+	}
+
+	if _, err := invite.Open(token, app2.Keys, now.Add(2*time.Hour)); err != invite.ErrExpired {
+		t.Errorf("expected ErrExpired, got %v", err)
+	}
+	if _, err := invite.Open(token, app1.Keys, now); err != invite.ErrNotForUs {
+		t.Errorf("expected ErrNotForUs, got %v", err)
+	}
+}
+
+func TestTampered(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	now := time.Now()
+	inv := &invite.Invitation{
+		VolumeName: "foo",
+		Inviter:    *(*peer.PublicKey)(app1.Keys.Sign.Pub),
+		Invitee:    *(*peer.PublicKey)(app2.Keys.Sign.Pub),
+		Expires:    now.Add(time.Hour),
+	}
+	token, err := invite.Seal(inv, app1.Keys)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	buf, err := zbase32.DecodeString(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// flip a bit in the signed payload, past the protobuf framing
+	buf[10] ^= 0x01
+	if _, err := invite.Open(zbase32.EncodeToString(buf), app2.Keys, now); err == nil {
+		t.Fatal("expected error from tampered invitation")
+	}
+
+	if _, err := invite.Open("not a token", app2.Keys, now); err != invite.ErrMalformed {
+		t.Errorf("expected ErrMalformed, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bazil.org/bazil/server/invite/wire/invite.proto
+
+package wire
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Invitation struct {
+	// Exactly 64 bytes long.
+	VolumeID []byte `protobuf:"bytes,1,opt,name=volumeID,proto3" json:"volumeID,omitempty"`
+	// Name of the volume on the inviting server, used as the default
+	// local name.
+	VolumeName string `protobuf:"bytes,2,opt,name=volumeName,proto3" json:"volumeName,omitempty"`
+	// Public key of the inviting server. Must be exactly 32 bytes
+	// long.
+	Inviter []byte `protobuf:"bytes,3,opt,name=inviter,proto3" json:"inviter,omitempty"`
+	// Public key of the invited server. Must be exactly 32 bytes long.
+	Invitee []byte `protobuf:"bytes,4,opt,name=invitee,proto3" json:"invitee,omitempty"`
+	// Network locations of the inviting server, as host:port.
+	Locations      []string `protobuf:"bytes,5,rep,name=locations,proto3" json:"locations,omitempty"`
+	SharingKeyName string   `protobuf:"bytes,6,opt,name=sharingKeyName,proto3" json:"sharingKeyName,omitempty"`
+	// Sharing key secret, encrypted with NaCl box from inviter to
+	// invitee. The 24-byte nonce is prepended to the box.
+	SharingKey []byte `protobuf:"bytes,7,opt,name=sharingKey,proto3" json:"sharingKey,omitempty"`
+	// Seconds since Unix epoch after which the invitation must not
+	// be accepted.
+	Expires int64 `protobuf:"varint,8,opt,name=expires,proto3" json:"expires,omitempty"`
+	// Padding mode used with the sharing key, empty for none.
+	SharingKeyPadding    string   `protobuf:"bytes,9,opt,name=sharingKeyPadding,proto3" json:"sharingKeyPadding,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Invitation) Reset()         { *m = Invitation{} }
+func (m *Invitation) String() string { return proto.CompactTextString(m) }
+func (*Invitation) ProtoMessage()    {}
+func (*Invitation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6fc7fc21c300979b, []int{0}
+}
+
+func (m *Invitation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Invitation.Unmarshal(m, b)
+}
+func (m *Invitation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Invitation.Marshal(b, m, deterministic)
+}
+func (m *Invitation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Invitation.Merge(m, src)
+}
+func (m *Invitation) XXX_Size() int {
+	return xxx_messageInfo_Invitation.Size(m)
+}
+func (m *Invitation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Invitation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Invitation proto.InternalMessageInfo
+
+func (m *Invitation) GetVolumeID() []byte {
+	if m != nil {
+		return m.VolumeID
+	}
+	return nil
+}
+
+func (m *Invitation) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+func (m *Invitation) GetInviter() []byte {
+	if m != nil {
+		return m.Inviter
+	}
+	return nil
+}
+
+func (m *Invitation) GetInvitee() []byte {
+	if m != nil {
+		return m.Invitee
+	}
+	return nil
+}
+
+func (m *Invitation) GetLocations() []string {
+	if m != nil {
+		return m.Locations
+	}
+	return nil
+}
+
+func (m *Invitation) GetSharingKeyName() string {
+	if m != nil {
+		return m.SharingKeyName
+	}
+	return ""
+}
+
+func (m *Invitation) GetSharingKey() []byte {
+	if m != nil {
+		return m.SharingKey
+	}
+	return nil
+}
+
+func (m *Invitation) GetExpires() int64 {
+	if m != nil {
+		return m.Expires
+	}
+	return 0
+}
+
+func (m *Invitation) GetSharingKeyPadding() string {
+	if m != nil {
+		return m.SharingKeyPadding
+	}
+	return ""
+}
+
+type SignedInvitation struct {
+	// Marshaled Invitation.
+	Invitation []byte `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	// Signature of invitation, made with the inviter key. Must be
+	// exactly 64 bytes long.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedInvitation) Reset()         { *m = SignedInvitation{} }
+func (m *SignedInvitation) String() string { return proto.CompactTextString(m) }
+func (*SignedInvitation) ProtoMessage()    {}
+func (*SignedInvitation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6fc7fc21c300979b, []int{1}
+}
+
+func (m *SignedInvitation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedInvitation.Unmarshal(m, b)
+}
+func (m *SignedInvitation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedInvitation.Marshal(b, m, deterministic)
+}
+func (m *SignedInvitation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedInvitation.Merge(m, src)
+}
+func (m *SignedInvitation) XXX_Size() int {
+	return xxx_messageInfo_SignedInvitation.Size(m)
+}
+func (m *SignedInvitation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedInvitation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedInvitation proto.InternalMessageInfo
+
+func (m *SignedInvitation) GetInvitation() []byte {
+	if m != nil {
+		return m.Invitation
+	}
+	return nil
+}
+
+func (m *SignedInvitation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Invitation)(nil), "bazil.invite.Invitation")
+	proto.RegisterType((*SignedInvitation)(nil), "bazil.invite.SignedInvitation")
+}
+
+func init() {
+	proto.RegisterFile("bazil.org/bazil/server/invite/wire/invite.proto", fileDescriptor_6fc7fc21c300979b)
+}
+
+var fileDescriptor_6fc7fc21c300979b = []byte{
+	// 269 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0xcd, 0x6a, 0xeb, 0x30,
+	0x10, 0x85, 0x71, 0x9c, 0xeb, 0xc4, 0x83, 0xb9, 0xb4, 0x5a, 0x89, 0x52, 0x82, 0xc9, 0xa2, 0x78,
+	0x51, 0xe2, 0x45, 0xdf, 0xa0, 0x74, 0x13, 0x0a, 0x25, 0xb8, 0xbb, 0xee, 0x94, 0x7a, 0x70, 0x07,
+	0x1c, 0x29, 0x48, 0x8e, 0xfb, 0xf3, 0x48, 0x7d, 0xca, 0x22, 0x29, 0x89, 0x44, 0xbb, 0x3b, 0xe7,
+	0x1b, 0x8d, 0x8e, 0x34, 0x03, 0xf5, 0x56, 0x7c, 0x51, 0xbf, 0x52, 0xba, 0xf3, 0xaa, 0x36, 0xa8,
+	0x47, 0xd4, 0x35, 0xc9, 0x91, 0x06, 0xac, 0xdf, 0x49, 0xe3, 0x51, 0xaf, 0xf6, 0x5a, 0x0d, 0x8a,
+	0x15, 0xbe, 0xc1, 0xb3, 0xe5, 0xf7, 0x04, 0x60, 0x6d, 0xa5, 0x18, 0x48, 0x49, 0x76, 0x05, 0xf3,
+	0x51, 0xf5, 0x87, 0x1d, 0xae, 0x1f, 0x78, 0x52, 0x26, 0x55, 0xd1, 0x9c, 0x3d, 0x5b, 0x00, 0x78,
+	0xfd, 0x24, 0x76, 0xc8, 0x27, 0x65, 0x52, 0xe5, 0x4d, 0x44, 0x18, 0x87, 0x99, 0xbf, 0x54, 0xf3,
+	0xd4, 0xb5, 0x9e, 0x6c, 0xa8, 0x20, 0x9f, 0xc6, 0x15, 0x64, 0xd7, 0x90, 0xf7, 0xea, 0xd5, 0x65,
+	0x1b, 0xfe, 0xaf, 0x4c, 0xab, 0xbc, 0x09, 0x80, 0xdd, 0xc0, 0x7f, 0xf3, 0x26, 0x34, 0xc9, 0xee,
+	0x11, 0x3f, 0x5d, 0x6a, 0xe6, 0x52, 0x7f, 0x51, 0xfb, 0xb2, 0x40, 0xf8, 0xcc, 0x45, 0x44, 0xc4,
+	0xe6, 0xe3, 0xc7, 0x9e, 0x34, 0x1a, 0x3e, 0x2f, 0x93, 0x2a, 0x6d, 0x4e, 0x96, 0xdd, 0xc2, 0x65,
+	0x38, 0xb7, 0x11, 0x6d, 0x4b, 0xb2, 0xe3, 0xb9, 0x0b, 0xf9, 0x5b, 0x58, 0x6e, 0xe0, 0xe2, 0x99,
+	0x3a, 0x89, 0x6d, 0x34, 0xb1, 0x05, 0x00, 0x9d, 0xdd, 0x71, 0x66, 0x11, 0xb1, 0x3f, 0x34, 0xd4,
+	0x49, 0x31, 0x1c, 0xb4, 0x1f, 0x5a, 0xd1, 0x04, 0x70, 0x9f, 0xbd, 0x4c, 0xed, 0x86, 0xb6, 0x99,
+	0xdb, 0xcd, 0xdd, 0xcf, 0x00, 0x22, 0xaa, 0x8b, 0x82, 0xce, 0x01, 0x00, 0x00,
+}
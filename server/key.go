@@ -2,6 +2,7 @@ package server
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 
 	"bazil.org/bazil/tokens"
@@ -27,12 +28,16 @@ func extractEd25519Pubkey(priv *[ed25519.PrivateKeySize]byte) *[ed25519.PublicKe
 	return &pub
 }
 
+var ErrIdentityExists = errors.New("a different identity exists already")
+
 // loadOrGenerateKeys reads the master signing key from the global
 // state in DB, (generating one if it's not already there), and
 // generates the boxing keys based on it.
 //
+// If identity is not nil, its key is stored in DB and used instead.
+//
 // This is meant to be called exactly once at startup time.
-func loadOrGenerateKeys(db *bolt.DB) (*CryptoKeys, error) {
+func loadOrGenerateKeys(db *bolt.DB, identity *identityConfig) (*CryptoKeys, error) {
 	var k CryptoKeys
 
 	getKey := func(tx *bolt.Tx) error {
@@ -54,6 +59,20 @@ func loadOrGenerateKeys(db *bolt.DB) (*CryptoKeys, error) {
 		return nil, err
 	}
 
+	if identity != nil {
+		if k.Sign.Priv != nil && *k.Sign.Priv != *identity.priv && !identity.replace {
+			return nil, ErrIdentityExists
+		}
+		putKey := func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte(tokens.BucketBazil))
+			return bucket.Put([]byte(tokens.GlobalStateKey), identity.priv[:])
+		}
+		if err := db.Update(putKey); err != nil {
+			return nil, err
+		}
+		k.Sign.Priv = identity.priv
+	}
+
 	if k.Sign.Priv == nil {
 		// did not load keys from database
 		var err error
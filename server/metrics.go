@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/kv"
+	"bazil.org/bazil/util/metrics"
+	"bazil.org/fuse"
+)
+
+// appMetrics are the metrics the server keeps about itself.
+type appMetrics struct {
+	registry *metrics.Registry
+
+	fuseOps      *metrics.Counter
+	fuseErrors   *metrics.Counter
+	fuseDuration *metrics.Histogram
+
+	storageOps    *metrics.Counter
+	storageBytes  *metrics.Counter
+	storageErrors *metrics.Counter
+
+	syncDuration *metrics.Histogram
+	conflicts    *metrics.Counter
+}
+
+func (app *App) initMetrics() {
+	r := metrics.NewRegistry()
+	m := &app.metrics
+	m.registry = r
+	m.fuseOps = r.NewCounter("bazil_fuse_ops_total",
+		"FUSE requests handled.", "volume", "op")
+	m.fuseErrors = r.NewCounter("bazil_fuse_errors_total",
+		"FUSE requests that returned an error.", "volume", "op")
+	m.fuseDuration = r.NewHistogram("bazil_fuse_op_duration_seconds",
+		"Time taken to handle FUSE requests.", metrics.DefBuckets, "volume", "op")
+	m.storageOps = r.NewCounter("bazil_storage_ops_total",
+		"Chunk store operations.", "backend", "op")
+	m.storageBytes = r.NewCounter("bazil_storage_bytes_total",
+		"Bytes read from and written to chunk stores.", "backend", "op")
+	m.storageErrors = r.NewCounter("bazil_storage_errors_total",
+		"Chunk store operations that failed, not counting missing chunks.", "backend", "op")
+	m.syncDuration = r.NewHistogram("bazil_sync_duration_seconds",
+		"Time taken to sync a directory from a peer.", metrics.DefBuckets, "volume", "result")
+	m.conflicts = r.NewCounter("bazil_sync_conflicts_total",
+		"Conflicting changes recorded while syncing.", "volume")
+	r.NewGaugeFunc("bazil_volumes_open", "Volumes currently open.", func() float64 {
+		app.volumes.Lock()
+		defer app.volumes.Unlock()
+		return float64(len(app.volumes.open))
+	})
+}
+
+// Metrics returns the metrics of the server. Serve it over HTTP, or
+// write it out, to see them in the Prometheus text format.
+func (app *App) Metrics() *metrics.Registry {
+	return app.metrics.registry
+}
+
+// meteredKV counts the operations on a storage backend.
+type meteredKV struct {
+	kv.KV
+	metrics *appMetrics
+	backend string
+}
+
+var _ kv.KV = (*meteredKV)(nil)
+
+func (m *meteredKV) Get(ctx context.Context, key []byte) ([]byte, error) {
+	data, err := m.KV.Get(ctx, key)
+	m.metrics.storageOps.Inc(m.backend, "get")
+	m.metrics.storageBytes.Add(float64(len(data)), m.backend, "get")
+	if err != nil {
+		if _, ok := err.(kv.NotFoundError); !ok {
+			m.metrics.storageErrors.Inc(m.backend, "get")
+		}
+	}
+	return data, err
+}
+
+func (m *meteredKV) Put(ctx context.Context, key, value []byte) error {
+	err := m.KV.Put(ctx, key, value)
+	m.metrics.storageOps.Inc(m.backend, "put")
+	if err != nil {
+		m.metrics.storageErrors.Inc(m.backend, "put")
+		return err
+	}
+	m.metrics.storageBytes.Add(float64(len(value)), m.backend, "put")
+	return nil
+}
+
+// fuseMetrics times the FUSE requests of one mount, from the debug
+// messages the FUSE server sends when a request arrives and when it
+// is responded to.
+type fuseMetrics struct {
+	metrics *appMetrics
+	volume  string
+
+	mu sync.Mutex
+	// Requests that have not been responded to yet.
+	pending map[fuse.RequestID]time.Time
+}
+
+func newFUSEMetrics(m *appMetrics, volID *db.VolumeID) *fuseMetrics {
+	return &fuseMetrics{
+		metrics: m,
+		volume:  volID.String(),
+		pending: make(map[fuse.RequestID]time.Time),
+	}
+}
+
+var (
+	fuseHeaderType    = reflect.TypeOf((*fuse.Header)(nil))
+	fuseRequestIDType = reflect.TypeOf(fuse.RequestID(0))
+)
+
+// observe looks at a FUSE debug message. The messages are of types
+// private to the FUSE library, so only their shape is known: they
+// have an Op, and a Request that is the request header on arrival,
+// or has the request ID on response, along with an Errno or Error.
+func (f *fuseMetrics) observe(msg interface{}) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	op := v.FieldByName("Op")
+	req := v.FieldByName("Request")
+	if !op.IsValid() || op.Kind() != reflect.String || !req.IsValid() {
+		return
+	}
+
+	if req.Type() == fuseHeaderType {
+		if req.IsNil() {
+			return
+		}
+		hdr := req.Interface().(*fuse.Header)
+		f.mu.Lock()
+		f.pending[hdr.ID] = time.Now()
+		f.mu.Unlock()
+		return
+	}
+
+	if req.Kind() != reflect.Struct {
+		return
+	}
+	idField := req.FieldByName("ID")
+	if !idField.IsValid() || idField.Type() != fuseRequestIDType {
+		return
+	}
+	id := idField.Interface().(fuse.RequestID)
+	f.mu.Lock()
+	start, ok := f.pending[id]
+	delete(f.pending, id)
+	f.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	name := op.String()
+	f.metrics.fuseOps.Inc(f.volume, name)
+	f.metrics.fuseDuration.Observe(time.Since(start).Seconds(), f.volume, name)
+	for _, field := range []string{"Errno", "Error"} {
+		if e := v.FieldByName(field); e.IsValid() && e.Kind() == reflect.String && e.String() != "" {
+			f.metrics.fuseErrors.Inc(f.volume, name)
+			break
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/util/tempdir"
+	"bazil.org/fuse"
+)
+
+func TestMetricsStorage(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(filepath.Join(tmp.Path, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	dir := filepath.Join(tmp.Path, "store")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	s, err := app.openStorage(dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+	if err := s.Put(ctx, []byte("k"), []byte("hello")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, err := s.Get(ctx, []byte("k")); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	// missing chunks are not errors of the backend
+	if _, err := s.Get(ctx, []byte("missing")); err == nil {
+		t.Fatal("expected error for missing key")
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, []byte("k2"), []byte("lost")); err == nil {
+		t.Fatal("expected error from removed backend")
+	}
+
+	m := &app.metrics
+	for _, c := range []struct {
+		name string
+		g, e float64
+	}{
+		{"get ops", m.storageOps.Value(dir, "get"), 2},
+		{"put ops", m.storageOps.Value(dir, "put"), 2},
+		{"get bytes", m.storageBytes.Value(dir, "get"), 5},
+		{"put bytes", m.storageBytes.Value(dir, "put"), 5},
+		{"get errors", m.storageErrors.Value(dir, "get"), 0},
+		{"put errors", m.storageErrors.Value(dir, "put"), 1},
+	} {
+		if c.g != c.e {
+			t.Errorf("wrong %s: %v != %v", c.name, c.g, c.e)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := app.Metrics().WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	for _, want := range []string{
+		"\nbazil_volumes_open 0\n",
+		"\nbazil_storage_errors_total{backend=\"" + dir + "\",op=\"put\"} 1\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q from metrics:\n%s", want, buf.String())
+		}
+	}
+}
+
+// Shaped like the debug messages of the FUSE library.
+type testFUSERequest struct {
+	Op      string
+	Request *fuse.Header
+	In      interface{}
+}
+
+type testFUSEResponseHeader struct {
+	ID fuse.RequestID
+}
+
+type testFUSEResponse struct {
+	Op      string
+	Request testFUSEResponseHeader
+	Out     interface{}
+	Errno   string
+	Error   string
+}
+
+func TestMetricsFUSE(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(filepath.Join(tmp.Path, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	volID := db.VolumeID{1, 2, 3}
+	f := newFUSEMetrics(&app.metrics, &volID)
+	f.observe(testFUSERequest{Op: "Lookup", Request: &fuse.Header{ID: 1}})
+	f.observe(testFUSERequest{Op: "Read", Request: &fuse.Header{ID: 2}})
+	f.observe(testFUSERequest{Op: "Lookup", Request: &fuse.Header{ID: 3}})
+	f.observe(testFUSEResponse{Op: "Lookup", Request: testFUSEResponseHeader{ID: 1}})
+	f.observe(testFUSEResponse{Op: "Lookup", Request: testFUSEResponseHeader{ID: 3}, Errno: "ENOENT"})
+	// other messages are ignored
+	f.observe("unmount fail")
+	f.observe(testFUSEResponse{Op: "Read", Request: testFUSEResponseHeader{ID: 42}})
+
+	vol := volID.String()
+	m := &app.metrics
+	if g, e := m.fuseOps.Value(vol, "Lookup"), 2.0; g != e {
+		t.Errorf("wrong op count: %v != %v", g, e)
+	}
+	if g, e := m.fuseErrors.Value(vol, "Lookup"), 1.0; g != e {
+		t.Errorf("wrong error count: %v != %v", g, e)
+	}
+	if g, e := m.fuseDuration.Count(vol, "Lookup"), uint64(2); g != e {
+		t.Errorf("wrong duration count: %v != %v", g, e)
+	}
+	if g, e := m.fuseOps.Value(vol, "Read"), 0.0; g != e {
+		t.Errorf("unfinished request counted: %v != %v", g, e)
+	}
+	if g, e := len(f.pending), 1; g != e {
+		t.Errorf("wrong number of pending requests: %v != %v", g, e)
+	}
+}
@@ -1,11 +1,25 @@
 package server
 
+import (
+	"errors"
+
+	"github.com/agl/ed25519"
+)
+
 type appOption func(*appConfig) error
 
 type AppOption appOption
 
 type appConfig struct {
-	debug func(msg interface{})
+	debug      func(msg interface{})
+	identity   *identityConfig
+	peerLimits *PeerLimits
+	adminGroup *uint32
+}
+
+type identityConfig struct {
+	priv    *[ed25519.PrivateKeySize]byte
+	replace bool
 }
 
 func Debug(fn func(msg interface{})) AppOption {
@@ -14,3 +28,66 @@ func Debug(fn func(msg interface{})) AppOption {
 		return nil
 	}
 }
+
+// Identity makes the server use the given private key as its
+// identity, instead of the one stored in the data directory.
+//
+// The key is stored in the data directory for future runs. If a
+// different identity is already stored there, New returns
+// ErrIdentityExists, unless replace is true.
+func Identity(priv *[ed25519.PrivateKeySize]byte, replace bool) AppOption {
+	return func(conf *appConfig) error {
+		conf.identity = &identityConfig{
+			priv:    priv,
+			replace: replace,
+		}
+		return nil
+	}
+}
+
+// PeerLimits bounds the resources a single peer can use on the peer
+// server. A zero field means no limit.
+type PeerLimits struct {
+	// Largest object a peer may store, in bytes.
+	MaxObjectSize int64
+	// Number of streaming calls a peer may have open at once.
+	MaxStreams int
+	// Calls per second a peer may make on average, and how many
+	// calls it may make in a burst above that rate.
+	Rate  float64
+	Burst int
+}
+
+// DefaultPeerLimits are used when no PeerLimits option is given.
+var DefaultPeerLimits = PeerLimits{
+	MaxObjectSize: 64 << 20,
+	MaxStreams:    32,
+	Rate:          100,
+	Burst:         200,
+}
+
+// ErrPeerBurst means a peer rate limit was given without room for
+// even a single call.
+var ErrPeerBurst = errors.New("peer burst must be at least 1 when the rate is limited")
+
+// LimitPeers sets the limits enforced on peers by the peer server.
+// With a Rate, Burst must be at least 1, or no call would ever be
+// allowed.
+func LimitPeers(limits PeerLimits) AppOption {
+	return func(conf *appConfig) error {
+		if limits.Rate > 0 && limits.Burst < 1 {
+			return ErrPeerBurst
+		}
+		conf.peerLimits = &limits
+		return nil
+	}
+}
+
+// ControlAdminGroup lets members of the group use the control
+// socket, in addition to the user running the server.
+func ControlAdminGroup(gid uint32) AppOption {
+	return func(conf *appConfig) error {
+		conf.adminGroup = &gid
+		return nil
+	}
+}
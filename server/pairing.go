@@ -0,0 +1,282 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	wirepeer "bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/util/grpcedtls"
+	"github.com/agl/ed25519"
+	"google.golang.org/grpc"
+)
+
+// MaxPairingDuration is the longest time pairing mode can be kept
+// open at once.
+const MaxPairingDuration = time.Hour
+
+// Pairing mode ends early after this many wrong codes, to stop
+// guessing.
+const maxPairingFailures = 5
+
+// At most this many unknown peers are let in during one pairing
+// mode.
+const maxPairingAdmitted = 32
+
+var (
+	ErrUnknownPeer    = errors.New("unknown peer")
+	ErrPairingFull    = errors.New("too many unknown peers connected for pairing")
+	ErrPairingClosed  = errors.New("not in pairing mode")
+	ErrPairingCode    = errors.New("wrong pairing code")
+	ErrPairingSelf    = errors.New("cannot pair with self")
+	ErrPairingPeer    = errors.New("peer does not match the pairing code")
+	ErrPeerNotPending = errors.New("peer is not waiting for approval")
+)
+
+// PendingPeer is a peer that presented the right pairing code, and
+// is waiting for the operator to approve it.
+type PendingPeer struct {
+	Pub peer.PublicKey
+	// When the peer paired.
+	Since time.Time
+}
+
+func (app *App) isPeer(pub *peer.PublicKey) (bool, error) {
+	known := true
+	get := func(tx *db.Tx) error {
+		_, err := tx.Peers().Get(pub)
+		if err == db.ErrPeerNotFound {
+			known = false
+			return nil
+		}
+		return err
+	}
+	if err := app.DB.View(get); err != nil {
+		return false, err
+	}
+	return known, nil
+}
+
+// AdmitPeer decides whether to accept a connection from the given
+// public key. Known peers are always let in, others only in pairing
+// mode, where all they can do is pair.
+func (app *App) AdmitPeer(pub *peer.PublicKey) error {
+	known, err := app.isPeer(pub)
+	if err != nil {
+		return err
+	}
+	if known {
+		return nil
+	}
+	app.pairing.Lock()
+	defer app.pairing.Unlock()
+	if app.pairing.code == nil {
+		return ErrUnknownPeer
+	}
+	if _, ok := app.pairing.admitted[*pub]; !ok && len(app.pairing.admitted) >= maxPairingAdmitted {
+		return ErrPairingFull
+	}
+	app.pairing.admitted[*pub] = struct{}{}
+	return nil
+}
+
+// StartPairing enters pairing mode for the given duration, and
+// returns the code that peers must present. Starting again while in
+// pairing mode replaces the code.
+func (app *App) StartPairing(d time.Duration) (*peer.PairingCode, error) {
+	code, err := peer.NewPairingCode((*peer.PublicKey)(app.Keys.Sign.Pub))
+	if err != nil {
+		return nil, err
+	}
+	app.pairing.Lock()
+	defer app.pairing.Unlock()
+	if app.pairing.timer != nil {
+		app.pairing.timer.Stop()
+	}
+	app.pairing.code = code
+	app.pairing.failures = 0
+	app.pairing.timer = time.AfterFunc(d, func() {
+		app.stopPairing(code)
+	})
+	return code, nil
+}
+
+// StopPairing leaves pairing mode. Peers already waiting for
+// approval stay pending.
+func (app *App) StopPairing() {
+	app.stopPairing(nil)
+}
+
+// stopPairing ends pairing mode, if the current code is code, or
+// always if code is nil.
+func (app *App) stopPairing(code *peer.PairingCode) {
+	app.pairing.Lock()
+	if app.pairing.code == nil || (code != nil && app.pairing.code != code) {
+		// already ended, or restarted
+		app.pairing.Unlock()
+		return
+	}
+	admitted := app.endPairingLocked()
+	app.pairing.Unlock()
+	app.disconnectUnpaired(admitted)
+}
+
+// endPairingLocked ends pairing mode, and returns the unknown peers
+// that were let in. The caller must hold app.pairing, and should
+// call disconnectUnpaired without holding it.
+func (app *App) endPairingLocked() map[peer.PublicKey]struct{} {
+	app.pairing.timer.Stop()
+	app.pairing.timer = nil
+	app.pairing.code = nil
+	admitted := app.pairing.admitted
+	app.pairing.admitted = make(map[peer.PublicKey]struct{})
+	return admitted
+}
+
+// disconnectUnpaired closes the connections of the peers that are
+// still unknown.
+func (app *App) disconnectUnpaired(admitted map[peer.PublicKey]struct{}) {
+	for pub := range admitted {
+		pub := pub
+		known, err := app.isPeer(&pub)
+		if err != nil {
+			log.Printf("pairing: cannot check peer %v: %v", &pub, err)
+		}
+		if !known {
+			app.closePeerConns(&pub)
+		}
+	}
+}
+
+// Pair records the peer as waiting for approval, if secret is from
+// the current pairing code. Pairing again as a known peer does
+// nothing.
+func (app *App) Pair(pub *peer.PublicKey, secret []byte) error {
+	known, err := app.isPeer(pub)
+	if err != nil {
+		return err
+	}
+	if known {
+		return nil
+	}
+	app.pairing.Lock()
+	if app.pairing.code == nil {
+		app.pairing.Unlock()
+		return ErrPairingClosed
+	}
+	if !app.pairing.code.CheckSecret(secret) {
+		app.pairing.failures++
+		if app.pairing.failures < maxPairingFailures {
+			app.pairing.Unlock()
+			return ErrPairingCode
+		}
+		log.Printf("pairing: too many wrong codes, leaving pairing mode")
+		// not disconnecting, that would lose the reply to this call;
+		// all the unknown peers can do is fail to pair
+		_ = app.endPairingLocked()
+		app.pairing.Unlock()
+		return ErrPairingCode
+	}
+	defer app.pairing.Unlock()
+	if _, ok := app.pairing.pending[*pub]; !ok {
+		app.pairing.pending[*pub] = time.Now()
+	}
+	return nil
+}
+
+// PendingPeers returns the peers waiting for approval, oldest first.
+func (app *App) PendingPeers() []PendingPeer {
+	app.pairing.Lock()
+	defer app.pairing.Unlock()
+	list := make([]PendingPeer, 0, len(app.pairing.pending))
+	for pub, since := range app.pairing.pending {
+		list = append(list, PendingPeer{Pub: pub, Since: since})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Since.Equal(list[j].Since) {
+			return list[i].Since.Before(list[j].Since)
+		}
+		return bytes.Compare(list[i].Pub[:], list[j].Pub[:]) < 0
+	})
+	return list
+}
+
+// ApprovePeer adds a pending peer as a known peer.
+func (app *App) ApprovePeer(pub *peer.PublicKey) error {
+	app.pairing.Lock()
+	defer app.pairing.Unlock()
+	if _, ok := app.pairing.pending[*pub]; !ok {
+		return ErrPeerNotPending
+	}
+	makePeer := func(tx *db.Tx) error {
+		_, err := tx.Peers().Make(pub)
+		return err
+	}
+	if err := app.DB.Update(makePeer); err != nil {
+		return err
+	}
+	delete(app.pairing.pending, *pub)
+	return nil
+}
+
+// PairWith pairs with the peer in pairing mode at addr, that gave
+// out code. On success, the peer is added as a known peer reachable
+// at addr, and its public key is returned. The other side still has
+// to approve us before we can talk to it.
+func (app *App) PairWith(ctx context.Context, addr string, code *peer.PairingCode) (*peer.PublicKey, error) {
+	// set by the handshake, which runs in a different goroutine
+	var mu sync.Mutex
+	var remote *peer.PublicKey
+	var lookupErr error
+	auth := &grpcedtls.Authenticator{
+		Config: app.GetTLSConfig,
+		Lookup: func(pub *[ed25519.PublicKeySize]byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case bytes.Equal(pub[:], app.Keys.Sign.Pub[:]):
+				lookupErr = ErrPairingSelf
+			case !code.Matches((*peer.PublicKey)(pub)):
+				lookupErr = ErrPairingPeer
+			default:
+				remote = (*peer.PublicKey)(pub)
+			}
+			return lookupErr
+		},
+	}
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(auth))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := wirepeer.NewPeerClient(conn)
+	_, err = client.Pair(ctx, &wirepeer.PairRequest{Secret: code.Secret()})
+	mu.Lock()
+	defer mu.Unlock()
+	if lookupErr != nil {
+		// more useful than the connection error it caused
+		return nil, lookupErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	add := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(remote)
+		if err != nil {
+			return err
+		}
+		return p.Locations().Add(addr, 0)
+	}
+	if err := app.DB.Update(add); err != nil {
+		return nil, err
+	}
+	return remote, nil
+}
@@ -1,7 +1,12 @@
 package server
 
 import (
+	"context"
 	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
 
 	"bazil.org/bazil/db"
 	"bazil.org/bazil/kv"
@@ -12,6 +17,8 @@ import (
 	"google.golang.org/grpc"
 )
 
+// OpenKVForPeer opens the storage the peer is allowed to use. The
+// caller must close the returned store.
 func (app *App) OpenKVForPeer(pub *peer.PublicKey) (kv.KV, error) {
 	var kvstore kv.KV
 	open := func(tx *db.Tx) error {
@@ -35,32 +42,63 @@ func (app *App) OpenKVForPeer(pub *peer.PublicKey) (kv.KV, error) {
 type PeerClient interface {
 	wirepeer.PeerClient
 	io.Closer
+
+	// Protocol returns what the peer supports. The handshake is done
+	// on first use, and the result is cached for the life of the
+	// client.
+	Protocol(ctx context.Context) (*peer.Protocol, error)
 }
 
+// peerClient is a user of a pooled connection.
 type peerClient struct {
 	wirepeer.PeerClient
-	conn *grpc.ClientConn
+	pc   *pooledConn
+	once sync.Once
 }
 
 var _ PeerClient = (*peerClient)(nil)
 
+func (p *peerClient) Protocol(ctx context.Context) (*peer.Protocol, error) {
+	return p.pc.protocol(ctx)
+}
+
+// Close releases the connection back to the pool.
 func (p *peerClient) Close() error {
-	return p.conn.Close()
+	p.once.Do(func() {
+		p.pc.app.releasePooledConn(p.pc)
+	})
+	return nil
 }
 
+// peerDialTimeout limits how long a single network location of a
+// peer is tried, before moving on to the next one.
+const peerDialTimeout = 10 * time.Second
+
+// DialPeer returns a client for talking to the peer. Connections are
+// pooled: closing the client lets others reuse its connection, which
+// is closed after it has been idle for a while.
 func (app *App) DialPeer(pub *peer.PublicKey) (PeerClient, error) {
-	var addr string
+	pc, err := app.getPooledConn(pub)
+	if err != nil {
+		return nil, err
+	}
+	p := &peerClient{
+		PeerClient: pc.client,
+		pc:         pc,
+	}
+	return p, nil
+}
+
+// dialPeerConn creates a new connection to the peer.
+func (app *App) dialPeerConn(pub *peer.PublicKey) (*grpc.ClientConn, error) {
 	find := func(tx *db.Tx) error {
 		p, err := tx.Peers().Get(pub)
 		if err != nil {
 			return err
 		}
-		a, err := p.Locations().Get()
-		if err != nil {
-			return err
-		}
-		addr = a
-		return nil
+		// fail early if there is nowhere to connect
+		_, err = p.Locations().Get()
+		return err
 	}
 	if err := app.DB.View(find); err != nil {
 		return nil, err
@@ -72,17 +110,102 @@ func (app *App) DialPeer(pub *peer.PublicKey) (PeerClient, error) {
 	}
 
 	// this is not a slow network operation, it just tells grpc about
-	// the remote
-	conn, err := grpc.Dial(addr,
+	// the remote; the target is only a name, the actual address used
+	// is decided by the dialer, on every (re)connect
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(auth),
-	)
-	if err != nil {
-		return nil, err
+		grpc.WithContextDialer(app.peerDialer(pub)),
+		grpc.WithKeepaliveParams(peerKeepalive),
 	}
-	client := wirepeer.NewPeerClient(conn)
-	p := &peerClient{
-		PeerClient: client,
-		conn:       conn,
+	opts = append(opts, app.peerInterceptors(pub)...)
+	return grpc.Dial(pub.String(), opts...)
+}
+
+// peerDialer returns a function that connects to the peer, trying
+// all its known network locations in order.
+func (app *App) peerDialer(pub *peer.PublicKey) func(ctx context.Context, target string) (net.Conn, error) {
+	key := *pub
+	dial := func(ctx context.Context, target string) (net.Conn, error) {
+		var addrs []string
+		var last string
+		find := func(tx *db.Tx) error {
+			p, err := tx.Peers().Get(&key)
+			if err != nil {
+				return err
+			}
+			locs := p.Locations()
+			addrs = locs.List()
+			last = locs.Last()
+			return nil
+		}
+		if err := app.DB.View(find); err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, db.ErrNoLocationForPeer
+		}
+
+		var firstErr error
+		for _, addr := range addrs {
+			attemptCtx, cancel := context.WithTimeout(ctx, peerDialTimeout)
+			var d net.Dialer
+			conn, err := d.DialContext(attemptCtx, "tcp", addr)
+			cancel()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				if ctx.Err() != nil {
+					break
+				}
+				continue
+			}
+			if addr != last {
+				app.rememberPeerLocation(&key, addr)
+			}
+			return conn, nil
+		}
+		return nil, firstErr
 	}
-	return p, nil
+	return dial
+}
+
+// rememberPeerLocation records addr as the location that last worked
+// for the peer.
+func (app *App) rememberPeerLocation(pub *peer.PublicKey, addr string) {
+	remember := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(pub)
+		if err != nil {
+			return err
+		}
+		return p.Locations().SetLast(addr)
+	}
+	if err := app.DB.Update(remember); err != nil {
+		switch err {
+		case db.ErrPeerNotFound, db.ErrPeerLocationNotFound:
+			// removed while we were connecting; nothing to remember
+			return
+		}
+		log.Printf("db error: remembering peer location: %v", err)
+	}
+}
+
+// RemovePeer forgets about a peer, revoking all access it has been
+// granted, and closes any active connections to or from it.
+//
+// If the peer does not exist, returns db.ErrPeerNotFound.
+func (app *App) RemovePeer(pub *peer.PublicKey) error {
+	remove := func(tx *db.Tx) error {
+		return tx.Peers().Remove(pub)
+	}
+	if err := app.DB.Update(remove); err != nil {
+		return err
+	}
+	// Any new connections will be rejected by the peer service, as
+	// the database no longer knows the peer.
+	app.closePeerConns(pub)
+	app.peerPool.Lock()
+	delete(app.peerPool.health, *pub)
+	app.peerPool.Unlock()
+	return nil
 }
@@ -0,0 +1,78 @@
+package peer
+
+import (
+	"context"
+	"time"
+
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/audit"
+	"google.golang.org/grpc"
+)
+
+// auditor records every call from peers in the audit log.
+type auditor struct {
+	app *server.App
+}
+
+func (a *auditor) record(ctx context.Context, method string) *audit.Record {
+	r := &audit.Record{
+		Time: time.Now(),
+		RPC:  method,
+	}
+	if pub, err := caller(ctx); err == nil {
+		r.Peer = pub.String()
+	}
+	return r
+}
+
+func (a *auditor) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	r := a.record(ctx, info.FullMethod)
+	r.Note(req)
+	resp, err := handler(ctx, req)
+	r.Finish(err)
+	a.app.Audit(r)
+	return resp, err
+}
+
+// auditStream notes what the received messages say about the call.
+type auditStream struct {
+	grpc.ServerStream
+	r *audit.Record
+}
+
+func (s *auditStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.r.Note(m)
+	}
+	return err
+}
+
+func (a *auditor) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	r := a.record(ss.Context(), info.FullMethod)
+	err := handler(srv, &auditStream{ServerStream: ss, r: r})
+	r.Finish(err)
+	a.app.Audit(r)
+	return err
+}
+
+// chainUnary makes outer run around inner, as grpc only takes one
+// interceptor of each kind.
+func chainUnary(outer, inner grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return inner(ctx, req, info, handler)
+		}
+		return outer(ctx, req, info, next)
+	}
+}
+
+// chainStream is chainUnary for streaming calls.
+func chainStream(outer, inner grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := func(srv interface{}, ss grpc.ServerStream) error {
+			return inner(srv, ss, info, handler)
+		}
+		return outer(srv, ss, info, next)
+	}
+}
@@ -0,0 +1,95 @@
+package peer_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"sync"
+	"testing"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/audit"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAudit(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	app1, app2, client, cleanup := limitedPair(t, tmp, &wg, server.PeerLimits{Rate: 0.001, Burst: 3})
+	defer cleanup()
+
+	ctx := context.Background()
+	stream, err := client.ObjectPut(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&wire.ObjectPutRequest{Key: []byte("k1"), Data: []byte("hello")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&wire.ObjectPutRequest{Data: []byte("world")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	get, err := client.ObjectGet(ctx, &wire.ObjectGetRequest{Key: []byte("k1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := get.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("get failed: %v", err)
+		}
+	}
+
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	// over the limit, and still recorded
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+
+	records, err := audit.Tail(server.AuditDir(app1.DataDir), 10)
+	if err != nil {
+		t.Fatalf("audit tail: %v", err)
+	}
+	want := []struct {
+		rpc    string
+		object string
+		code   string
+	}{
+		{"/bazil.peer.Peer/ObjectPut", hex.EncodeToString([]byte("k1")), "OK"},
+		{"/bazil.peer.Peer/ObjectGet", hex.EncodeToString([]byte("k1")), "OK"},
+		{"/bazil.peer.Peer/Ping", "", "OK"},
+		{"/bazil.peer.Peer/Ping", "", "ResourceExhausted"},
+	}
+	if g, e := len(records), len(want); g != e {
+		t.Fatalf("wrong number of audit records: %d != %d", g, e)
+	}
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+	for i, w := range want {
+		r := records[i]
+		if g, e := r.RPC, w.rpc; g != e {
+			t.Errorf("record %d: wrong rpc: %q != %q", i, g, e)
+		}
+		if g, e := r.Object, w.object; g != e {
+			t.Errorf("record %d: wrong object: %q != %q", i, g, e)
+		}
+		if g, e := r.Code, w.code; g != e {
+			t.Errorf("record %d: wrong code: %q != %q", i, g, e)
+		}
+		if g, e := r.Peer, pub2.String(); g != e {
+			t.Errorf("record %d: wrong peer: %q != %q", i, g, e)
+		}
+	}
+}
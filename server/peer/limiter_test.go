@@ -0,0 +1,42 @@
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+)
+
+func TestLimiterRefill(t *testing.T) {
+	l := newLimiter(server.PeerLimits{Rate: 2, Burst: 2})
+	now := time.Unix(1000, 0)
+	l.now = func() time.Time { return now }
+	pub := &peer.PublicKey{1}
+
+	for i := 0; i < 2; i++ {
+		if err := l.allow(pub); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if err := l.allow(pub); err == nil {
+		t.Fatal("expected burst to be used up")
+	}
+	now = now.Add(500 * time.Millisecond)
+	if err := l.allow(pub); err != nil {
+		t.Fatalf("expected one call after refill: %v", err)
+	}
+	if err := l.allow(pub); err == nil {
+		t.Fatal("expected only one call after refill")
+	}
+	// refill stops at the burst size
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := l.allow(pub); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if err := l.allow(pub); err == nil {
+		t.Fatal("expected burst to be used up")
+	}
+}
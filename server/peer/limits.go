@@ -0,0 +1,166 @@
+package peer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/util/grpcedtls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Stop tracking idle peers once this many are known, to bound the
+// memory used by connections from unknown keys.
+const maxTrackedPeers = 1024
+
+// limiter enforces the per-peer call rate and concurrent stream
+// limits.
+type limiter struct {
+	limits server.PeerLimits
+	now    func() time.Time
+
+	mu    sync.Mutex
+	peers map[peer.PublicKey]*usage
+}
+
+// usage is the state of one peer. Tokens refill at the allowed rate,
+// up to the burst size, and each call takes one.
+type usage struct {
+	streams int
+	tokens  float64
+	last    time.Time
+}
+
+func newLimiter(limits server.PeerLimits) *limiter {
+	return &limiter{
+		limits: limits,
+		now:    time.Now,
+		peers:  make(map[peer.PublicKey]*usage),
+	}
+}
+
+// refill adds the tokens earned since the last call. Caller must
+// hold l.mu.
+func (l *limiter) refill(u *usage, now time.Time) {
+	u.tokens += now.Sub(u.last).Seconds() * l.limits.Rate
+	if max := float64(l.limits.Burst); u.tokens > max {
+		u.tokens = max
+	}
+	u.last = now
+}
+
+// get returns the usage of the peer. Caller must hold l.mu.
+func (l *limiter) get(pub *peer.PublicKey, now time.Time) *usage {
+	if u, ok := l.peers[*pub]; ok {
+		l.refill(u, now)
+		return u
+	}
+	if len(l.peers) >= maxTrackedPeers {
+		l.forgetIdle(now)
+	}
+	u := &usage{
+		tokens: float64(l.limits.Burst),
+		last:   now,
+	}
+	l.peers[*pub] = u
+	return u
+}
+
+// forgetIdle drops peers that have no streams open and a full
+// bucket, as they are in the same state as a new peer. Caller must
+// hold l.mu.
+func (l *limiter) forgetIdle(now time.Time) {
+	for pub, u := range l.peers {
+		l.refill(u, now)
+		if u.streams == 0 && u.tokens >= float64(l.limits.Burst) {
+			delete(l.peers, pub)
+		}
+	}
+}
+
+// allow takes one call from the peer's rate allowance.
+func (l *limiter) allow(pub *peer.PublicKey) error {
+	if l.limits.Rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	u := l.get(pub, l.now())
+	if u.tokens < 1 {
+		return status.Errorf(codes.ResourceExhausted, "too many requests")
+	}
+	u.tokens--
+	return nil
+}
+
+// openStream counts a new stream for the peer. If it returns nil,
+// the caller must call closeStream when the stream is done.
+func (l *limiter) openStream(pub *peer.PublicKey) error {
+	if l.limits.MaxStreams <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	u := l.get(pub, l.now())
+	if u.streams >= l.limits.MaxStreams {
+		return status.Errorf(codes.ResourceExhausted, "too many concurrent streams")
+	}
+	u.streams++
+	return nil
+}
+
+func (l *limiter) closeStream(pub *peer.PublicKey) {
+	if l.limits.MaxStreams <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if u, ok := l.peers[*pub]; ok {
+		u.streams--
+	}
+}
+
+// caller returns the public key the connection was authenticated
+// with. Whether that is a known peer is checked by the handlers.
+func caller(ctx context.Context) (*peer.PublicKey, error) {
+	peerInfo, ok := grpcpeer.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+	auth, ok := peerInfo.AuthInfo.(*grpcedtls.Auth)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	}
+	return (*peer.PublicKey)(auth.PeerPub), nil
+}
+
+func (l *limiter) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	pub, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.allow(pub); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (l *limiter) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	pub, err := caller(ss.Context())
+	if err != nil {
+		return err
+	}
+	if err := l.allow(pub); err != nil {
+		return err
+	}
+	if err := l.openStream(pub); err != nil {
+		return err
+	}
+	defer l.closeStream(pub)
+	return handler(srv, ss)
+}
@@ -0,0 +1,204 @@
+package peer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/http/httptest"
+	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// limitedPair returns a client on app2 for talking to app1, which
+// enforces the limits. app2 may store objects on app1.
+func limitedPair(t testing.TB, tmp tempdir.Dir, wg *sync.WaitGroup, limits server.PeerLimits) (app1, app2 *server.App, client server.PeerClient, cleanup func()) {
+	app1, err := server.New(tmp.Subdir("app1"), server.LimitPeers(limits))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app2, err = server.New(tmp.Subdir("app2"))
+	if err != nil {
+		app1.Close()
+		t.Fatal(err)
+	}
+	web1 := httptest.ServeHTTP(t, wg, app1)
+
+	pub1 := (*peer.PublicKey)(app1.Keys.Sign.Pub)
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+
+	setup1 := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(pub2)
+		if err != nil {
+			return err
+		}
+		return p.Storage().Allow("local")
+	}
+	if err := app1.DB.Update(setup1); err != nil {
+		t.Fatalf("app1 setup: %v", err)
+	}
+	setup2 := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(pub1)
+		if err != nil {
+			return err
+		}
+		return p.Locations().Set(web1.Addr().String())
+	}
+	if err := app2.DB.Update(setup2); err != nil {
+		t.Fatalf("app2 setup: %v", err)
+	}
+
+	client, err = app2.DialPeer(pub1)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	cleanup = func() {
+		client.Close()
+		web1.Close()
+		app2.Close()
+		app1.Close()
+	}
+	return app1, app2, client, cleanup
+}
+
+func TestObjectPutTooLarge(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	_, _, client, cleanup := limitedPair(t, tmp, &wg, server.PeerLimits{MaxObjectSize: 10})
+	defer cleanup()
+
+	ctx := context.Background()
+	put := func(key string, msgs ...string) error {
+		stream, err := client.ObjectPut(ctx)
+		if err != nil {
+			return err
+		}
+		for i, data := range msgs {
+			req := &wire.ObjectPutRequest{Data: []byte(data)}
+			if i == 0 {
+				req.Key = []byte(key)
+			}
+			if err := stream.Send(req); err != nil {
+				// the server gave up; the reason is in the
+				// response
+				break
+			}
+		}
+		_, err = stream.CloseAndRecv()
+		return err
+	}
+	if err := put("small", "01234", "56789"); err != nil {
+		t.Fatalf("put within limit failed: %v", err)
+	}
+	if err := put("large", "01234", "56789", "x"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	stream, err := client.ObjectGet(ctx, &wire.ObjectGetRequest{Key: []byte("large")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("oversized object must not be stored: %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	_, _, client, cleanup := limitedPair(t, tmp, &wg, server.PeerLimits{Rate: 0.001, Burst: 3})
+	defer cleanup()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Ping(ctx, &wire.PingRequest{}); err != nil {
+			t.Fatalf("ping %d failed: %v", i, err)
+		}
+	}
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+}
+
+func TestRateLimitNoBurst(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	_, err := server.New(tmp.Path, server.LimitPeers(server.PeerLimits{Rate: 10}))
+	if err != server.ErrPeerBurst {
+		t.Fatalf("wrong error: %v", err)
+	}
+}
+
+func TestStreamLimit(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	_, _, client, cleanup := limitedPair(t, tmp, &wg, server.PeerLimits{MaxStreams: 1})
+	defer cleanup()
+
+	ctx := context.Background()
+	put := func(key string) (wire.Peer_ObjectPutClient, error) {
+		stream, err := client.ObjectPut(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := stream.Send(&wire.ObjectPutRequest{Key: []byte(key), Data: []byte("data")}); err != nil {
+			return nil, err
+		}
+		return stream, nil
+	}
+
+	// The server may admit the second stream before the held one;
+	// then the held one is refused or gets its turn later. Retry
+	// until the order is right.
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		held, err := put("held")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// give the server a moment to start handling it
+		if _, err := client.Ping(ctx, &wire.PingRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		other, err := put("other")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, otherErr := other.CloseAndRecv()
+		_, heldErr := held.CloseAndRecv()
+		if status.Code(otherErr) == codes.ResourceExhausted {
+			if heldErr != nil {
+				t.Fatalf("held stream failed: %v", heldErr)
+			}
+			break
+		}
+		if otherErr != nil {
+			t.Fatalf("expected ResourceExhausted, got %v", otherErr)
+		}
+		if heldErr != nil && status.Code(heldErr) != codes.ResourceExhausted {
+			t.Fatalf("held stream failed: %v", heldErr)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stream limit was not enforced")
+		}
+	}
+
+	// the slot is free again
+	stream, err := put("after")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Errorf("put after closing held stream failed: %v", err)
+	}
+}
@@ -22,6 +22,7 @@ func (p *peers) ObjectGet(req *wire.ObjectGetRequest, stream wire.Peer_ObjectGet
 		}
 		return err
 	}
+	defer store.Close()
 
 	buf, err := store.Get(stream.Context(), req.Key)
 	if err != nil {
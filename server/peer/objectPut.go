@@ -21,7 +21,9 @@ func (p *peers) ObjectPut(stream wire.Peer_ObjectPutServer) error {
 		}
 		return err
 	}
+	defer store.Close()
 
+	maxSize := p.app.PeerLimits().MaxObjectSize
 	var key []byte
 	var data []byte
 	for {
@@ -38,6 +40,9 @@ func (p *peers) ObjectPut(stream wire.Peer_ObjectPutServer) error {
 			}
 			key = req.Key
 		}
+		if maxSize > 0 && int64(len(data))+int64(len(req.Data)) > maxSize {
+			return status.Errorf(codes.ResourceExhausted, "object too large, limit is %d bytes", maxSize)
+		}
 		data = append(data, req.Data...)
 	}
 
@@ -0,0 +1,32 @@
+package peer
+
+import (
+	"context"
+	"log"
+
+	"bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Pair does not use p.auth, as the caller is not a peer yet. Unknown
+// callers only get this far while in pairing mode.
+func (p *peers) Pair(ctx context.Context, req *wire.PairRequest) (*wire.PairResponse, error) {
+	pub, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.app.Pair(pub, req.Secret); err != nil {
+		switch err {
+		case server.ErrPairingClosed:
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		case server.ErrPairingCode:
+			log.Printf("pairing: wrong code from %v", pub)
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return nil, err
+	}
+	log.Printf("pairing: peer %v is waiting for approval", pub)
+	return &wire.PairResponse{}, nil
+}
@@ -0,0 +1,202 @@
+package peer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	bazfstestutil "bazil.org/bazil/fs/fstestutil"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/server"
+	"bazil.org/bazil/server/http/httptest"
+	"bazil.org/bazil/util/grpcedtls"
+	"bazil.org/bazil/util/tempdir"
+	"github.com/agl/ed25519"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPair(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	web1 := httptest.ServeHTTP(t, &wg, app1)
+	defer web1.Close()
+
+	pub1 := (*peer.PublicKey)(app1.Keys.Sign.Pub)
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+
+	code, err := app1.StartPairing(time.Minute)
+	if err != nil {
+		t.Fatalf("start pairing: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	got, err := app2.PairWith(ctx, web1.Addr().String(), code)
+	if err != nil {
+		t.Fatalf("pair: %v", err)
+	}
+	if *got != *pub1 {
+		t.Errorf("paired with wrong peer: %v != %v", got, pub1)
+	}
+
+	pending := app1.PendingPeers()
+	if g, e := len(pending), 1; g != e {
+		t.Fatalf("wrong number of pending peers: %d != %d", g, e)
+	}
+	if g, e := pending[0].Pub, *pub2; g != e {
+		t.Errorf("wrong pending peer: %v != %v", &g, &e)
+	}
+
+	// not a peer until approved
+	client, err := app2.DialPeer(pub1)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("wrong error from ping before approval: %v", err)
+	}
+
+	if err := app1.ApprovePeer(pub2); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if g := app1.PendingPeers(); len(g) != 0 {
+		t.Errorf("still pending after approval: %v", g)
+	}
+	if err := app1.ApprovePeer(pub2); err != server.ErrPeerNotPending {
+		t.Errorf("wrong error from approving again: %v", err)
+	}
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); err != nil {
+		t.Errorf("ping failed: %v", err)
+	}
+
+	// the peer stays known after pairing mode ends
+	app1.StopPairing()
+	if err := app1.AdmitPeer(pub2); err != nil {
+		t.Errorf("approved peer not admitted: %v", err)
+	}
+}
+
+func TestPairBad(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	web1 := httptest.ServeHTTP(t, &wg, app1)
+	defer web1.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	addr := web1.Addr().String()
+
+	code, err := app1.StartPairing(time.Minute)
+	if err != nil {
+		t.Fatalf("start pairing: %v", err)
+	}
+
+	// a code made by someone else does not match the server
+	other, err := peer.NewPairingCode((*peer.PublicKey)(app2.Keys.Sign.Pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app2.PairWith(ctx, addr, other); err == nil {
+		t.Error("expected error for code of another peer")
+	}
+
+	// right server, wrong secret; enough of these end pairing mode.
+	// The fingerprint depends on the secret, so this has to skip
+	// PairWith.
+	auth := &grpcedtls.Authenticator{
+		Config: app2.GetTLSConfig,
+		Lookup: func(*[ed25519.PublicKeySize]byte) error { return nil },
+	}
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(auth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := wire.NewPeerClient(conn)
+	wrong := append([]byte(nil), code.Secret()...)
+	for i := range wrong {
+		wrong[i] ^= 0xff
+	}
+	for i := 0; i < 5; i++ {
+		_, err := client.Pair(ctx, &wire.PairRequest{Secret: wrong})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("wrong error from bad secret: %v", err)
+		}
+	}
+	if _, err := app2.PairWith(ctx, addr, code); status.Code(err) != codes.Unavailable {
+		t.Errorf("wrong error after pairing ended: %v", err)
+	}
+	if g := app1.PendingPeers(); len(g) != 0 {
+		t.Errorf("unexpected pending peers: %v", g)
+	}
+}
+
+func TestPairExpire(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+
+	pub := &peer.PublicKey{1, 2, 3}
+	code, err := app1.StartPairing(10 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("start pairing: %v", err)
+	}
+	if err := app1.AdmitPeer(pub); err != nil {
+		t.Errorf("not admitted while pairing: %v", err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for app1.AdmitPeer(pub) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("pairing mode did not end")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := app1.Pair(pub, code.Secret()); err != server.ErrPairingClosed {
+		t.Errorf("wrong error from pair: %v", err)
+	}
+}
+
+func TestPairAdmitLimit(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+
+	if _, err := app1.StartPairing(time.Minute); err != nil {
+		t.Fatalf("start pairing: %v", err)
+	}
+	first := &peer.PublicKey{1}
+	if err := app1.AdmitPeer(first); err != nil {
+		t.Fatalf("not admitted while pairing: %v", err)
+	}
+	var err error
+	for i := 1; i < 1000 && err == nil; i++ {
+		pub := &peer.PublicKey{1, byte(i), byte(i >> 8)}
+		err = app1.AdmitPeer(pub)
+	}
+	if err != server.ErrPairingFull {
+		t.Fatalf("wrong error when admitting many peers: %v", err)
+	}
+	if err := app1.AdmitPeer(first); err != nil {
+		t.Errorf("admitted peer no longer let in: %v", err)
+	}
+}
@@ -8,22 +8,17 @@ import (
 	"bazil.org/bazil/peer/wire"
 	"bazil.org/bazil/server"
 	"bazil.org/bazil/util/grpcedtls"
+	"github.com/agl/ed25519"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	grpcpeer "google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 func (p *peers) auth(ctx context.Context) (*peer.PublicKey, error) {
-	peerInfo, ok := grpcpeer.FromContext(ctx)
-	if !ok {
-		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
-	}
-	auth, ok := peerInfo.AuthInfo.(*grpcedtls.Auth)
-	if !ok {
-		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated")
+	pub, err := caller(ctx)
+	if err != nil {
+		return nil, err
 	}
-	pub := (*peer.PublicKey)(auth.PeerPub)
 	getPeer := func(tx *db.Tx) error {
 		_, err := tx.Peers().Get(pub)
 		return err
@@ -44,10 +39,18 @@ type peers struct {
 func New(app *server.App) *grpc.Server {
 	auth := &grpcedtls.Authenticator{
 		Config: app.GetTLSConfig,
-		// TODO Lookup:
+		Lookup: func(pub *[ed25519.PublicKeySize]byte) error {
+			return app.AdmitPeer((*peer.PublicKey)(pub))
+		},
 	}
+	limits := newLimiter(app.PeerLimits())
+	// audit first, so calls over the limits are recorded too
+	audit := &auditor{app: app}
 	srv := grpc.NewServer(
-		grpc.Creds(auth),
+		grpc.Creds(app.TrackPeerCreds(auth)),
+		grpc.UnaryInterceptor(chainUnary(audit.unary, limits.unary)),
+		grpc.StreamInterceptor(chainStream(audit.stream, limits.stream)),
+		grpc.KeepaliveEnforcementPolicy(server.PeerKeepaliveEnforcement),
 	)
 	rpc := &peers{app: app}
 	wire.RegisterPeerServer(srv, rpc)
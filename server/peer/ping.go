@@ -3,13 +3,19 @@ package peer
 import (
 	"context"
 
+	"bazil.org/bazil/peer"
 	"bazil.org/bazil/peer/wire"
 )
 
 func (p *peers) Ping(ctx context.Context, req *wire.PingRequest) (*wire.PingResponse, error) {
-	_, err := p.auth(ctx)
-	if err != nil {
+	if _, err := p.auth(ctx); err != nil {
 		return nil, err
 	}
-	return &wire.PingResponse{}, nil
+	// Nothing is remembered about the caller; requests that depend
+	// on what it supports say so themselves.
+	resp := &wire.PingResponse{
+		Version:      peer.ProtocolVersion,
+		Capabilities: peer.Capabilities(),
+	}
+	return resp, nil
 }
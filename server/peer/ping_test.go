@@ -2,13 +2,16 @@ package peer_test
 
 import (
 	"context"
+	"net"
 	"sync"
 	"testing"
+	"time"
 
 	"bazil.org/bazil/db"
 	bazfstestutil "bazil.org/bazil/fs/fstestutil"
 	"bazil.org/bazil/peer"
 	"bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/server"
 	"bazil.org/bazil/server/http/httptest"
 	"bazil.org/bazil/util/tempdir"
 	"google.golang.org/grpc/codes"
@@ -67,6 +70,83 @@ func TestPing(t *testing.T) {
 	}
 }
 
+func TestPingFailover(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	web1 := httptest.ServeHTTP(t, &wg, app1)
+	defer web1.Close()
+
+	// find an address nobody is listening on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadAddr := l.Addr().String()
+	l.Close()
+
+	pub1 := (*peer.PublicKey)(app1.Keys.Sign.Pub)
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+
+	setup1 := func(tx *db.Tx) error {
+		if _, err := tx.Peers().Make(pub2); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := app1.DB.Update(setup1); err != nil {
+		t.Fatalf("app1 setup: %v", err)
+	}
+
+	setup2 := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(pub1)
+		if err != nil {
+			return err
+		}
+		if err := p.Locations().Add(deadAddr, 0); err != nil {
+			return err
+		}
+		if err := p.Locations().Add(web1.Addr().String(), 10); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := app2.DB.Update(setup2); err != nil {
+		t.Fatalf("app2 setup location: %v", err)
+	}
+
+	client, err := app2.DialPeer(pub1)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); err != nil {
+		t.Errorf("ping failed: %v", err)
+	}
+
+	check := func(tx *db.Tx) error {
+		p, err := tx.Peers().Get(pub1)
+		if err != nil {
+			return err
+		}
+		if g, e := p.Locations().Last(), web1.Addr().String(); g != e {
+			t.Errorf("wrong last location: %q != %q", g, e)
+		}
+		return nil
+	}
+	if err := app2.DB.View(check); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestPingBadNotPeer(t *testing.T) {
 	tmp := tempdir.New(t)
 	defer tmp.Cleanup()
@@ -102,8 +182,114 @@ func TestPingBadNotPeer(t *testing.T) {
 	}
 	defer client.Close()
 
+	// unknown peers are turned away in the handshake
 	ctx := context.Background()
-	if _, err := client.Ping(ctx, &wire.PingRequest{}); status.Code(err) != codes.PermissionDenied {
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("wrong error from ping: %v", err)
+	}
+	if err := app1.AdmitPeer((*peer.PublicKey)(app2.Keys.Sign.Pub)); err != server.ErrUnknownPeer {
+		t.Errorf("wrong error from admit: %v", err)
+	}
+}
+
+func TestPingBadRemovedPeer(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app1 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app1"), "1")
+	defer app1.Close()
+	app2 := bazfstestutil.NewAppWithName(t, tmp.Subdir("app2"), "2")
+	defer app2.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	web1 := httptest.ServeHTTP(t, &wg, app1)
+	defer web1.Close()
+
+	pub1 := (*peer.PublicKey)(app1.Keys.Sign.Pub)
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+
+	setup1 := func(tx *db.Tx) error {
+		if _, err := tx.Peers().Make(pub2); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := app1.DB.Update(setup1); err != nil {
+		t.Fatalf("app1 setup: %v", err)
+	}
+
+	setup2 := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(pub1)
+		if err != nil {
+			return err
+		}
+		if err := p.Locations().Set(web1.Addr().String()); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := app2.DB.Update(setup2); err != nil {
+		t.Fatalf("app2 setup location: %v", err)
+	}
+
+	client, err := app2.DialPeer(pub1)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+
+	if err := app1.RemovePeer(pub2); err != nil {
+		t.Fatalf("remove peer: %v", err)
+	}
+
+	// the old connection was closed, and reconnecting fails the
+	// handshake
+	if _, err := client.Ping(ctx, &wire.PingRequest{}); status.Code(err) != codes.Unavailable {
 		t.Errorf("wrong error from ping: %v", err)
 	}
+	if err := app1.AdmitPeer(pub2); err != server.ErrUnknownPeer {
+		t.Errorf("wrong error from admit: %v", err)
+	}
+}
+
+func TestPingHandshake(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	_, _, client, cleanup := limitedPair(t, tmp, &wg, server.DefaultPeerLimits)
+	defer cleanup()
+
+	ctx := context.Background()
+	resp, err := client.Ping(ctx, &wire.PingRequest{})
+	if err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if g, e := resp.Version, uint32(peer.ProtocolVersion); g != e {
+		t.Errorf("wrong version: %d != %d", g, e)
+	}
+
+	proto, err := client.Protocol(ctx)
+	if err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+	if g, e := proto.Version, uint32(peer.ProtocolVersion); g != e {
+		t.Errorf("wrong negotiated version: %d != %d", g, e)
+	}
+	if err := proto.Require(peer.CapObjects, peer.CapVolumeSync, peer.CapSyncTombstones); err != nil {
+		t.Error(err)
+	}
+	again, err := client.Protocol(ctx)
+	if err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+	if again != proto {
+		t.Error("handshake result was not cached")
+	}
 }
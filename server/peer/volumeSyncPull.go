@@ -2,6 +2,7 @@ package peer
 
 import (
 	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
 	"bazil.org/bazil/peer/wire"
 	"bazil.org/fuse"
 	"google.golang.org/grpc/codes"
@@ -25,15 +26,21 @@ func (p *peers) VolumeSyncPull(req *wire.VolumeSyncPullRequest, stream wire.Peer
 			return err
 		}
 		vol, err := tx.Volumes().GetByVolumeID(&volID)
+		var grant *db.VolumeGrant
+		if err == nil {
+			grant, err = client.Volumes().Grant(vol)
+		}
 		// do not leak names peer has no access to; not found gets the
 		// same error as not allowed
-		if (err == nil && !client.Volumes().IsAllowed(vol)) ||
-			err == db.ErrVolumeIDNotFound {
+		if err == db.ErrPeerVolumeNotAllowed || err == db.ErrVolumeIDNotFound {
 			err = status.Errorf(codes.PermissionDenied, "peer is not authorized for that volume")
 		}
 		if err != nil {
 			return err
 		}
+		if !grant.AllowsPath(req.Path) {
+			return status.Errorf(codes.PermissionDenied, "peer is not authorized for that path")
+		}
 		return nil
 	}
 	if err := p.app.DB.View(view); err != nil {
@@ -46,7 +53,11 @@ func (p *peers) VolumeSyncPull(req *wire.VolumeSyncPullRequest, stream wire.Peer
 	}
 	defer v.Close()
 
-	if err := v.FS().SyncSend(ctx, req.Path, stream.Send); err != nil {
+	send := stream.Send
+	if !peer.Negotiate(req.Version, req.Capabilities).Has(peer.CapSyncTombstones) {
+		send = withoutTombstones(send)
+	}
+	if err := v.FS().SyncSend(ctx, req.Path, send); err != nil {
 		if err == fuse.ENOENT {
 			return status.Errorf(codes.NotFound, "not found")
 		}
@@ -54,3 +65,21 @@ func (p *peers) VolumeSyncPull(req *wire.VolumeSyncPullRequest, stream wire.Peer
 	}
 	return nil
 }
+
+// withoutTombstones downgrades sync for peers that do not understand
+// tombstones. They will not learn about removed entries, but can
+// still receive everything else.
+func withoutTombstones(send func(*wire.VolumeSyncPullItem) error) func(*wire.VolumeSyncPullItem) error {
+	filter := func(item *wire.VolumeSyncPullItem) error {
+		children := make([]*wire.Dirent, 0, len(item.Children))
+		for _, de := range item.Children {
+			if _, ok := de.Type.(*wire.Dirent_Tombstone); ok {
+				continue
+			}
+			children = append(children, de)
+		}
+		item.Children = children
+		return send(item)
+	}
+	return filter
+}
@@ -0,0 +1,30 @@
+package peer
+
+import (
+	"testing"
+
+	"bazil.org/bazil/peer/wire"
+)
+
+func TestWithoutTombstones(t *testing.T) {
+	var got []string
+	send := withoutTombstones(func(item *wire.VolumeSyncPullItem) error {
+		for _, de := range item.Children {
+			got = append(got, de.Name)
+		}
+		return nil
+	})
+	item := &wire.VolumeSyncPullItem{
+		Children: []*wire.Dirent{
+			{Name: "a", Type: &wire.Dirent_Dir{Dir: &wire.Dir{}}},
+			{Name: "b", Type: &wire.Dirent_Tombstone{Tombstone: &wire.Tombstone{}}},
+			{Name: "c", Type: &wire.Dirent_File{File: &wire.File{}}},
+		},
+	}
+	if err := send(item); err != nil {
+		t.Fatal(err)
+	}
+	if g, e := len(got), 2; g != e || got[0] != "a" || got[1] != "c" {
+		t.Errorf("wrong entries sent: %q", got)
+	}
+}
@@ -17,8 +17,11 @@ import (
 	bazfstestutil "bazil.org/bazil/fs/fstestutil"
 	"bazil.org/bazil/peer"
 	"bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/server"
 	"bazil.org/bazil/server/http/httptest"
 	"bazil.org/bazil/util/tempdir"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestSyncPull(t *testing.T) {
@@ -112,6 +115,7 @@ func TestSyncPull(t *testing.T) {
 	if err := app2.DB.View(openKV); err != nil {
 		t.Fatalf("cannot open storage for app2: %v", err)
 	}
+	defer chunkStore2.Close()
 
 	const testFileName = "greeting"
 	const testFileContent = "hello, world"
@@ -216,3 +220,56 @@ func TestSyncPull(t *testing.T) {
 
 // TODO TestSyncPullBadNotPeer
 // TODO TestSyncPullBadPeerNotAllowed
+
+func TestSyncPullPathNotAllowed(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	app1, app2, client, cleanup := limitedPair(t, tmp, &wg, server.DefaultPeerLimits)
+	defer cleanup()
+
+	pub2 := (*peer.PublicKey)(app2.Keys.Sign.Pub)
+	var volID db.VolumeID
+	setup1 := func(tx *db.Tx) error {
+		sharingKey, err := tx.SharingKeys().Get("default")
+		if err != nil {
+			return err
+		}
+		v, err := tx.Volumes().Create("foo", "local", sharingKey)
+		if err != nil {
+			return err
+		}
+		v.VolumeID(&volID)
+		p, err := tx.Peers().Get(pub2)
+		if err != nil {
+			return err
+		}
+		return p.Volumes().AllowGrant(v, &db.VolumeGrant{Paths: []string{"shared"}})
+	}
+	if err := app1.DB.Update(setup1); err != nil {
+		t.Fatalf("app1 setup: %v", err)
+	}
+
+	ctx := context.Background()
+	pull := func(p string) error {
+		stream, err := client.VolumeSyncPull(ctx, &wire.VolumeSyncPullRequest{
+			VolumeID: volID[:],
+			Path:     p,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+	for _, p := range []string{"", "other", "shared/../other"} {
+		if err := pull(p); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("pull %q: expected PermissionDenied, got %v", p, err)
+		}
+	}
+	// allowed, but does not exist
+	if err := pull("shared"); status.Code(err) != codes.NotFound {
+		t.Errorf("pull of allowed path: expected NotFound, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"io"
+	"net"
+	"sync"
+
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/util/grpcedtls"
+	"google.golang.org/grpc/credentials"
+)
+
+// trackPeerConn remembers c as a connection to or from the given
+// peer, so it can be closed if the peer is removed. The returned
+// function must be called when c is closed by other means.
+func (app *App) trackPeerConn(pub *peer.PublicKey, c io.Closer) (untrack func()) {
+	key := *pub
+	app.peerConns.Lock()
+	defer app.peerConns.Unlock()
+	set, ok := app.peerConns.open[key]
+	if !ok {
+		set = make(map[io.Closer]struct{})
+		app.peerConns.open[key] = set
+	}
+	set[c] = struct{}{}
+
+	var once sync.Once
+	untrack = func() {
+		once.Do(func() {
+			app.peerConns.Lock()
+			defer app.peerConns.Unlock()
+			// the set may have been replaced by closePeerConns
+			cur := app.peerConns.open[key]
+			delete(cur, c)
+			if len(cur) == 0 {
+				delete(app.peerConns.open, key)
+			}
+		})
+	}
+	return untrack
+}
+
+// closePeerConns closes all tracked connections to and from the
+// given peer.
+func (app *App) closePeerConns(pub *peer.PublicKey) {
+	app.peerConns.Lock()
+	set := app.peerConns.open[*pub]
+	delete(app.peerConns.open, *pub)
+	app.peerConns.Unlock()
+
+	for c := range set {
+		// the connection is being torn down, there's nothing useful
+		// to do with the error
+		_ = c.Close()
+	}
+}
+
+type trackedConn struct {
+	net.Conn
+	untrack func()
+}
+
+func (c *trackedConn) Close() error {
+	c.untrack()
+	return c.Conn.Close()
+}
+
+type trackedCreds struct {
+	*grpcedtls.Authenticator
+	app *App
+}
+
+var _ credentials.TransportCredentials = (*trackedCreds)(nil)
+
+func (t *trackedCreds) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, authInfo, err := t.Authenticator.ServerHandshake(rawConn)
+	if err != nil {
+		return nil, nil, err
+	}
+	auth, ok := authInfo.(*grpcedtls.Auth)
+	if !ok {
+		// cannot happen with grpcedtls, be paranoid anyway
+		return conn, authInfo, nil
+	}
+	tc := &trackedConn{Conn: conn}
+	tc.untrack = t.app.trackPeerConn((*peer.PublicKey)(auth.PeerPub), tc)
+	return tc, authInfo, nil
+}
+
+func (t *trackedCreds) Clone() credentials.TransportCredentials {
+	tt := &trackedCreds{
+		Authenticator: t.Authenticator.Clone().(*grpcedtls.Authenticator),
+		app:           t.app,
+	}
+	return tt
+}
+
+// TrackPeerCreds wraps the server side of peer authentication, so
+// that incoming connections are closed if the peer is removed.
+func (app *App) TrackPeerCreds(auth *grpcedtls.Authenticator) credentials.TransportCredentials {
+	return &trackedCreds{
+		Authenticator: auth,
+		app:           app,
+	}
+}
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"bazil.org/bazil/peer"
+	wirepeer "bazil.org/bazil/peer/wire"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Connections to peers are closed after being unused this long.
+	peerIdleTimeout = 5 * time.Minute
+	// How often to look for idle connections.
+	peerIdleCheck = time.Minute
+)
+
+// peerKeepalive makes dead connections to peers be noticed even
+// when no calls are in progress.
+var peerKeepalive = keepalive.ClientParameters{
+	Time:                30 * time.Second,
+	Timeout:             10 * time.Second,
+	PermitWithoutStream: true,
+}
+
+// PeerKeepaliveEnforcement is the keepalive policy for the peer
+// server, allowing the pings of our own clients.
+var PeerKeepaliveEnforcement = keepalive.EnforcementPolicy{
+	MinTime:             20 * time.Second,
+	PermitWithoutStream: true,
+}
+
+const pingMethod = "/bazil.peer.Peer/Ping"
+
+// pooledConn is a connection to a peer, shared by all clients of
+// that peer.
+type pooledConn struct {
+	app     *App
+	pub     peer.PublicKey
+	conn    *grpc.ClientConn
+	client  wirepeer.PeerClient
+	untrack func()
+
+	// Protected by app.peerPool.
+	refs      int
+	idleSince time.Time
+
+	proto struct {
+		sync.Mutex
+		negotiated *peer.Protocol
+	}
+}
+
+// Close closes the connection, even if it is still in use, and
+// removes it from the pool.
+func (pc *pooledConn) Close() error {
+	pc.app.peerPool.Lock()
+	if pc.app.peerPool.conns[pc.pub] == pc {
+		delete(pc.app.peerPool.conns, pc.pub)
+	}
+	pc.app.peerPool.Unlock()
+	return pc.close()
+}
+
+// close closes the connection, which must already be out of the
+// pool.
+func (pc *pooledConn) close() error {
+	pc.untrack()
+	return pc.conn.Close()
+}
+
+func (pc *pooledConn) protocol(ctx context.Context) (*peer.Protocol, error) {
+	// holding the lock over the handshake makes concurrent first
+	// callers wait for it, instead of all pinging the peer
+	pc.proto.Lock()
+	defer pc.proto.Unlock()
+	if pc.proto.negotiated != nil {
+		return pc.proto.negotiated, nil
+	}
+	resp, err := pc.client.Ping(ctx, &wirepeer.PingRequest{
+		Version:      peer.ProtocolVersion,
+		Capabilities: peer.Capabilities(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	negotiated := peer.Negotiate(resp.Version, resp.Capabilities)
+	pc.proto.negotiated = negotiated
+	return negotiated, nil
+}
+
+// peerDial is a dial of a pooled connection in progress.
+type peerDial struct {
+	// Closed when the dial is done.
+	done chan struct{}
+	// Set before done is closed.
+	err error
+}
+
+// getPooledConn returns the pooled connection to the peer, with a
+// reference held, dialing if needed. The pool is not locked while
+// dialing, so other peers need not wait.
+func (app *App) getPooledConn(pub *peer.PublicKey) (*pooledConn, error) {
+	for {
+		app.peerPool.Lock()
+		if pc, ok := app.peerPool.conns[*pub]; ok {
+			pc.refs++
+			app.peerPool.Unlock()
+			return pc, nil
+		}
+		if d, ok := app.peerPool.dialing[*pub]; ok {
+			app.peerPool.Unlock()
+			<-d.done
+			if d.err != nil {
+				return nil, d.err
+			}
+			// the connection may be closed again already, so
+			// look it up anew
+			continue
+		}
+		d := &peerDial{done: make(chan struct{})}
+		app.peerPool.dialing[*pub] = d
+		app.peerPool.Unlock()
+		return app.dialPooledConn(pub, d)
+	}
+}
+
+// dialPooledConn dials the peer and adds the connection to the pool,
+// with a reference held, completing d.
+func (app *App) dialPooledConn(pub *peer.PublicKey, d *peerDial) (*pooledConn, error) {
+	defer close(d.done)
+	conn, err := app.dialPeerConn(pub)
+
+	app.peerPool.Lock()
+	defer app.peerPool.Unlock()
+	delete(app.peerPool.dialing, *pub)
+	if err != nil {
+		d.err = err
+		return nil, err
+	}
+	pc := &pooledConn{
+		app:    app,
+		pub:    *pub,
+		conn:   conn,
+		client: wirepeer.NewPeerClient(conn),
+	}
+	pc.untrack = app.trackPeerConn(pub, pc)
+	app.peerPool.conns[*pub] = pc
+	pc.refs++
+	return pc, nil
+}
+
+func (app *App) releasePooledConn(pc *pooledConn) {
+	app.peerPool.Lock()
+	defer app.peerPool.Unlock()
+	pc.refs--
+	if pc.refs == 0 {
+		pc.idleSince = time.Now()
+	}
+}
+
+// closeIdlePeerConns closes pooled connections that have not been
+// used since before the cutoff.
+func (app *App) closeIdlePeerConns(cutoff time.Time) {
+	var idle []*pooledConn
+	app.peerPool.Lock()
+	for pub, pc := range app.peerPool.conns {
+		if pc.refs == 0 && pc.idleSince.Before(cutoff) {
+			// removing them while still holding the lock keeps
+			// getPooledConn from handing them out again
+			delete(app.peerPool.conns, pub)
+			idle = append(idle, pc)
+		}
+	}
+	app.peerPool.Unlock()
+
+	for _, pc := range idle {
+		// nothing useful to do with the error
+		_ = pc.close()
+	}
+}
+
+// closeAllPeerConns closes all pooled connections, used or not.
+func (app *App) closeAllPeerConns() {
+	var all []*pooledConn
+	app.peerPool.Lock()
+	for pub, pc := range app.peerPool.conns {
+		delete(app.peerPool.conns, pub)
+		all = append(all, pc)
+	}
+	app.peerPool.Unlock()
+
+	for _, pc := range all {
+		_ = pc.close()
+	}
+}
+
+func (app *App) reapIdlePeerConns(ctx context.Context) {
+	ticker := time.NewTicker(peerIdleCheck)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			app.closeIdlePeerConns(now.Add(-peerIdleTimeout))
+		}
+	}
+}
+
+type peerHealth struct {
+	lastSeen  time.Time
+	lastError string
+	latency   time.Duration
+}
+
+// PeerHealth describes what we know about reaching a peer.
+type PeerHealth struct {
+	// Whether there is an open connection to the peer.
+	Connected bool
+	// State of the connection, if any.
+	State string
+	// Number of clients using the connection.
+	InUse int
+	// Last time the peer answered a call. Zero if never.
+	LastSeen time.Time
+	// Why the last call failed, or empty if it succeeded.
+	LastError string
+	// Round trip time of the last successful ping.
+	Latency time.Duration
+}
+
+// recordPeerCall updates the health of the peer after a call.
+func (app *App) recordPeerCall(pub *peer.PublicKey, method string, rtt time.Duration, err error) {
+	app.peerPool.Lock()
+	defer app.peerPool.Unlock()
+	h, ok := app.peerPool.health[*pub]
+	if !ok {
+		h = &peerHealth{}
+		app.peerPool.health[*pub] = h
+	}
+	switch status.Code(err) {
+	case codes.OK:
+		h.lastSeen = time.Now()
+		h.lastError = ""
+		if method == pingMethod {
+			h.latency = rtt
+		}
+	case codes.Canceled:
+		// our own doing, says nothing about the peer
+	case codes.Unavailable, codes.DeadlineExceeded:
+		h.lastError = err.Error()
+	case codes.PermissionDenied, codes.Unauthenticated:
+		// the peer answered, but does not want to talk to us
+		h.lastSeen = time.Now()
+		h.lastError = err.Error()
+	default:
+		// errors about the request itself
+		h.lastSeen = time.Now()
+		h.lastError = ""
+	}
+}
+
+func (app *App) peerInterceptors(pub *peer.PublicKey) []grpc.DialOption {
+	key := *pub
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		app.recordPeerCall(&key, method, time.Since(start), err)
+		return err
+	}
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		app.recordPeerCall(&key, method, time.Since(start), err)
+		return s, err
+	}
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(unary),
+		grpc.WithStreamInterceptor(stream),
+	}
+}
+
+// PeerHealth returns what is known about reaching the peer.
+func (app *App) PeerHealth(pub *peer.PublicKey) PeerHealth {
+	app.peerPool.Lock()
+	defer app.peerPool.Unlock()
+	var health PeerHealth
+	if h, ok := app.peerPool.health[*pub]; ok {
+		health.LastSeen = h.lastSeen
+		health.LastError = h.lastError
+		health.Latency = h.latency
+	}
+	if pc, ok := app.peerPool.conns[*pub]; ok {
+		health.Connected = true
+		health.State = pc.conn.GetState().String()
+		health.InUse = pc.refs
+	}
+	return health
+}
+
+// PingPeer checks that the peer can be reached, updating its health.
+func (app *App) PingPeer(ctx context.Context, pub *peer.PublicKey) error {
+	client, err := app.DialPeer(pub)
+	if err != nil {
+		// nowhere to connect counts as unreachable
+		app.recordPeerCall(pub, pingMethod, 0, status.Error(codes.Unavailable, err.Error()))
+		return err
+	}
+	defer client.Close()
+	_, err = client.Ping(ctx, &wirepeer.PingRequest{
+		Version:      peer.ProtocolVersion,
+		Capabilities: peer.Capabilities(),
+	})
+	return err
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/peer"
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestPeerPool(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	pub := peer.PublicKey{1, 2, 3, 4, 5}
+	setup := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(&pub)
+		if err != nil {
+			return err
+		}
+		// never reached; connecting happens in the background
+		return p.Locations().Set("127.0.0.1:1")
+	}
+	if err := app.DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+
+	c1, err := app.DialPeer(&pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := app.DialPeer(&pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := c1.(*peerClient).pc
+	if c2.(*peerClient).pc != pc {
+		t.Fatal("connection was not reused")
+	}
+	if g, e := app.PeerHealth(&pub).InUse, 2; g != e {
+		t.Errorf("wrong use count: %d != %d", g, e)
+	}
+	c1.Close()
+	// closing twice must not release twice
+	c1.Close()
+	if g, e := app.PeerHealth(&pub).InUse, 1; g != e {
+		t.Errorf("wrong use count: %d != %d", g, e)
+	}
+
+	// in use, not idle
+	app.closeIdlePeerConns(time.Now().Add(time.Hour))
+	if !app.PeerHealth(&pub).Connected {
+		t.Fatal("connection in use was closed")
+	}
+
+	c2.Close()
+	app.closeIdlePeerConns(time.Now().Add(-time.Hour))
+	if !app.PeerHealth(&pub).Connected {
+		t.Fatal("connection closed before idle timeout")
+	}
+	app.closeIdlePeerConns(time.Now().Add(time.Hour))
+	if app.PeerHealth(&pub).Connected {
+		t.Fatal("idle connection was not closed")
+	}
+
+	c3, err := app.DialPeer(&pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c3.Close()
+	if c3.(*peerClient).pc == pc {
+		t.Error("closed connection was reused")
+	}
+
+	if err := app.RemovePeer(&pub); err != nil {
+		t.Fatal(err)
+	}
+	if app.PeerHealth(&pub).Connected {
+		t.Error("connection to removed peer was not closed")
+	}
+}
+
+func TestPeerPoolConcurrentDial(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(tmp.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	pub := peer.PublicKey{1, 2, 3, 4, 5}
+	setup := func(tx *db.Tx) error {
+		p, err := tx.Peers().Make(&pub)
+		if err != nil {
+			return err
+		}
+		return p.Locations().Set("127.0.0.1:1")
+	}
+	if err := app.DB.Update(setup); err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 10
+	conns := make(chan *pooledConn, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			pc, err := app.getPooledConn(&pub)
+			if err != nil {
+				t.Error(err)
+			}
+			conns <- pc
+		}()
+	}
+	first := <-conns
+	for i := 1; i < n; i++ {
+		if pc := <-conns; pc != first {
+			t.Error("connection was dialed more than once")
+		}
+	}
+	if g, e := app.PeerHealth(&pub).InUse, n; g != e {
+		t.Errorf("wrong use count: %d != %d", g, e)
+	}
+
+	// unknown peers fail without blocking the pool
+	other := peer.PublicKey{6, 7, 8}
+	if _, err := app.getPooledConn(&other); err == nil {
+		t.Error("expected error")
+	}
+	if _, ok := app.peerPool.dialing[other]; ok {
+		t.Error("failed dial left behind")
+	}
+}
@@ -0,0 +1,460 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"bazil.org/bazil/cas"
+	"bazil.org/bazil/cas/chunks"
+	"bazil.org/bazil/cas/chunks/kvchunks"
+	"bazil.org/bazil/db"
+	wiredb "bazil.org/bazil/db/wire"
+	"bazil.org/bazil/kv"
+	"bazil.org/bazil/kv/untrusted"
+)
+
+var ErrSharingKeyRotating = errors.New("sharing key is being rotated already")
+
+// How many re-encrypted chunks to batch before recording progress
+// in the database.
+const rotationCheckpoint = 1000
+
+// RotateSharingKey replaces the named sharing key with a new
+// generation, for all volume storage using it. Chunks are
+// re-encrypted with the new sharing key in the background; until
+// that is complete, chunks encrypted with the old sharing key remain
+// readable. Returns the name of the new sharing key.
+//
+// The new sharing key uses the given padding mode, or the padding of
+// the old sharing key if padding is empty. Rotating is the only way
+// to change the padding of existing data.
+//
+// Volumes that use the sharing key must not be in use; this returns
+// ErrVolumeInUse. If the sharing key is already being rotated,
+// returns ErrSharingKeyRotating.
+func (app *App) RotateSharingKey(name string, padding string) (newName string, err error) {
+	if padding != "" {
+		if _, err := untrusted.ParsePadding(padding); err != nil {
+			return "", err
+		}
+	}
+
+	// hold the lock over the transaction, to keep the volumes from
+	// being opened with the old storage configuration
+	app.volumes.Lock()
+	defer app.volumes.Unlock()
+
+	start := func(tx *db.Tx) error {
+		var state wiredb.SharingKeyRotation
+		switch err := tx.SharingKeyRotations().Get(name, &state); {
+		case err == db.ErrSharingKeyRotationNotFound:
+		case err != nil:
+			return err
+		case !state.Done:
+			return ErrSharingKeyRotating
+		}
+
+		sharingKey, err := tx.SharingKeys().Rotate(name)
+		if err != nil {
+			return err
+		}
+		newName = sharingKey.Name()
+		if padding != "" {
+			if err := sharingKey.SetPadding(padding); err != nil {
+				return err
+			}
+		}
+
+		var volumes uint32
+		c := tx.Volumes().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			vol := item.Volume()
+			storage, err := storageUsing(vol, name)
+			if err != nil {
+				return err
+			}
+			if len(storage) == 0 {
+				continue
+			}
+			var volID db.VolumeID
+			vol.VolumeID(&volID)
+			if _, found := app.volumes.open[volID]; found {
+				return ErrVolumeInUse
+			}
+			for _, s := range storage {
+				if err := vol.Storage().RotateSharingKey(s, sharingKey); err != nil {
+					return err
+				}
+			}
+			volumes++
+		}
+
+		state = wiredb.SharingKeyRotation{
+			NewName: newName,
+			Volumes: volumes,
+		}
+		return tx.SharingKeyRotations().Put(name, &state)
+	}
+	if err := app.DB.Update(start); err != nil {
+		return "", err
+	}
+	app.startRotation(name)
+	return newName, nil
+}
+
+// storageUsing returns the names of the volume storage items that
+// use the named sharing key.
+//
+// If one of them is still in the middle of an earlier rotation,
+// returns ErrSharingKeyRotating.
+func storageUsing(vol *db.Volume, sharingKeyName string) ([]string, error) {
+	var names []string
+	c := vol.Storage().Cursor()
+	for item := c.First(); item != nil; item = c.Next() {
+		n, err := item.SharingKeyName()
+		if err != nil {
+			return nil, err
+		}
+		if n != sharingKeyName {
+			continue
+		}
+		old, err := item.OldSharingKeyName()
+		if err != nil {
+			return nil, err
+		}
+		if old != "" {
+			return nil, ErrSharingKeyRotating
+		}
+		names = append(names, item.Name())
+	}
+	return names, nil
+}
+
+// ResumeSharingKeyRotations restarts the background work for sharing
+// key rotations that did not complete before the last shutdown.
+func (app *App) ResumeSharingKeyRotations() error {
+	var pending []string
+	find := func(tx *db.Tx) error {
+		c := tx.SharingKeyRotations().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			var state wiredb.SharingKeyRotation
+			if err := item.State(&state); err != nil {
+				return err
+			}
+			if !state.Done {
+				pending = append(pending, item.OldName())
+			}
+		}
+		return nil
+	}
+	if err := app.DB.View(find); err != nil {
+		return err
+	}
+	for _, name := range pending {
+		app.startRotation(name)
+	}
+	return nil
+}
+
+func (app *App) startRotation(oldName string) {
+	app.rotations.Lock()
+	defer app.rotations.Unlock()
+	if _, found := app.rotations.running[oldName]; found {
+		return
+	}
+	work := func(ctx context.Context) {
+		defer func() {
+			app.rotations.Lock()
+			delete(app.rotations.running, oldName)
+			app.rotations.Unlock()
+		}()
+		if err := app.rotate(ctx, oldName); err != nil {
+			if ctx.Err() != nil {
+				// shutting down; will resume on next start
+				return
+			}
+			log.Printf("sharing key rotation of %q failed: %v", oldName, err)
+		}
+	}
+	if app.goBackground(work) {
+		app.rotations.running[oldName] = struct{}{}
+	}
+}
+
+// rotate re-encrypts the chunks of every volume still using the old
+// sharing key, one volume at a time. Progress is recorded after
+// every volume, so an interrupted rotation resumes from the first
+// volume not yet completed.
+func (app *App) rotate(ctx context.Context, oldName string) error {
+	for {
+		var volID db.VolumeID
+		found := false
+		next := func(tx *db.Tx) error {
+			c := tx.Volumes().Cursor()
+			for item := c.First(); item != nil; item = c.Next() {
+				vol := item.Volume()
+				storage, err := storageRotatingFrom(vol, oldName)
+				if err != nil {
+					return err
+				}
+				if len(storage) > 0 {
+					vol.VolumeID(&volID)
+					found = true
+					return nil
+				}
+			}
+			return nil
+		}
+		if err := app.DB.View(next); err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		if err := app.rotateVolume(ctx, oldName, &volID); err != nil {
+			if err == db.ErrVolumeIDNotFound {
+				// deleted while we were working on it
+				continue
+			}
+			return fmt.Errorf("volume %v: %v", volID, err)
+		}
+	}
+
+	done := func(tx *db.Tx) error {
+		var state wiredb.SharingKeyRotation
+		if err := tx.SharingKeyRotations().Get(oldName, &state); err != nil {
+			return err
+		}
+		state.Done = true
+		return tx.SharingKeyRotations().Put(oldName, &state)
+	}
+	return app.DB.Update(done)
+}
+
+// storageRotatingFrom returns the names of the volume storage items
+// that still need chunks re-encrypted from the named sharing key.
+func storageRotatingFrom(vol *db.Volume, oldName string) ([]string, error) {
+	var names []string
+	c := vol.Storage().Cursor()
+	for item := c.First(); item != nil; item = c.Next() {
+		n, err := item.OldSharingKeyName()
+		if err != nil {
+			return nil, err
+		}
+		if n == oldName {
+			names = append(names, item.Name())
+		}
+	}
+	return names, nil
+}
+
+func (app *App) addRotationProgress(oldName string, volumes uint32, chunks uint64) error {
+	progress := func(tx *db.Tx) error {
+		var state wiredb.SharingKeyRotation
+		if err := tx.SharingKeyRotations().Get(oldName, &state); err != nil {
+			return err
+		}
+		state.VolumesDone += volumes
+		state.Chunks += chunks
+		return tx.SharingKeyRotations().Put(oldName, &state)
+	}
+	return app.DB.Update(progress)
+}
+
+func (app *App) rotateVolume(ctx context.Context, oldName string, volID *db.VolumeID) error {
+	r := &rotation{
+		ctx:  ctx,
+		seen: make(map[cas.Key]struct{}),
+	}
+	r.checkpoint = func(n uint64) error {
+		return app.addRotationProgress(oldName, 0, n)
+	}
+	defer r.close()
+	var storage []string
+	var roots *volumeRoots
+	prepare := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByVolumeID(volID)
+		if err != nil {
+			return err
+		}
+		storage, err = storageRotatingFrom(vol, oldName)
+		if err != nil {
+			return err
+		}
+		kvstore, err := app.OpenKV(tx, vol.Storage())
+		if err != nil {
+			return err
+		}
+		r.store = kvchunks.New(kvstore)
+
+		c := vol.Storage().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			n, err := item.OldSharingKeyName()
+			if err != nil {
+				return err
+			}
+			if n != oldName {
+				continue
+			}
+			backend, err := item.Backend()
+			if err != nil {
+				return err
+			}
+			newName, err := item.SharingKeyName()
+			if err != nil {
+				return err
+			}
+			p, err := app.rotationPair(tx, backend, oldName, newName)
+			if err != nil {
+				return err
+			}
+			r.pairs = append(r.pairs, p)
+		}
+
+		roots, err = listVolumeRoots(vol)
+		return err
+	}
+	if err := app.DB.View(prepare); err != nil {
+		return err
+	}
+
+	w := &chunkWalk{
+		ctx:   ctx,
+		store: r.store,
+		visit: func(p string, key cas.Key, typ string, level uint8) (bool, error) {
+			return true, r.copy(key, typ, level)
+		},
+		done: func(root cas.Key) bool {
+			_, found := r.seen[root]
+			return found
+		},
+	}
+	if err := w.walk(roots); err != nil {
+		return err
+	}
+
+	finish := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByVolumeID(volID)
+		if err != nil {
+			return err
+		}
+		for _, s := range storage {
+			if err := vol.Storage().FinishRotation(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := app.DB.Update(finish); err != nil {
+		return err
+	}
+	return app.addRotationProgress(oldName, 1, r.pending)
+}
+
+func (app *App) rotationPair(tx *db.Tx, backend string, oldName, newName string) (rotationPair, error) {
+	s, err := app.openStorage(backend)
+	if err != nil {
+		return rotationPair{}, err
+	}
+	from, err := sharingStore(tx, s, oldName)
+	if err != nil {
+		_ = s.Close()
+		return rotationPair{}, err
+	}
+	to, err := sharingStore(tx, s, newName)
+	if err != nil {
+		_ = s.Close()
+		return rotationPair{}, err
+	}
+	p := rotationPair{
+		from: kvchunks.New(from),
+		to:   kvchunks.New(to),
+	}
+	return p, nil
+}
+
+// rotationPair re-encrypts chunks of one storage backend.
+type rotationPair struct {
+	// Both use the same backend; closing either closes it.
+	from chunks.Store
+	to   chunks.Store
+}
+
+// rotation re-encrypts all chunks reachable from a volume.
+type rotation struct {
+	ctx context.Context
+	// Used to read the chunks, to find out what chunks they point
+	// to.
+	store chunks.Store
+	pairs []rotationPair
+	seen  map[cas.Key]struct{}
+	// Chunks re-encrypted but not yet recorded as progress.
+	pending    uint64
+	checkpoint func(n uint64) error
+}
+
+// close releases the storage used by the rotation.
+func (r *rotation) close() {
+	if r.store != nil {
+		if err := r.store.Close(); err != nil {
+			log.Printf("rotation: closing storage: %v", err)
+		}
+	}
+	for _, p := range r.pairs {
+		if err := p.to.Close(); err != nil {
+			log.Printf("rotation: closing storage: %v", err)
+		}
+	}
+}
+
+func isNotFound(err error) bool {
+	switch err.(type) {
+	case kv.NotFoundError, cas.NotFoundError:
+		return true
+	}
+	return false
+}
+
+func (r *rotation) copy(key cas.Key, typ string, level uint8) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+	if _, found := r.seen[key]; found {
+		return nil
+	}
+	r.seen[key] = struct{}{}
+
+	for _, p := range r.pairs {
+		if _, err := p.to.Get(r.ctx, key, typ, level); err == nil {
+			// already done, e.g. before an interruption
+			continue
+		} else if !isNotFound(err) {
+			return err
+		}
+		chunk, err := p.from.Get(r.ctx, key, typ, level)
+		if isNotFound(err) {
+			// not stored in this backend
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		k, err := p.to.Add(r.ctx, chunk)
+		if err != nil {
+			return err
+		}
+		if k != key {
+			return fmt.Errorf("re-encrypted chunk changed key: %v != %v", k, key)
+		}
+		r.pending++
+		if r.pending >= rotationCheckpoint {
+			if err := r.checkpoint(r.pending); err != nil {
+				return err
+			}
+			r.pending = 0
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"bazil.org/bazil/cas"
+	"bazil.org/bazil/cas/chunks"
+	"bazil.org/bazil/cas/chunks/chunkutil"
+	"bazil.org/bazil/cas/chunks/kvchunks"
+	"bazil.org/bazil/db"
+)
+
+// ScrubProblem is a chunk that could not be read intact from one of
+// the storage backends of a volume.
+type ScrubProblem struct {
+	Key   cas.Key
+	Type  string
+	Level uint8
+	// Name of the volume storage the chunk is bad in. Empty if the
+	// chunk could not be read from any storage, and the chunks it
+	// points to were not checked.
+	Storage string
+	Err     error
+	// Paths of the files and directories using the chunk, relative
+	// to the volume root. Snapshots are under ".snap".
+	Paths []string
+	// Whether the chunk was rewritten from an intact copy.
+	Repaired bool
+}
+
+// ScrubResult is the outcome of scrubbing a volume.
+type ScrubResult struct {
+	// Number of distinct chunks checked.
+	Chunks   uint64
+	Problems []*ScrubProblem
+}
+
+// errHashMismatch is reported for chunks whose content does not
+// match their key.
+var errHashMismatch = errors.New("content does not match key")
+
+// errNoIntactCopy is reported for chunks that could not be read
+// intact from any storage.
+var errNoIntactCopy = errors.New("no intact copy in any storage")
+
+type scrubBackend struct {
+	name  string
+	store chunks.Store
+}
+
+// scrub checks all chunks reachable from a volume.
+type scrub struct {
+	ctx context.Context
+	// Used to read the chunks, to find out what chunks they point
+	// to.
+	store    chunks.Store
+	backends []scrubBackend
+	repair   bool
+	result   ScrubResult
+	// Problems by chunk, nil for chunks that were intact
+	// everywhere.
+	seen map[cas.Key][]*ScrubProblem
+}
+
+// ScrubVolume reads every chunk reachable from the volume from each
+// of its storage backends, and verifies the chunk content matches
+// its key. With repair, chunks that are missing or corrupt in some
+// backend are rewritten from a backend that has an intact copy.
+//
+// The volume may be in use while it is being scrubbed; changes made
+// meanwhile may not be checked.
+func (app *App) ScrubVolume(ctx context.Context, volID *db.VolumeID, repair bool) (*ScrubResult, error) {
+	s := &scrub{
+		ctx:    ctx,
+		repair: repair,
+		seen:   make(map[cas.Key][]*ScrubProblem),
+	}
+	defer s.close()
+	var roots *volumeRoots
+	prepare := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByVolumeID(volID)
+		if err != nil {
+			return err
+		}
+		kvstore, err := app.OpenKV(tx, vol.Storage())
+		if err != nil {
+			return err
+		}
+		s.store = kvchunks.New(kvstore)
+
+		c := vol.Storage().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			backend, err := item.Backend()
+			if err != nil {
+				return err
+			}
+			st, err := app.openStorage(backend)
+			if err != nil {
+				return fmt.Errorf("storage %q: %v", item.Name(), err)
+			}
+			// reads through the old sharing key too, while it is
+			// being rotated
+			conv, err := itemStore(tx, item, st)
+			if err != nil {
+				_ = st.Close()
+				return err
+			}
+			s.backends = append(s.backends, scrubBackend{
+				name:  item.Name(),
+				store: kvchunks.New(conv),
+			})
+		}
+
+		roots, err = listVolumeRoots(vol)
+		return err
+	}
+	if err := app.DB.View(prepare); err != nil {
+		return nil, err
+	}
+
+	w := &chunkWalk{
+		ctx:   ctx,
+		store: s.store,
+		visit: s.check,
+		done: func(root cas.Key) bool {
+			problems, found := s.seen[root]
+			return found && len(problems) == 0
+		},
+	}
+	if err := w.walk(roots); err != nil {
+		return nil, err
+	}
+	return &s.result, nil
+}
+
+// close releases the storage used by the scrub.
+func (s *scrub) close() {
+	if s.store != nil {
+		if err := s.store.Close(); err != nil {
+			log.Printf("scrub: closing storage: %v", err)
+		}
+	}
+	for _, b := range s.backends {
+		if err := b.store.Close(); err != nil {
+			log.Printf("scrub: closing storage %q: %v", b.name, err)
+		}
+	}
+}
+
+// check verifies one chunk in every backend. Returns false if no
+// intact copy was found.
+func (s *scrub) check(p string, key cas.Key, typ string, level uint8) (bool, error) {
+	if err := s.ctx.Err(); err != nil {
+		return false, err
+	}
+	if problems, found := s.seen[key]; found {
+		for _, problem := range problems {
+			problem.Paths = append(problem.Paths, p)
+		}
+		for _, problem := range problems {
+			if problem.Storage == "" {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	if key.IsSpecial() {
+		s.seen[key] = nil
+		return true, nil
+	}
+	s.result.Chunks++
+
+	var good *chunks.Chunk
+	var problems []*ScrubProblem
+	for _, b := range s.backends {
+		chunk, err := b.store.Get(s.ctx, key, typ, level)
+		if err == nil && chunkutil.Hash(chunk) != key {
+			err = errHashMismatch
+		}
+		if err != nil {
+			if err := s.ctx.Err(); err != nil {
+				return false, err
+			}
+			problems = append(problems, &ScrubProblem{
+				Key:     key,
+				Type:    typ,
+				Level:   level,
+				Storage: b.name,
+				Err:     err,
+				Paths:   []string{p},
+			})
+			continue
+		}
+		if good == nil {
+			good = chunk
+		}
+	}
+
+	if good != nil && s.repair {
+		for _, problem := range problems {
+			problem.Repaired = s.repairChunk(problem.Storage, key, good)
+		}
+	}
+	if good == nil && len(s.backends) > 0 {
+		problems = append(problems, &ScrubProblem{
+			Key:   key,
+			Type:  typ,
+			Level: level,
+			Err:   errNoIntactCopy,
+			Paths: []string{p},
+		})
+	}
+	s.seen[key] = problems
+	s.result.Problems = append(s.result.Problems, problems...)
+	return good != nil, nil
+}
+
+// repairChunk writes the chunk to the named backend, and verifies it
+// can now be read back intact.
+func (s *scrub) repairChunk(name string, key cas.Key, chunk *chunks.Chunk) bool {
+	for _, b := range s.backends {
+		if b.name != name {
+			continue
+		}
+		if _, err := b.store.Add(s.ctx, chunk); err != nil {
+			return false
+		}
+		again, err := b.store.Get(s.ctx, key, chunk.Type, chunk.Level)
+		return err == nil && chunkutil.Hash(again) == key
+	}
+	return false
+}
+
+// ScrubAll scrubs every volume, logging any problems found.
+func (app *App) ScrubAll(ctx context.Context, repair bool) error {
+	var vols []db.VolumeID
+	list := func(tx *db.Tx) error {
+		c := tx.Volumes().Cursor()
+		for item := c.First(); item != nil; item = c.Next() {
+			var volID db.VolumeID
+			item.Volume().VolumeID(&volID)
+			vols = append(vols, volID)
+		}
+		return nil
+	}
+	if err := app.DB.View(list); err != nil {
+		return err
+	}
+	for i := range vols {
+		volID := &vols[i]
+		result, err := app.ScrubVolume(ctx, volID, repair)
+		if err == db.ErrVolumeIDNotFound {
+			// deleted meanwhile
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("scrubbing volume %v: %v", volID, err)
+		}
+		for _, p := range result.Problems {
+			log.Printf("scrub: volume %v: chunk %v in storage %q: %v (repaired: %v): %v",
+				volID, p.Key, p.Storage, p.Err, p.Repaired, p.Paths)
+		}
+	}
+	return nil
+}
+
+// StartScrubber scrubs all volumes periodically in the background,
+// repairing chunks where possible, until the App is closed.
+func (app *App) StartScrubber(interval time.Duration) {
+	work := func(ctx context.Context) {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			if err := app.ScrubAll(ctx, true); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("scrub failed: %v", err)
+			}
+		}
+	}
+	app.goBackground(work)
+}
@@ -1,23 +1,30 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
+	"time"
 
+	"bazil.org/bazil/cas/chunks"
 	"bazil.org/bazil/cas/chunks/kvchunks"
 	"bazil.org/bazil/db"
 	"bazil.org/bazil/fs"
 	"bazil.org/bazil/kv"
 	"bazil.org/bazil/kv/kvfiles"
 	"bazil.org/bazil/kv/kvmulti"
-	"bazil.org/bazil/kv/kvpeer"
 	"bazil.org/bazil/kv/untrusted"
 	"bazil.org/bazil/peer"
+	wirepeer "bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/server/audit"
 	"bazil.org/bazil/tokens"
 	"bazil.org/fuse"
 	fusefs "bazil.org/fuse/fs"
@@ -29,18 +36,74 @@ type App struct {
 	lockFile *os.File
 	DB       *db.DB
 	debug    func(data interface{})
-	volumes  struct {
+	// Limits enforced on peers by the peer server.
+	peerLimits PeerLimits
+	// Group allowed to use the control socket, if any.
+	adminGroup *uint32
+	audit      *audit.Log
+	metrics    appMetrics
+	volumes    struct {
 		sync.Mutex
 		// This Broadcasts whenever open volumes, or their mounted
 		// state, changes.
 		sync.Cond
 		open map[db.VolumeID]*VolumeRef
 	}
+	peerConns struct {
+		sync.Mutex
+		// Connections to and from peers, closed when the peer is
+		// removed.
+		open map[peer.PublicKey]map[io.Closer]struct{}
+	}
+	peerPool struct {
+		sync.Mutex
+		// Connections to peers, shared by all users.
+		conns map[peer.PublicKey]*pooledConn
+		// Connections being dialed. Callers wanting one wait for
+		// the dial instead of starting their own.
+		dialing map[peer.PublicKey]*peerDial
+		// Outcome of recent calls to peers, kept after their
+		// connections are closed.
+		health map[peer.PublicKey]*peerHealth
+	}
+	pairing struct {
+		sync.Mutex
+		// Code peers must present to pair, or nil when not in
+		// pairing mode.
+		code     *peer.PairingCode
+		timer    *time.Timer
+		failures int
+		// Unknown peers let in while in pairing mode.
+		admitted map[peer.PublicKey]struct{}
+		// Peers that paired and are waiting for approval, with the
+		// time they paired.
+		pending map[peer.PublicKey]time.Time
+	}
 	Keys *CryptoKeys
 	tls  struct {
 		config atomic.Value
 		gen    sync.Mutex
 	}
+	background struct {
+		sync.Mutex
+		// Canceled when the App is closing.
+		ctx    context.Context
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+	rotations struct {
+		sync.Mutex
+		// Sharing key rotations running in the background, by old
+		// sharing key name.
+		running map[string]struct{}
+	}
+	storage struct {
+		sync.Mutex
+		// Storage backends in use, by normalized backend string.
+		// Shared by all users, as some backends can only be opened
+		// once.
+		open map[string]*sharedStorage
+	}
 }
 
 func New(dataDir string, options ...AppOption) (app *App, err error) {
@@ -91,24 +154,77 @@ func New(dataDir string, options ...AppOption) (app *App, err error) {
 		return nil, err
 	}
 
-	keys, err := loadOrGenerateKeys(database.DB)
+	keys, err := loadOrGenerateKeys(database.DB, config.identity)
+	if err != nil {
+		database.Close()
+		return nil, err
+	}
+
+	auditLog, err := audit.Open(AuditDir(dataDir))
 	if err != nil {
+		database.Close()
 		return nil, err
 	}
 
 	app = &App{
-		DataDir:  dataDir,
-		lockFile: lockFile,
-		DB:       database,
-		debug:    config.debug,
-		Keys:     keys,
+		DataDir:    dataDir,
+		lockFile:   lockFile,
+		DB:         database,
+		debug:      config.debug,
+		peerLimits: DefaultPeerLimits,
+		audit:      auditLog,
+		Keys:       keys,
 	}
+	if config.peerLimits != nil {
+		app.peerLimits = *config.peerLimits
+	}
+	app.adminGroup = config.adminGroup
 	app.volumes.Cond.L = &app.volumes.Mutex
 	app.volumes.open = make(map[db.VolumeID]*VolumeRef)
+	app.peerConns.open = make(map[peer.PublicKey]map[io.Closer]struct{})
+	app.peerPool.conns = make(map[peer.PublicKey]*pooledConn)
+	app.peerPool.dialing = make(map[peer.PublicKey]*peerDial)
+	app.peerPool.health = make(map[peer.PublicKey]*peerHealth)
+	app.pairing.admitted = make(map[peer.PublicKey]struct{})
+	app.pairing.pending = make(map[peer.PublicKey]time.Time)
+	app.background.ctx, app.background.cancel = context.WithCancel(context.Background())
+	app.rotations.running = make(map[string]struct{})
+	app.storage.open = make(map[string]*sharedStorage)
+	app.initMetrics()
+	app.goBackground(app.reapIdlePeerConns)
 	return app, nil
 }
 
+// goBackground runs fn in a goroutine, until the App is closed. The
+// context passed to fn is canceled when closing. Returns false if
+// the App is already closing.
+func (app *App) goBackground(fn func(ctx context.Context)) bool {
+	app.background.Lock()
+	defer app.background.Unlock()
+	ctx := app.background.ctx
+	if ctx.Err() != nil {
+		// closing
+		return false
+	}
+	app.background.wg.Add(1)
+	go func() {
+		defer app.background.wg.Done()
+		fn(ctx)
+	}()
+	return true
+}
+
 func (app *App) Close() {
+	// Stop background work; unfinished rotations resume on next
+	// start.
+	app.background.Lock()
+	app.background.cancel()
+	app.background.Unlock()
+	app.background.wg.Wait()
+
+	app.StopPairing()
+	app.closeAllPeerConns()
+
 	// Wait for VolumeRefs to go away, to detect refcounting bugs.
 	app.volumes.Lock()
 	for len(app.volumes.open) > 0 {
@@ -116,6 +232,20 @@ func (app *App) Close() {
 	}
 	app.volumes.Unlock()
 
+	// Storage still open was leaked by someone.
+	app.storage.Lock()
+	for key, st := range app.storage.open {
+		log.Printf("storage %s still in use at close", key)
+		if err := st.kv.Close(); err != nil {
+			log.Printf("closing storage %s: %v", key, err)
+		}
+		delete(app.storage.open, key)
+	}
+	app.storage.Unlock()
+
+	if err := app.audit.Close(); err != nil {
+		log.Printf("closing audit log: %v", err)
+	}
 	app.DB.Close()
 	app.lockFile.Close()
 }
@@ -127,6 +257,21 @@ func (app *App) Debug(msg interface{}) {
 	app.debug(msg)
 }
 
+// ControlAdminGroup returns the group allowed to use the control
+// socket in addition to the user running the server, if any.
+func (app *App) ControlAdminGroup() (gid uint32, ok bool) {
+	if app.adminGroup == nil {
+		return 0, false
+	}
+	return *app.adminGroup, true
+}
+
+// PeerLimits returns the limits the peer server enforces on each
+// peer.
+func (app *App) PeerLimits() PeerLimits {
+	return app.peerLimits
+}
+
 func (app *App) GetVolume(id *db.VolumeID) (*VolumeRef, error) {
 	app.volumes.Lock()
 	defer app.volumes.Unlock()
@@ -134,14 +279,19 @@ func (app *App) GetVolume(id *db.VolumeID) (*VolumeRef, error) {
 	ref, found := app.volumes.open[*id]
 	if !found {
 		open := func(tx *db.Tx) error {
-			vol, err := app.openVolume(tx, id)
+			vol, store, err := app.openVolume(tx, id)
 			if err != nil {
 				return err
 			}
+			volume := id.String()
+			vol.SetConflictHook(func() {
+				app.metrics.conflicts.Inc(volume)
+			})
 			ref = &VolumeRef{
 				app:   app,
 				volID: *id,
 				fs:    vol,
+				store: store,
 			}
 			return nil
 		}
@@ -171,27 +321,74 @@ func (app *App) GetVolumeByName(name string) (*VolumeRef, error) {
 	return app.GetVolume(&volID)
 }
 
+var (
+	ErrVolumeMounted = errors.New("volume is mounted")
+	ErrVolumeInUse   = errors.New("volume is in use")
+)
+
+// DeleteVolume removes the named volume from this node.
+//
+// Volumes that are currently mounted, or otherwise in use, cannot be
+// deleted; this returns ErrVolumeMounted or ErrVolumeInUse.
+func (app *App) DeleteVolume(name string) error {
+	// hold the lock over the transaction, to keep the volume from
+	// being opened while it is being deleted
+	app.volumes.Lock()
+	defer app.volumes.Unlock()
+
+	del := func(tx *db.Tx) error {
+		vol, err := tx.Volumes().GetByName(name)
+		if err != nil {
+			return err
+		}
+		var volID db.VolumeID
+		vol.VolumeID(&volID)
+		if ref, found := app.volumes.open[volID]; found {
+			if ref.mounted {
+				return ErrVolumeMounted
+			}
+			return ErrVolumeInUse
+		}
+		return tx.Volumes().Delete(name)
+	}
+	return app.DB.Update(del)
+}
+
+// openVolume opens the volume and the chunk store it uses. The
+// chunk store must be closed after the volume is no longer used.
+//
 // caller must hold App.volumes.Mutex
-func (app *App) openVolume(tx *db.Tx, id *db.VolumeID) (*fs.Volume, error) {
+func (app *App) openVolume(tx *db.Tx, id *db.VolumeID) (*fs.Volume, chunks.Store, error) {
 	v, err := tx.Volumes().GetByVolumeID(id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	kvstore, err := app.OpenKV(tx, v.Storage())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	chunkStore := kvchunks.New(kvstore)
 	vol, err := fs.Open(app.DB, chunkStore, id, (*peer.PublicKey)(app.Keys.Sign.Pub))
 	if err != nil {
-		return nil, err
+		_ = chunkStore.Close()
+		return nil, nil, err
 	}
-	return vol, nil
+	return vol, chunkStore, nil
 }
 
-func (app *App) OpenKV(tx *db.Tx, storage *db.VolumeStorage) (kv.KV, error) {
+// OpenKV opens all the storage of a volume as one store. The caller
+// must close the returned store.
+func (app *App) OpenKV(tx *db.Tx, storage *db.VolumeStorage) (_ kv.KV, err error) {
 	var kvstores []kv.KV
+	defer func() {
+		if err != nil {
+			for _, s := range kvstores {
+				// already failing, nothing more to report
+				_ = s.Close()
+			}
+		}
+	}()
 
 	c := storage.Cursor()
 	for item := c.First(); item != nil; item = c.Next() {
@@ -203,72 +400,76 @@ func (app *App) OpenKV(tx *db.Tx, storage *db.VolumeStorage) (kv.KV, error) {
 		if err != nil {
 			return nil, err
 		}
+		// closed through the wrappers from now on
+		kvstores = append(kvstores, s)
 
-		sharingKeyName, err := item.SharingKeyName()
+		s, err = itemStore(tx, item, s)
 		if err != nil {
 			return nil, err
 		}
-		sharingKey, err := tx.SharingKeys().Get(sharingKeyName)
-		if err != nil {
-			return nil, fmt.Errorf("getting sharing key %q: %v", sharingKeyName, err)
-		}
-		var secret [32]byte
-		sharingKey.Secret(&secret)
-		s = untrusted.New(s, &secret)
-
-		kvstores = append(kvstores, s)
+		kvstores[len(kvstores)-1] = s
 	}
 
 	return kvmulti.New(kvstores...), nil
 }
 
-func (app *App) openStorage(backend string) (kv.KV, error) {
-	switch backend {
-	case "local":
-		kvpath := filepath.Join(app.DataDir, "chunks")
-		return kvfiles.Open(kvpath)
-	}
-	if backend != "" && backend[0] == '/' {
-		return kvfiles.Open(backend)
-	}
-	idx := strings.IndexByte(backend, ':')
-	if idx != -1 {
-		scheme, rest := backend[:idx], backend[idx+1:]
-		switch scheme {
-		case "peerkey":
-			var key peer.PublicKey
-			if err := key.Set(rest); err != nil {
-				return nil, err
-			}
-			p, err := app.DialPeer(&key)
-			if err != nil {
-				return nil, err
-			}
-			// TODO Close
-			return kvpeer.Open(p)
-		}
+// itemStore returns a store that encrypts data for the volume
+// storage item, before passing it to s.
+func itemStore(tx *db.Tx, item *db.VolumeStorageItem, s kv.KV) (kv.KV, error) {
+	sharingKeyName, err := item.SharingKeyName()
+	if err != nil {
+		return nil, err
+	}
+	cur, err := sharingStore(tx, s, sharingKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSharingKeyName, err := item.OldSharingKeyName()
+	if err != nil {
+		return nil, err
+	}
+	if oldSharingKeyName == "" {
+		return cur, nil
 	}
-	return nil, errors.New("unknown storage backend")
+	// sharing key is being rotated, not all chunks have been
+	// re-encrypted yet
+	old, err := sharingStore(tx, s, oldSharingKeyName)
+	if err != nil {
+		return nil, err
+	}
+	return untrusted.NewRotating(cur, old), nil
 }
 
-func (app *App) ValidateKV(backend string) error {
-	// TODO opening a KV just to validate the input string would be a
-	// bad idea if a backend was costly to open; then again, current
-	// API doesn't support a Close anyway.
-	_, err := app.openStorage(backend)
-	return err
+// sharingStore returns a store that encrypts data with the named
+// sharing key, before passing it to s.
+func sharingStore(tx *db.Tx, s kv.KV, name string) (*untrusted.Convergent, error) {
+	sharingKey, err := tx.SharingKeys().Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("getting sharing key %q: %v", name, err)
+	}
+	padding, err := untrusted.ParsePadding(sharingKey.Padding())
+	if err != nil {
+		return nil, fmt.Errorf("sharing key %q: %v", name, err)
+	}
+	var secret [32]byte
+	sharingKey.Secret(&secret)
+	return untrusted.NewPadded(s, &secret, padding), nil
 }
 
 type VolumeRef struct {
 	app   *App
 	volID db.VolumeID
 	fs    *fs.Volume
+	// Storage used by fs, closed with the last reference.
+	store chunks.Store
 
 	// fields protected by App.volumes.Mutex
 
-	refs    uint32
-	mounted bool
-	conn    *fuse.Conn
+	refs       uint32
+	mounted    bool
+	mountpoint string
+	conn       *fuse.Conn
 }
 
 func (ref *VolumeRef) Close() {
@@ -279,6 +480,9 @@ func (ref *VolumeRef) Close() {
 	if ref.refs == 0 {
 		delete(ref.app.volumes.open, ref.volID)
 		ref.app.volumes.Broadcast()
+		if err := ref.store.Close(); err != nil {
+			log.Printf("closing storage of volume %v: %v", ref.volID, err)
+		}
 	}
 }
 
@@ -289,6 +493,21 @@ func (ref *VolumeRef) FS() *fs.Volume {
 	return ref.fs
 }
 
+// SyncReceive is like fs.Volume.SyncReceive, but keeps track of how
+// long syncing takes.
+//
+// Caller must keep a reference to VolumeRef for the duration
+func (ref *VolumeRef) SyncReceive(ctx context.Context, dirPath string, peers map[uint32][]byte, dirClock []byte, recv func() ([]*wirepeer.Dirent, error)) error {
+	start := time.Now()
+	err := ref.fs.SyncReceive(ctx, dirPath, peers, dirClock, recv)
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	ref.app.metrics.syncDuration.Observe(time.Since(start).Seconds(), ref.volID.String(), result)
+	return err
+}
+
 // Protocol returns the underlying FUSE protocol version.
 //
 // Caller must keep a reference to VolumeRef for the duration
@@ -321,8 +540,12 @@ func (ref *VolumeRef) Mount(mountpoint string) error {
 		return fmt.Errorf("mount fail: %v", err)
 	}
 
+	fuseMetrics := newFUSEMetrics(&ref.app.metrics, &ref.volID)
 	srv := fusefs.New(conn, &fusefs.Config{
-		Debug: ref.debug,
+		Debug: func(msg interface{}) {
+			fuseMetrics.observe(msg)
+			ref.debug(msg)
+		},
 	})
 	serveErr := make(chan error, 1)
 	go func() {
@@ -330,6 +553,7 @@ func (ref *VolumeRef) Mount(mountpoint string) error {
 			// remove map entry on unmount or failed mount
 			ref.app.volumes.Lock()
 			ref.mounted = false
+			ref.mountpoint = ""
 			ref.conn = nil
 			ref.app.volumes.Unlock()
 			ref.app.volumes.Broadcast()
@@ -350,6 +574,7 @@ func (ref *VolumeRef) Mount(mountpoint string) error {
 		}
 		ref.refs++
 		ref.mounted = true
+		ref.mountpoint = mountpoint
 		ref.conn = conn
 		ref.app.volumes.Broadcast()
 		return nil
@@ -383,6 +608,90 @@ func (ref *VolumeRef) debug(msg interface{}) {
 
 var ErrNotMounted = errors.New("not currently mounted")
 
+// VolumeBusyError is returned when a volume cannot be unmounted
+// because it is still in use.
+type VolumeBusyError struct {
+	// Number of file handles still open on the volume. May be zero
+	// if the volume is busy for other reasons, such as being the
+	// working directory of a process.
+	Handles uint64
+}
+
+var _ error = (*VolumeBusyError)(nil)
+
+func (e *VolumeBusyError) Error() string {
+	if e.Handles == 0 {
+		return "volume is busy"
+	}
+	return fmt.Sprintf("volume is busy: %d open file handles", e.Handles)
+}
+
+// Unmount flushes all dirty files to storage and unmounts the
+// volume. It returns once the unmount has completed.
+//
+// If files are still open on the volume, or the kernel refuses to
+// unmount it as busy, returns a *VolumeBusyError.
+func (ref *VolumeRef) Unmount(ctx context.Context) error {
+	ref.app.volumes.Lock()
+	mounted, mountpoint := ref.mounted, ref.mountpoint
+	ref.app.volumes.Unlock()
+	if !mounted {
+		return ErrNotMounted
+	}
+
+	handles, err := ref.fs.Flush(ctx)
+	if err != nil {
+		return fmt.Errorf("flush fail: %v", err)
+	}
+	if handles > 0 {
+		return &VolumeBusyError{Handles: handles}
+	}
+	if err := fuse.Unmount(mountpoint); err != nil {
+		if isBusy(err) {
+			// for example, a process has its working directory
+			// in the volume
+			return &VolumeBusyError{}
+		}
+		return err
+	}
+	if err := ref.WaitForUnmount(); err != nil && err != ErrNotMounted {
+		return err
+	}
+	return nil
+}
+
+// isBusy reports whether the unmount failed with EBUSY. On Linux,
+// the error only carries the message of the fusermount helper.
+func isBusy(err error) bool {
+	if errors.Is(err, syscall.EBUSY) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), strings.ToLower(syscall.EBUSY.Error()))
+}
+
+// MountInfo describes a currently mounted volume.
+type MountInfo struct {
+	VolumeID   db.VolumeID
+	Mountpoint string
+}
+
+// Mounts returns information about all currently mounted volumes.
+func (app *App) Mounts() []MountInfo {
+	app.volumes.Lock()
+	defer app.volumes.Unlock()
+	var list []MountInfo
+	for volID, ref := range app.volumes.open {
+		if !ref.mounted {
+			continue
+		}
+		list = append(list, MountInfo{
+			VolumeID:   volID,
+			Mountpoint: ref.mountpoint,
+		})
+	}
+	return list
+}
+
 func (ref *VolumeRef) WaitForUnmount() error {
 	ref.app.volumes.Lock()
 	defer ref.app.volumes.Unlock()
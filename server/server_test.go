@@ -0,0 +1,24 @@
+package server
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestIsBusy(t *testing.T) {
+	for _, c := range []struct {
+		err  error
+		busy bool
+	}{
+		{&os.PathError{Op: "unmount", Path: "/mnt", Err: syscall.EBUSY}, true},
+		{errors.New("exit status 1: fusermount: failed to unmount /mnt: Device or resource busy"), true},
+		{&os.PathError{Op: "unmount", Path: "/mnt", Err: syscall.EINVAL}, false},
+		{errors.New(`exec: "fusermount": executable file not found in $PATH`), false},
+	} {
+		if g, e := isBusy(c.err), c.busy; g != e {
+			t.Errorf("isBusy(%v) = %v, want %v", c.err, g, e)
+		}
+	}
+}
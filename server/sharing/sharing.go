@@ -0,0 +1,114 @@
+// Package sharing derives sharing keys from passphrases, and moves
+// sharing keys between servers as passphrase-encrypted text.
+//
+// Passphrases are turned into keys with scrypt. The salt and cost
+// parameters are stored alongside the sharing key, as they are
+// needed to derive the same key elsewhere.
+package sharing
+
+import (
+	wiredb "bazil.org/bazil/db/wire"
+	"bazil.org/bazil/kv/untrusted"
+	"bazil.org/bazil/server/sharing/wire"
+	"bazil.org/bazil/util/passbox"
+	"github.com/golang/protobuf/proto"
+)
+
+// Default scrypt cost parameters, used when creating new keys.
+const (
+	DefaultLogN = passbox.DefaultLogN
+	DefaultR    = passbox.DefaultR
+	DefaultP    = passbox.DefaultP
+)
+
+// PEM block type of exported sharing keys.
+const armorType = "BAZIL SHARING KEY"
+
+var (
+	ErrBadKDF        = passbox.ErrBadKDF
+	ErrMalformed     = passbox.ErrMalformed
+	ErrBadPassphrase = passbox.ErrBadPassphrase
+)
+
+// NewKDF returns key derivation parameters with a fresh random salt
+// and the default costs.
+func NewKDF() (*wiredb.SharingKeyKDF, error) {
+	kdf, err := passbox.NewKDF()
+	if err != nil {
+		return nil, err
+	}
+	out := &wiredb.SharingKeyKDF{
+		Salt: kdf.Salt,
+		LogN: kdf.LogN,
+		R:    kdf.R,
+		P:    kdf.P,
+	}
+	return out, nil
+}
+
+// Derive computes a key from the passphrase.
+//
+// If the parameters are out of the accepted range, returns
+// ErrBadKDF.
+func Derive(passphrase []byte, kdf *wiredb.SharingKeyKDF) (*[32]byte, error) {
+	k := &passbox.KDF{
+		Salt: kdf.Salt,
+		LogN: kdf.LogN,
+		R:    kdf.R,
+		P:    kdf.P,
+	}
+	return k.Derive(passphrase)
+}
+
+// Key is a sharing key being exported or imported.
+type Key struct {
+	Name   string
+	Secret [32]byte
+	// Set if the sharing key was derived from a passphrase.
+	KDF *wiredb.SharingKeyKDF
+	// Padding mode used with the sharing key, empty for none.
+	Padding string
+}
+
+// Export encrypts the sharing key with the passphrase, and encodes
+// it as PEM-armored text.
+func Export(key *Key, passphrase []byte) ([]byte, error) {
+	plain, err := proto.Marshal(&wire.SharingKey{
+		Name:    key.Name,
+		Secret:  key.Secret[:],
+		Kdf:     key.KDF,
+		Padding: key.Padding,
+	})
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{
+		"Name": key.Name,
+	}
+	return passbox.Seal(armorType, headers, plain, passphrase)
+}
+
+// Import decodes and decrypts a sharing key exported with Export.
+func Import(armored []byte, passphrase []byte) (*Key, error) {
+	plain, err := passbox.Open(armorType, armored, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	var msg wire.SharingKey
+	if err := proto.Unmarshal(plain, &msg); err != nil {
+		return nil, ErrMalformed
+	}
+	key := &Key{
+		Name:    msg.Name,
+		KDF:     msg.Kdf,
+		Padding: msg.Padding,
+	}
+	if len(msg.Secret) != len(key.Secret) {
+		return nil, ErrMalformed
+	}
+	copy(key.Secret[:], msg.Secret)
+	if _, err := untrusted.ParsePadding(key.Padding); err != nil {
+		return nil, ErrMalformed
+	}
+	return key, nil
+}
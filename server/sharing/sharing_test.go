@@ -0,0 +1,90 @@
+package sharing_test
+
+import (
+	"bytes"
+	"testing"
+
+	wiredb "bazil.org/bazil/db/wire"
+	"bazil.org/bazil/server/sharing"
+)
+
+func TestDerive(t *testing.T) {
+	kdf := &wiredb.SharingKeyKDF{
+		Salt: []byte("NaCl"),
+		LogN: 10,
+		R:    8,
+		P:    16,
+	}
+	key, err := sharing.Derive([]byte("password"), kdf)
+	if err != nil {
+		t.Fatalf("derive failed: %v", err)
+	}
+	// test vector from the scrypt paper
+	want := []byte{
+		0xfd, 0xba, 0xbe, 0x1c, 0x9d, 0x34, 0x72, 0x00,
+		0x78, 0x56, 0xe7, 0x19, 0x0d, 0x01, 0xe9, 0xfe,
+		0x7c, 0x6a, 0xd7, 0xcb, 0xc8, 0x23, 0x78, 0x30,
+		0xe7, 0x73, 0x76, 0x63, 0x4b, 0x37, 0x31, 0x62,
+	}
+	if !bytes.Equal(key[:], want) {
+		t.Errorf("wrong key: %x != %x", key[:], want)
+	}
+}
+
+func TestDeriveBadKDF(t *testing.T) {
+	for _, kdf := range []*wiredb.SharingKeyKDF{
+		{LogN: 10, R: 8, P: 1},
+		{Salt: []byte("x"), LogN: 0, R: 8, P: 1},
+		{Salt: []byte("x"), LogN: 40, R: 8, P: 1},
+		{Salt: []byte("x"), LogN: 10, R: 0, P: 1},
+		{Salt: []byte("x"), LogN: 10, R: 1 << 16, P: 1 << 16},
+	} {
+		if _, err := sharing.Derive([]byte("password"), kdf); err != sharing.ErrBadKDF {
+			t.Errorf("expected ErrBadKDF for %v, got %v", kdf, err)
+		}
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	key := &sharing.Key{
+		Name:   "team",
+		Secret: [32]byte{1, 2, 3, 4},
+		KDF: &wiredb.SharingKeyKDF{
+			Salt: []byte("salty"),
+			LogN: 10,
+			R:    8,
+			P:    1,
+		},
+	}
+	armored, err := sharing.Export(key, []byte("s3kr1t"))
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !bytes.HasPrefix(armored, []byte("-----BEGIN BAZIL SHARING KEY-----\n")) {
+		t.Errorf("not armored: %q", armored)
+	}
+	if bytes.Contains(armored, key.Secret[:4]) {
+		t.Errorf("secret visible in export")
+	}
+
+	got, err := sharing.Import(armored, []byte("s3kr1t"))
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if g, e := got.Name, key.Name; g != e {
+		t.Errorf("wrong name: %q != %q", g, e)
+	}
+	if got.Secret != key.Secret {
+		t.Errorf("wrong secret: %x != %x", got.Secret, key.Secret)
+	}
+	if got.KDF == nil || string(got.KDF.Salt) != "salty" {
+		t.Errorf("KDF parameters not preserved: %v", got.KDF)
+	}
+
+	if _, err := sharing.Import(armored, []byte("wrong")); err != sharing.ErrBadPassphrase {
+		t.Errorf("expected ErrBadPassphrase, got %v", err)
+	}
+	if _, err := sharing.Import([]byte("junk"), []byte("s3kr1t")); err != sharing.ErrMalformed {
+		t.Errorf("expected ErrMalformed, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bazil.org/bazil/server/sharing/wire/sharing.proto
+
+package wire
+
+import (
+	wire "bazil.org/bazil/db/wire"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SharingKey is the plaintext of an exported sharing key.
+type SharingKey struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Exactly 32 bytes long.
+	Secret []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Set if the sharing key was derived from a passphrase.
+	Kdf *wire.SharingKeyKDF `protobuf:"bytes,3,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	// Padding mode of data encrypted with the sharing key, empty for
+	// none.
+	Padding              string   `protobuf:"bytes,4,opt,name=padding,proto3" json:"padding,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharingKey) Reset()         { *m = SharingKey{} }
+func (m *SharingKey) String() string { return proto.CompactTextString(m) }
+func (*SharingKey) ProtoMessage()    {}
+func (*SharingKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c559ce5b32d2c7c6, []int{0}
+}
+
+func (m *SharingKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharingKey.Unmarshal(m, b)
+}
+func (m *SharingKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharingKey.Marshal(b, m, deterministic)
+}
+func (m *SharingKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharingKey.Merge(m, src)
+}
+func (m *SharingKey) XXX_Size() int {
+	return xxx_messageInfo_SharingKey.Size(m)
+}
+func (m *SharingKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharingKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharingKey proto.InternalMessageInfo
+
+func (m *SharingKey) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SharingKey) GetSecret() []byte {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+func (m *SharingKey) GetKdf() *wire.SharingKeyKDF {
+	if m != nil {
+		return m.Kdf
+	}
+	return nil
+}
+
+func (m *SharingKey) GetPadding() string {
+	if m != nil {
+		return m.Padding
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SharingKey)(nil), "bazil.sharing.SharingKey")
+}
+
+func init() {
+	proto.RegisterFile("bazil.org/bazil/server/sharing/wire/sharing.proto", fileDescriptor_c559ce5b32d2c7c6)
+}
+
+var fileDescriptor_c559ce5b32d2c7c6 = []byte{
+	// 180 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4c, 0x4a, 0xac, 0xca,
+	0xcc, 0xd1, 0xcb, 0x2f, 0x4a, 0xd7, 0x07, 0xb3, 0xf4, 0x8b, 0x53, 0x8b, 0xca, 0x52, 0x8b, 0xf4,
+	0x8b, 0x33, 0x12, 0x8b, 0x32, 0xf3, 0xd2, 0xf5, 0xcb, 0x33, 0x8b, 0x52, 0x61, 0x1c, 0xbd, 0x82,
+	0xa2, 0xfc, 0x92, 0x7c, 0x21, 0x5e, 0x88, 0x16, 0xa8, 0xa0, 0x94, 0x2a, 0xba, 0x09, 0x29, 0x49,
+	0x58, 0x74, 0x29, 0xd5, 0x72, 0x71, 0x05, 0x43, 0x04, 0xbc, 0x53, 0x2b, 0x85, 0x84, 0xb8, 0x58,
+	0xf2, 0x12, 0x73, 0x53, 0x25, 0x18, 0x15, 0x18, 0x35, 0x38, 0x83, 0xc0, 0x6c, 0x21, 0x31, 0x2e,
+	0xb6, 0xe2, 0xd4, 0xe4, 0xa2, 0xd4, 0x12, 0x09, 0x26, 0x05, 0x46, 0x0d, 0x9e, 0x20, 0x28, 0x4f,
+	0x48, 0x93, 0x8b, 0x39, 0x3b, 0x25, 0x4d, 0x82, 0x59, 0x81, 0x51, 0x83, 0xdb, 0x48, 0x5c, 0x0f,
+	0x62, 0x5d, 0x4a, 0x92, 0x1e, 0xc2, 0x38, 0x6f, 0x17, 0xb7, 0x20, 0x90, 0x1a, 0x21, 0x09, 0x2e,
+	0xf6, 0x82, 0xc4, 0x94, 0x94, 0xcc, 0xbc, 0x74, 0x09, 0x16, 0xb0, 0xc9, 0x30, 0xae, 0x13, 0x5b,
+	0x14, 0x0b, 0xc8, 0x51, 0x49, 0x6c, 0x60, 0xd7, 0x18, 0x03, 0x06, 0x00, 0x61, 0x83, 0x74, 0x4c,
+	0xf8, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,305 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/kv"
+	"bazil.org/bazil/kv/kverasure"
+	"bazil.org/bazil/kv/kvfiles"
+	"bazil.org/bazil/kv/kvpack"
+	"bazil.org/bazil/kv/kvpeer"
+	"bazil.org/bazil/peer"
+)
+
+var errUnknownBackend = errors.New("unknown storage backend")
+
+// sharedStorage is an open storage backend, shared by all its
+// users.
+type sharedStorage struct {
+	key string
+	kv  kv.KV
+	// Protected by App.storage.
+	refs int
+}
+
+// storageRef is a user of a shared storage backend. Closing it
+// releases the backend, which is closed once it has no users left.
+//
+// Close is idempotent, so stores that wrap the same storageRef may
+// all close it.
+type storageRef struct {
+	kv.KV
+	app    *App
+	shared *sharedStorage
+	once   sync.Once
+}
+
+func (r *storageRef) Close() error {
+	var err error
+	r.once.Do(func() {
+		err = r.app.releaseStorage(r.shared)
+	})
+	return err
+}
+
+func (app *App) releaseStorage(s *sharedStorage) error {
+	// closing under the lock keeps the backend from being opened
+	// again before it is fully closed
+	app.storage.Lock()
+	defer app.storage.Unlock()
+	s.refs--
+	if s.refs > 0 {
+		return nil
+	}
+	delete(app.storage.open, s.key)
+	return s.kv.Close()
+}
+
+// storageKey normalizes a backend that is not erasure coded, so that
+// all spellings of it share one instance.
+func storageKey(backend string) (string, error) {
+	if backend == "local" {
+		return backend, nil
+	}
+	if backend != "" && backend[0] == '/' {
+		return filepath.Clean(backend), nil
+	}
+	idx := strings.IndexByte(backend, ':')
+	if idx == -1 {
+		return "", errUnknownBackend
+	}
+	scheme, rest := backend[:idx], backend[idx+1:]
+	switch scheme {
+	case "peerkey":
+		var key peer.PublicKey
+		if err := key.Set(rest); err != nil {
+			return "", err
+		}
+		return scheme + ":" + key.String(), nil
+	case "pack":
+		if !filepath.IsAbs(rest) {
+			return "", errors.New("pack storage path must be absolute")
+		}
+		return scheme + ":" + filepath.Clean(rest), nil
+	}
+	return "", errUnknownBackend
+}
+
+// openStorage opens the backend, sharing already open instances.
+// The caller must close the returned store.
+func (app *App) openStorage(backend string) (kv.KV, error) {
+	if strings.HasPrefix(backend, "ec:") {
+		// composed of other backends, which are shared; the
+		// erasure coding itself is cheap
+		return app.openErasure(backend[len("ec:"):])
+	}
+	key, err := storageKey(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	app.storage.Lock()
+	defer app.storage.Unlock()
+	s, ok := app.storage.open[key]
+	if !ok {
+		store, err := app.openBackend(key)
+		if err != nil {
+			return nil, err
+		}
+		s = &sharedStorage{
+			key: key,
+			kv: &meteredKV{
+				KV:      store,
+				metrics: &app.metrics,
+				backend: key,
+			},
+		}
+		app.storage.open[key] = s
+	}
+	s.refs++
+	ref := &storageRef{
+		KV:     s.kv,
+		app:    app,
+		shared: s,
+	}
+	return ref, nil
+}
+
+// openBackend opens a new instance of the backend, as normalized by
+// storageKey.
+func (app *App) openBackend(key string) (kv.KV, error) {
+	if key == "local" {
+		return kvfiles.Open(app.localPath())
+	}
+	if key[0] == '/' {
+		return kvfiles.Open(key)
+	}
+	idx := strings.IndexByte(key, ':')
+	scheme, rest := key[:idx], key[idx+1:]
+	switch scheme {
+	case "peerkey":
+		var pub peer.PublicKey
+		if err := pub.Set(rest); err != nil {
+			return nil, err
+		}
+		p, err := app.DialPeer(&pub)
+		if err != nil {
+			return nil, err
+		}
+		return kvpeer.Open(p)
+	case "pack":
+		return kvpack.Open(rest)
+	}
+	return nil, errUnknownBackend
+}
+
+// checkDir checks that path is an existing directory.
+func checkDir(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("not a directory: %s", path)
+	}
+	return nil
+}
+
+// localPath returns the directory of the "local" backend.
+func (app *App) localPath() string {
+	return filepath.Join(app.DataDir, "chunks")
+}
+
+// parseErasure parses erasure coded storage described as
+// "DATA,PARITY:BACKEND,BACKEND,...", with DATA+PARITY backends.
+func (app *App) parseErasure(desc string) (data, parity int, backends []string, err error) {
+	idx := strings.IndexByte(desc, ':')
+	if idx == -1 {
+		return 0, 0, nil, errors.New("erasure coded storage needs DATA,PARITY:BACKEND,...")
+	}
+	params := strings.Split(desc[:idx], ",")
+	if len(params) != 2 {
+		return 0, 0, nil, fmt.Errorf("bad erasure coding parameters: %q", desc[:idx])
+	}
+	data, err = strconv.Atoi(params[0])
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("bad erasure coding parameters: %q", desc[:idx])
+	}
+	parity, err = strconv.Atoi(params[1])
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("bad erasure coding parameters: %q", desc[:idx])
+	}
+	if err := kverasure.Validate(data, parity); err != nil {
+		return 0, 0, nil, err
+	}
+	backends = strings.Split(desc[idx+1:], ",")
+	// shards are all stored under the same key, so a backend used
+	// twice would keep only one of them
+	seen := make(map[string]string)
+	for _, backend := range backends {
+		if strings.HasPrefix(backend, "ec:") {
+			return 0, 0, nil, errors.New("erasure coded storage cannot be nested")
+		}
+		key, err := storageKey(backend)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("erasure coded storage backend %q: %v", backend, err)
+		}
+		if key == "local" {
+			key = app.localPath()
+			if abs, err := filepath.Abs(key); err == nil {
+				key = abs
+			}
+		}
+		if prev, ok := seen[key]; ok {
+			return 0, 0, nil, fmt.Errorf("erasure coded storage backends %q and %q are the same", prev, backend)
+		}
+		seen[key] = backend
+	}
+	if len(backends) != data+parity {
+		return 0, 0, nil, fmt.Errorf("erasure coding %d+%d needs %d backends, got %d", data, parity, data+parity, len(backends))
+	}
+	return data, parity, backends, nil
+}
+
+// openErasure opens erasure coded storage described as
+// "DATA,PARITY:BACKEND,BACKEND,...", with DATA+PARITY backends.
+func (app *App) openErasure(desc string) (kv.KV, error) {
+	data, parity, backends, err := app.parseErasure(desc)
+	if err != nil {
+		return nil, err
+	}
+	var stores []kv.KV
+	closeStores := func() {
+		for _, s := range stores {
+			// already failing, nothing more to report
+			_ = s.Close()
+		}
+	}
+	for _, backend := range backends {
+		s, err := app.openStorage(backend)
+		if err != nil {
+			closeStores()
+			return nil, fmt.Errorf("erasure coded storage backend %q: %v", backend, err)
+		}
+		stores = append(stores, s)
+	}
+	e, err := kverasure.New(data, parity, stores...)
+	if err != nil {
+		closeStores()
+		return nil, err
+	}
+	e.Degraded = func(key []byte, shards []int) {
+		log.Printf("erasure coded storage degraded: key %x, shards %v", key, shards)
+	}
+	return e, nil
+}
+
+// ValidateKV checks that the backend is well-formed and refers to
+// things that exist, without opening it. Directories given for file
+// and pack storage must already exist.
+func (app *App) ValidateKV(backend string) error {
+	if strings.HasPrefix(backend, "ec:") {
+		_, _, backends, err := app.parseErasure(backend[len("ec:"):])
+		if err != nil {
+			return err
+		}
+		for _, b := range backends {
+			if err := app.ValidateKV(b); err != nil {
+				return fmt.Errorf("erasure coded storage backend %q: %v", b, err)
+			}
+		}
+		return nil
+	}
+	key, err := storageKey(backend)
+	if err != nil {
+		return err
+	}
+	if key[0] == '/' {
+		return checkDir(key)
+	}
+	if strings.HasPrefix(key, "pack:") {
+		return checkDir(key[len("pack:"):])
+	}
+	if strings.HasPrefix(key, "peerkey:") {
+		var pub peer.PublicKey
+		if err := pub.Set(key[len("peerkey:"):]); err != nil {
+			return err
+		}
+		find := func(tx *db.Tx) error {
+			_, err := tx.Peers().Get(&pub)
+			return err
+		}
+		if err := app.DB.View(find); err != nil {
+			return err
+		}
+	}
+	return nil
+}
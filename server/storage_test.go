@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bazil.org/bazil/util/tempdir"
+)
+
+func TestOpenErasure(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(filepath.Join(tmp.Path, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	var dirs []string
+	for _, name := range []string{"a", "b", "c"} {
+		dir := filepath.Join(tmp.Path, name)
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		dirs = append(dirs, dir)
+	}
+	backend := "ec:2,1:" + strings.Join(dirs, ",")
+	s, err := app.openStorage(backend)
+	if err != nil {
+		t.Fatalf("open %q: %v", backend, err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+	if err := s.Put(ctx, []byte("k"), []byte("hello, world")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// losing any one backend is fine
+	if err := os.RemoveAll(dirs[0]); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Get(ctx, []byte("k"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if g, e := string(got), "hello, world"; g != e {
+		t.Errorf("wrong value: %q != %q", g, e)
+	}
+
+	for _, bad := range []string{
+		"ec:2,1:" + strings.Join(dirs[:2], ","),
+		"ec:2:" + strings.Join(dirs, ","),
+		"ec:x,1:" + strings.Join(dirs, ","),
+		"ec:2,1",
+		"ec:1,1:" + dirs[0] + ",ec:1,0:" + dirs[1],
+	} {
+		if err := app.ValidateKV(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestOpenPack(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(filepath.Join(tmp.Path, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	backend := "pack:" + filepath.Join(tmp.Path, "pack")
+	s, err := app.openStorage(backend)
+	if err != nil {
+		t.Fatalf("open %q: %v", backend, err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+	if err := s.Put(ctx, []byte("k"), []byte("hello, world")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// opening again shares the open pack
+	s2, err := app.openStorage(backend + "/")
+	if err != nil {
+		t.Fatalf("open again: %v", err)
+	}
+	defer s2.Close()
+	got, err := s2.Get(ctx, []byte("k"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if g, e := string(got), "hello, world"; g != e {
+		t.Errorf("wrong value: %q != %q", g, e)
+	}
+
+	if err := app.ValidateKV("pack:relative"); err == nil {
+		t.Error("expected error for relative path")
+	}
+}
+
+func TestStorageShared(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(filepath.Join(tmp.Path, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	backend := "pack:" + filepath.Join(tmp.Path, "pack")
+	s1, err := app.openStorage(backend)
+	if err != nil {
+		t.Fatalf("open %q: %v", backend, err)
+	}
+	s2, err := app.openStorage(backend)
+	if err != nil {
+		t.Fatalf("open again: %v", err)
+	}
+	if s1.(*storageRef).shared != s2.(*storageRef).shared {
+		t.Fatal("backend was not shared")
+	}
+	inUse := func() int {
+		app.storage.Lock()
+		defer app.storage.Unlock()
+		return len(app.storage.open)
+	}
+
+	if err := s1.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	// closing twice must not release twice
+	if err := s1.Close(); err != nil {
+		t.Fatalf("close again: %v", err)
+	}
+	if g, e := inUse(), 1; g != e {
+		t.Fatalf("backend closed while in use")
+	}
+	if err := s2.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if g, e := inUse(), 0; g != e {
+		t.Fatalf("backend not closed after last user")
+	}
+
+	// the pack can be opened again once closed
+	s3, err := app.openStorage(backend)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if err := s3.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+}
+
+func TestErasureDuplicate(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(filepath.Join(tmp.Path, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	a := filepath.Join(tmp.Path, "a")
+	b := filepath.Join(tmp.Path, "b")
+	for _, dir := range []string{a, b} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, bad := range []string{
+		"ec:2,1:" + a + "," + a + "," + b,
+		"ec:2,1:" + a + "," + b + "," + a + "/",
+		"ec:1,1:local," + filepath.Join(tmp.Path, "data", "chunks"),
+		"ec:1,1:local,local",
+	} {
+		if err := app.ValidateKV(bad); err == nil {
+			t.Errorf("expected error from validate for %q", bad)
+		}
+		if s, err := app.openStorage(bad); err == nil {
+			_ = s.Close()
+			t.Errorf("expected error from open for %q", bad)
+		}
+	}
+	if g, e := len(app.storage.open), 0; g != e {
+		t.Errorf("failed opens left storage open: %d", g)
+	}
+}
+
+func TestValidateKV(t *testing.T) {
+	tmp := tempdir.New(t)
+	defer tmp.Cleanup()
+	app, err := New(filepath.Join(tmp.Path, "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	pack := filepath.Join(tmp.Path, "pack")
+	if err := os.Mkdir(pack, 0700); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(tmp.Path, "file")
+	if err := ioutil.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, good := range []string{
+		"local",
+		"pack:" + pack,
+		"ec:1,1:local," + tmp.Path,
+	} {
+		if err := app.ValidateKV(good); err != nil {
+			t.Errorf("unexpected error for %q: %v", good, err)
+		}
+	}
+	if fis, err := ioutil.ReadDir(pack); err != nil || len(fis) != 0 {
+		t.Errorf("validation opened the storage: %v", err)
+	}
+	if g, e := len(app.storage.open), 0; g != e {
+		t.Errorf("validation left storage open: %d", g)
+	}
+
+	for _, bad := range []string{
+		"",
+		"bogus",
+		"bogus:thing",
+		"peerkey:xyzzy",
+		// valid key, but not a known peer
+		"peerkey:4d0e625eff41006a18b3bfda35b140fcad9178fe6f6caf74f5059c35f0fe21af",
+		"ec:1,1:local,bogus",
+		filepath.Join(tmp.Path, "missing"),
+		file,
+		"pack:" + filepath.Join(tmp.Path, "missing"),
+		"pack:" + file,
+	} {
+		if err := app.ValidateKV(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
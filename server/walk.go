@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"bazil.org/bazil/cas"
+	"bazil.org/bazil/cas/blobs"
+	"bazil.org/bazil/cas/chunks"
+	wirecas "bazil.org/bazil/cas/wire"
+	"bazil.org/bazil/db"
+	"bazil.org/bazil/fs/snap"
+	wiresnap "bazil.org/bazil/fs/snap/wire"
+	wirefs "bazil.org/bazil/fs/wire"
+	wirepeer "bazil.org/bazil/peer/wire"
+	"bazil.org/bazil/tokens"
+	"github.com/golang/protobuf/proto"
+)
+
+// volumeFile is a blob found in the volume metadata.
+type volumeFile struct {
+	path     string
+	manifest *blobs.Manifest
+}
+
+// volumeSnapshot is a snapshot of the volume.
+type volumeSnapshot struct {
+	name string
+	key  cas.Key
+}
+
+// volumeRoots are where the chunks reachable from a volume are found.
+type volumeRoots struct {
+	files     []volumeFile
+	snapshots []volumeSnapshot
+}
+
+// listVolumeRoots returns the files of the volume, including the
+// conflicting versions recorded for them, and its snapshots.
+func listVolumeRoots(vol *db.Volume) (*volumeRoots, error) {
+	files, err := volumeFiles(vol, tokens.InodeRoot, "")
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := volumeSnapshots(vol)
+	if err != nil {
+		return nil, err
+	}
+	roots := &volumeRoots{
+		files:     files,
+		snapshots: snapshots,
+	}
+	return roots, nil
+}
+
+// volumeFiles returns the files in the directory and its
+// subdirectories, including the conflicting versions recorded for
+// them.
+func volumeFiles(vol *db.Volume, inode uint64, dir string) ([]volumeFile, error) {
+	var list []volumeFile
+	add := func(p string, m *wirecas.Manifest) error {
+		manifest, err := m.ToBlob("file")
+		if err != nil {
+			return err
+		}
+		list = append(list, volumeFile{path: p, manifest: manifest})
+		return nil
+	}
+
+	c := vol.Dirs().List(inode)
+	for item := c.First(); item != nil; item = c.Next() {
+		var de wirefs.Dirent
+		if err := item.Unmarshal(&de); err != nil {
+			return nil, err
+		}
+		p := path.Join(dir, item.Name())
+		switch t := de.Type.(type) {
+		case *wirefs.Dirent_File:
+			if err := add(p, t.File.Manifest); err != nil {
+				return nil, err
+			}
+		case *wirefs.Dirent_Dir:
+			sub, err := volumeFiles(vol, de.Inode, p)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, sub...)
+		}
+	}
+
+	cc := vol.Conflicts().ListAll(inode)
+	for item := cc.First(); item != nil; item = cc.Next() {
+		var de wirepeer.Dirent
+		if err := item.Dirent(&de); err != nil {
+			return nil, err
+		}
+		if t, ok := de.Type.(*wirepeer.Dirent_File); ok {
+			p := path.Join(dir, de.Name) + " (conflict)"
+			if err := add(p, t.File.Manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return list, nil
+}
+
+// volumeSnapshots returns the snapshots of the volume.
+func volumeSnapshots(vol *db.Volume) ([]volumeSnapshot, error) {
+	b := vol.SnapBucket()
+	if b == nil {
+		return nil, nil
+	}
+	var list []volumeSnapshot
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var ref wirefs.SnapshotRef
+		if err := proto.Unmarshal(v, &ref); err != nil {
+			return nil, fmt.Errorf("corrupt snapshot reference: %q: %v", k, err)
+		}
+		var key cas.Key
+		if err := key.UnmarshalBinary(ref.Key); err != nil {
+			return nil, fmt.Errorf("corrupt snapshot reference: %q: %v", k, err)
+		}
+		list = append(list, volumeSnapshot{name: string(k), key: key})
+	}
+	return list, nil
+}
+
+// chunkWalk visits every chunk reachable from the roots of a volume.
+// Chunks reachable in many ways are visited once for each.
+type chunkWalk struct {
+	ctx context.Context
+	// Used to read the chunks, to find out what chunks they point
+	// to.
+	store chunks.Store
+	// Called for each chunk, with the path of a file or directory
+	// using it. Returns false if the chunk is not intact, in which
+	// case the chunks it points to are not walked.
+	visit func(p string, key cas.Key, typ string, level uint8) (bool, error)
+	// Reports whether the directory with the root key has been
+	// walked fully before, and need not be walked again.
+	done func(root cas.Key) bool
+}
+
+var errWalkBroken = errors.New("unreadable pointer chunk")
+
+func (w *chunkWalk) walk(roots *volumeRoots) error {
+	for _, f := range roots.files {
+		if _, err := w.blob(f.path, f.manifest); err != nil {
+			return err
+		}
+	}
+	for _, s := range roots.snapshots {
+		if err := w.snapshot(path.Join(".snap", s.name), s.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blob visits the chunks of the blob, and reports whether they were
+// all intact.
+func (w *chunkWalk) blob(p string, m *blobs.Manifest) (bool, error) {
+	blob, err := blobs.Open(w.store, m)
+	if err != nil {
+		return false, err
+	}
+	intact := true
+	walk := func(key cas.Key, level uint8) error {
+		ok, err := w.visit(p, key, m.Type, level)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			intact = false
+			if level > 0 {
+				// cannot see what the chunk points to
+				return errWalkBroken
+			}
+		}
+		return nil
+	}
+	if err := blob.Walk(w.ctx, walk); err != nil && err != errWalkBroken {
+		return false, err
+	}
+	return intact, nil
+}
+
+func (w *chunkWalk) snapshot(p string, key cas.Key) error {
+	ok, err := w.visit(p, key, "snap", 0)
+	if err != nil || !ok {
+		return err
+	}
+	chunk, err := w.store.Get(w.ctx, key, "snap", 0)
+	if err != nil {
+		return err
+	}
+	var s wiresnap.Snapshot
+	if err := proto.Unmarshal(chunk.Buf, &s); err != nil {
+		return fmt.Errorf("corrupt snapshot: %v: %v", key, err)
+	}
+	return w.dirent(p, s.Contents)
+}
+
+func (w *chunkWalk) dirent(p string, de *wiresnap.Dirent) error {
+	switch t := de.Type.(type) {
+	case *wiresnap.Dirent_File:
+		m, err := t.File.Manifest.ToBlob("file")
+		if err != nil {
+			return err
+		}
+		_, err = w.blob(p, m)
+		return err
+
+	case *wiresnap.Dirent_Dir:
+		m, err := t.Dir.Manifest.ToBlob("dir")
+		if err != nil {
+			return err
+		}
+		if !m.Root.IsSpecial() && w.done(m.Root) {
+			// identical directory seen before
+			return nil
+		}
+		intact, err := w.blob(p, m)
+		if err != nil {
+			return err
+		}
+		if !intact {
+			// contents cannot be read; visit has seen why
+			return nil
+		}
+		blob, err := blobs.Open(w.store, m)
+		if err != nil {
+			return err
+		}
+		reader, err := snap.NewReader(blob.IO(w.ctx), t.Dir.Align)
+		if err != nil {
+			return err
+		}
+		it := reader.Iter()
+		for {
+			child, err := it.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if err := w.dirent(path.Join(p, child.Name), child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
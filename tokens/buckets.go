@@ -18,6 +18,21 @@ const (
 	// secret.
 	BucketSharing = "sharing"
 
+	// The DB bucket that contains sharing key rotations. Key is the
+	// name of the sharing key being replaced, value is protobuf
+	// bazil.db.SharingKeyRotation.
+	BucketSharingRotation = "sharingRotation"
+
+	// The DB bucket that contains the key derivation parameters of
+	// sharing keys derived from a passphrase. Key is the name of the
+	// sharing key, value is protobuf bazil.db.SharingKeyKDF.
+	BucketSharingKDF = "sharingKDF"
+
+	// The DB bucket that contains the padding mode of sharing keys
+	// that pad stored data to hide its size. Key is the name of the
+	// sharing key, value is the name of the padding mode.
+	BucketSharingPadding = "sharingPadding"
+
 	// The DB bucket that contains peers by public key.
 	BucketPeer = "peer"
 
@@ -5,9 +5,13 @@ const (
 	PeerStateID = "id"
 
 	// The DB bucket that contains addresses for the peer. Key is peer
-	// host:port, value is empty for now
+	// host:port, value is the priority as a big-endian uint32, lower
+	// is preferred. An empty value means priority 0.
 	PeerStateLocation = "location"
 
+	// The peer host:port that was last successfully connected to.
+	PeerStateLocationLast = "locationLast"
+
 	// The DB bucket that configures what storage to offer to peer.
 	// Key is storage backend, value is empty for now. Later this may
 	// include quota style restrictions.
@@ -0,0 +1,8 @@
+package tokens
+
+const (
+	// UDP multicast group and port used for discovering peers on the
+	// local network. The group is in the organization-local scope,
+	// the port is the same as TCPPortHTTP.
+	DiscoveryGroup = "239.255.42.11:34211"
+)
@@ -13,11 +13,18 @@ import (
 
 var (
 	errMissingTLSConfig = errors.New("missing TLS configuration")
+	errMissingPeerPub   = errors.New("missing peer public key")
 )
 
 type Authenticator struct {
 	Config  func() (*tls.Config, error)
 	PeerPub *[ed25519.PublicKeySize]byte
+	// Lookup decides whether to talk to the peer with the given
+	// public key. An error aborts the handshake. On the server side,
+	// a nil Lookup accepts everyone. On the client side, it is only
+	// used when PeerPub is nil, to accept a peer whose key is not
+	// known in advance.
+	Lookup func(pub *[ed25519.PublicKeySize]byte) error
 }
 
 var _ credentials.TransportCredentials = (*Authenticator)(nil)
@@ -48,6 +55,9 @@ func (a *Authenticator) ClientHandshake(ctx context.Context, addr string, rawCon
 	}
 	// We do our own verification, with edtls.
 	conf.InsecureSkipVerify = true
+	if a.PeerPub == nil {
+		return a.clientHandshakeLookup(rawConn, conf)
+	}
 	tconn, err := edtls.NewClient(rawConn, conf, a.PeerPub)
 	if err != nil {
 		return nil, nil, err
@@ -59,6 +69,35 @@ func (a *Authenticator) ClientHandshake(ctx context.Context, addr string, rawCon
 	return tconn, authInfo, nil
 }
 
+func (a *Authenticator) clientHandshakeLookup(rawConn net.Conn, conf *tls.Config) (net.Conn, credentials.AuthInfo, error) {
+	if a.Lookup == nil {
+		return nil, nil, errMissingPeerPub
+	}
+	tconn := tls.Client(rawConn, conf)
+	if err := tconn.Handshake(); err != nil {
+		tconn.Close()
+		return nil, nil, err
+	}
+	state := tconn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		tconn.Close()
+		return nil, nil, edtls.ErrNotEdTLS
+	}
+	pub, ok := edtls.Verify(state.PeerCertificates[0])
+	if !ok {
+		tconn.Close()
+		return nil, nil, edtls.ErrNotEdTLS
+	}
+	if err := a.Lookup(pub); err != nil {
+		tconn.Close()
+		return nil, nil, err
+	}
+	authInfo := &Auth{
+		PeerPub: pub,
+	}
+	return tconn, authInfo, nil
+}
+
 func (a *Authenticator) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
 	if a.Config == nil {
 		return nil, nil, errMissingTLSConfig
@@ -87,6 +126,12 @@ func (a *Authenticator) ServerHandshake(conn net.Conn) (net.Conn, credentials.Au
 		conn.Close()
 		return nil, nil, errors.New("edtls verification failed")
 	}
+	if a.Lookup != nil {
+		if err := a.Lookup(pub); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+	}
 	authInfo := &Auth{
 		PeerPub: pub,
 	}
@@ -104,6 +149,7 @@ func (a *Authenticator) Clone() credentials.TransportCredentials {
 	aa := &Authenticator{
 		Config:  a.Config,
 		PeerPub: a.PeerPub,
+		Lookup:  a.Lookup,
 	}
 	return aa
 }
@@ -6,7 +6,8 @@ import (
 
 func Dial(path string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	opts = append(opts,
-		// UNIX access controls are our security
+		// UNIX access controls are our security; the server
+		// checks who is connecting
 		grpc.WithInsecure(),
 	)
 	return grpc.Dial("unix:"+path, opts...)
@@ -0,0 +1,83 @@
+package metrics_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bazil.org/bazil/util/metrics"
+)
+
+func TestWrite(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounter("test_ops_total", "Operations done.", "kind", "where")
+	h := r.NewHistogram("test_duration_seconds", "Time taken.", []float64{0.1, 1}, "kind")
+	r.NewGaugeFunc("test_open", "Things open.", func() float64 { return 3 })
+
+	c.Inc("read", "here")
+	c.Add(2, "read", "here")
+	c.Inc("write", `"odd"\`+"\n")
+	h.Observe(0.05, "read")
+	h.Observe(0.5, "read")
+	h.Observe(5, "read")
+
+	if g, e := c.Value("read", "here"), 3.0; g != e {
+		t.Errorf("wrong counter value: %v != %v", g, e)
+	}
+	if g, e := h.Count("read"), uint64(3); g != e {
+		t.Errorf("wrong histogram count: %v != %v", g, e)
+	}
+
+	var buf bytes.Buffer
+	n, err := r.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if g, e := n, int64(buf.Len()); g != e {
+		t.Errorf("wrong length: %d != %d", g, e)
+	}
+	want := `# HELP test_duration_seconds Time taken.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{kind="read",le="0.1"} 1
+test_duration_seconds_bucket{kind="read",le="1"} 2
+test_duration_seconds_bucket{kind="read",le="+Inf"} 3
+test_duration_seconds_sum{kind="read"} 5.55
+test_duration_seconds_count{kind="read"} 3
+# HELP test_open Things open.
+# TYPE test_open gauge
+test_open 3
+# HELP test_ops_total Operations done.
+# TYPE test_ops_total counter
+test_ops_total{kind="read",where="here"} 3
+test_ops_total{kind="write",where="\"odd\"\\\n"} 1
+`
+	if g := buf.String(); g != want {
+		t.Errorf("wrong output:\n%s\nwant:\n%s", g, want)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	r := metrics.NewRegistry()
+	r.NewCounter("test_total", "Test.")
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	r.NewCounter("test_total", "Test.")
+}
+
+func TestServeHTTP(t *testing.T) {
+	r := metrics.NewRegistry()
+	r.NewCounter("test_total", "Test.").Inc()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if g, e := rec.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8"; g != e {
+		t.Errorf("wrong content type: %q != %q", g, e)
+	}
+	if !strings.Contains(rec.Body.String(), "\ntest_total 1\n") {
+		t.Errorf("counter missing from response:\n%s", rec.Body.String())
+	}
+}
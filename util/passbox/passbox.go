@@ -0,0 +1,175 @@
+// Package passbox encrypts small secrets with a passphrase, as
+// PEM-armored text.
+//
+// The passphrase is turned into a key with scrypt, and the secret is
+// sealed with NaCl secretbox. The scrypt parameters are stored in the
+// PEM headers.
+package passbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"strconv"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Default scrypt cost parameters.
+const (
+	DefaultLogN = 16
+	DefaultR    = 8
+	DefaultP    = 1
+)
+
+const (
+	saltSize  = 16
+	nonceSize = 24
+
+	// Limits on parameters accepted from elsewhere, to keep a
+	// malicious input from using all memory or CPU. scrypt needs
+	// about 128*R*N bytes of memory.
+	maxLogN   = 22
+	maxR      = 32
+	maxP      = 16
+	maxMemory = 1 << 30
+)
+
+var (
+	ErrBadKDF        = errors.New("bad key derivation parameters")
+	ErrMalformed     = errors.New("malformed encrypted file")
+	ErrBadPassphrase = errors.New("wrong passphrase or corrupt encrypted file")
+)
+
+// KDF holds scrypt parameters.
+type KDF struct {
+	Salt []byte
+	// scrypt cost parameter N is 1<<LogN.
+	LogN uint32
+	R    uint32
+	P    uint32
+}
+
+// NewKDF returns scrypt parameters with a fresh random salt and the
+// default costs.
+func NewKDF() (*KDF, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	kdf := &KDF{
+		Salt: salt,
+		LogN: DefaultLogN,
+		R:    DefaultR,
+		P:    DefaultP,
+	}
+	return kdf, nil
+}
+
+// Check returns ErrBadKDF if the parameters are out of the accepted
+// range.
+func (kdf *KDF) Check() error {
+	if len(kdf.Salt) == 0 ||
+		kdf.LogN < 1 || kdf.LogN > maxLogN ||
+		kdf.R < 1 || kdf.R > maxR ||
+		kdf.P < 1 || kdf.P > maxP ||
+		128*uint64(kdf.R)<<kdf.LogN > maxMemory {
+		return ErrBadKDF
+	}
+	return nil
+}
+
+// Derive computes a key from the passphrase.
+//
+// If the parameters are out of the accepted range, returns
+// ErrBadKDF.
+func (kdf *KDF) Derive(passphrase []byte) (*[32]byte, error) {
+	if err := kdf.Check(); err != nil {
+		return nil, err
+	}
+	buf, err := scrypt.Key(passphrase, kdf.Salt, 1<<kdf.LogN, int(kdf.R), int(kdf.P), 32)
+	if err != nil {
+		return nil, err
+	}
+	var key [32]byte
+	copy(key[:], buf)
+	return &key, nil
+}
+
+// Seal encrypts plain with the passphrase, and returns it as a PEM
+// block of the given type. The extra headers are stored
+// unencrypted.
+func Seal(blockType string, headers map[string]string, plain []byte, passphrase []byte) ([]byte, error) {
+	kdf, err := NewKDF()
+	if err != nil {
+		return nil, err
+	}
+	key, err := kdf.Derive(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	boxed := secretbox.Seal(nonce[:], plain, &nonce, key)
+
+	h := map[string]string{
+		"Salt": hex.EncodeToString(kdf.Salt),
+		"LogN": strconv.FormatUint(uint64(kdf.LogN), 10),
+		"R":    strconv.FormatUint(uint64(kdf.R), 10),
+		"P":    strconv.FormatUint(uint64(kdf.P), 10),
+	}
+	for k, v := range headers {
+		h[k] = v
+	}
+	block := &pem.Block{
+		Type:    blockType,
+		Headers: h,
+		Bytes:   boxed,
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+func parseUint32(s string) (uint32, error) {
+	n, err := strconv.ParseUint(s, 10, 32)
+	return uint32(n), err
+}
+
+// Open decrypts a PEM block of the given type, as created by Seal.
+func Open(blockType string, armored []byte, passphrase []byte) ([]byte, error) {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != blockType {
+		return nil, ErrMalformed
+	}
+	var kdf KDF
+	var err error
+	if kdf.Salt, err = hex.DecodeString(block.Headers["Salt"]); err != nil {
+		return nil, ErrMalformed
+	}
+	if kdf.LogN, err = parseUint32(block.Headers["LogN"]); err != nil {
+		return nil, ErrMalformed
+	}
+	if kdf.R, err = parseUint32(block.Headers["R"]); err != nil {
+		return nil, ErrMalformed
+	}
+	if kdf.P, err = parseUint32(block.Headers["P"]); err != nil {
+		return nil, ErrMalformed
+	}
+	if len(block.Bytes) < nonceSize {
+		return nil, ErrMalformed
+	}
+	key, err := kdf.Derive(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], block.Bytes)
+	plain, ok := secretbox.Open(nil, block.Bytes[nonceSize:], &nonce, key)
+	if !ok {
+		return nil, ErrBadPassphrase
+	}
+	return plain, nil
+}
@@ -0,0 +1,76 @@
+package passbox_test
+
+import (
+	"bytes"
+	"testing"
+
+	"bazil.org/bazil/util/passbox"
+)
+
+func TestSealOpen(t *testing.T) {
+	plain := []byte("attack at dawn")
+	armored, err := passbox.Seal("TEST SECRET", map[string]string{"Comment": "hello"}, plain, []byte("s3kr1t"))
+	if err != nil {
+		t.Fatalf("seal failed: %v", err)
+	}
+	if !bytes.HasPrefix(armored, []byte("-----BEGIN TEST SECRET-----\n")) {
+		t.Errorf("not armored: %q", armored)
+	}
+	if !bytes.Contains(armored, []byte("Comment: hello\n")) {
+		t.Errorf("missing extra header: %q", armored)
+	}
+	if bytes.Contains(armored, plain) {
+		t.Errorf("plaintext visible: %q", armored)
+	}
+
+	got, err := passbox.Open("TEST SECRET", armored, []byte("s3kr1t"))
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Errorf("wrong plaintext: %q != %q", got, plain)
+	}
+
+	if _, err := passbox.Open("TEST SECRET", armored, []byte("wrong")); err != passbox.ErrBadPassphrase {
+		t.Errorf("expected ErrBadPassphrase, got %v", err)
+	}
+	if _, err := passbox.Open("OTHER SECRET", armored, []byte("s3kr1t")); err != passbox.ErrMalformed {
+		t.Errorf("expected ErrMalformed for wrong type, got %v", err)
+	}
+	tampered := bytes.Replace(armored, []byte("LogN: 16"), []byte("LogN: 99"), 1)
+	if _, err := passbox.Open("TEST SECRET", tampered, []byte("s3kr1t")); err != passbox.ErrBadKDF {
+		t.Errorf("expected ErrBadKDF, got %v", err)
+	}
+}
+
+func TestDeriveLimits(t *testing.T) {
+	for _, kdf := range []*passbox.KDF{
+		// defaults are fine
+		{Salt: []byte("x"), LogN: passbox.DefaultLogN, R: passbox.DefaultR, P: passbox.DefaultP},
+		// exactly 1 GiB
+		{Salt: []byte("x"), LogN: 20, R: 8, P: 1},
+	} {
+		if err := kdf.Check(); err != nil {
+			t.Errorf("unexpected error for %+v: %v", kdf, err)
+		}
+	}
+	for _, kdf := range []*passbox.KDF{
+		{LogN: 10, R: 8, P: 1},
+		{Salt: []byte("x"), LogN: 0, R: 8, P: 1},
+		{Salt: []byte("x"), LogN: 23, R: 1, P: 1},
+		{Salt: []byte("x"), LogN: 10, R: 0, P: 1},
+		{Salt: []byte("x"), LogN: 10, R: 8, P: 0},
+		{Salt: []byte("x"), LogN: 10, R: 33, P: 1},
+		{Salt: []byte("x"), LogN: 10, R: 8, P: 17},
+		// over 1 GiB of memory
+		{Salt: []byte("x"), LogN: 21, R: 8, P: 1},
+		{Salt: []byte("x"), LogN: 22, R: 1024, P: 1},
+	} {
+		if err := kdf.Check(); err != passbox.ErrBadKDF {
+			t.Errorf("expected ErrBadKDF for %+v, got %v", kdf, err)
+		}
+		if _, err := kdf.Derive([]byte("s3kr1t")); err != passbox.ErrBadKDF {
+			t.Errorf("expected ErrBadKDF from Derive for %+v, got %v", kdf, err)
+		}
+	}
+}
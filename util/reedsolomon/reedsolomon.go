@@ -0,0 +1,292 @@
+// Package reedsolomon implements systematic Reed-Solomon erasure
+// coding over GF(2^8).
+//
+// Data is split into data shards, and parity shards are computed
+// from them. The original data shards can be reconstructed from any
+// data shards worth of the data and parity shards.
+package reedsolomon
+
+import (
+	"errors"
+)
+
+var (
+	ErrShardCount   = errors.New("invalid number of shards")
+	ErrShardSize    = errors.New("shards differ in size")
+	ErrTooFewShards = errors.New("too few shards to reconstruct")
+)
+
+// The field is GF(2^8) with the polynomial x^8+x^4+x^3+x^2+1, as
+// commonly used for Reed-Solomon codes.
+const polynomial = 0x11d
+
+var (
+	gfExp [510]byte
+	gfLog [256]byte
+	// gfMul[a][b] is a*b in the field.
+	gfMul [256][256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfExp[i+255] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= polynomial
+		}
+	}
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			gfMul[a][b] = gfExp[int(gfLog[a])+int(gfLog[b])]
+		}
+	}
+}
+
+func gfInverse(a byte) byte {
+	return gfExp[255-int(gfLog[a])]
+}
+
+func gfPow(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])*n)%255]
+}
+
+type matrix [][]byte
+
+func newMatrix(rows, cols int) matrix {
+	m := make(matrix, rows)
+	for r := range m {
+		m[r] = make([]byte, cols)
+	}
+	return m
+}
+
+func (m matrix) mul(o matrix) matrix {
+	out := newMatrix(len(m), len(o[0]))
+	for r := range out {
+		for c := range out[r] {
+			var v byte
+			for i := range o {
+				v ^= gfMul[m[r][i]][o[i][c]]
+			}
+			out[r][c] = v
+		}
+	}
+	return out
+}
+
+// invert returns the inverse of a square matrix, using Gauss-Jordan
+// elimination. Returns false if the matrix is singular.
+func (m matrix) invert() (matrix, bool) {
+	n := len(m)
+	work := newMatrix(n, 2*n)
+	for r := range m {
+		copy(work[r], m[r])
+		work[r][n+r] = 1
+	}
+	for c := 0; c < n; c++ {
+		if work[c][c] == 0 {
+			for r := c + 1; r < n; r++ {
+				if work[r][c] != 0 {
+					work[c], work[r] = work[r], work[c]
+					break
+				}
+			}
+		}
+		if work[c][c] == 0 {
+			return nil, false
+		}
+		scale := gfInverse(work[c][c])
+		for i := range work[c] {
+			work[c][i] = gfMul[scale][work[c][i]]
+		}
+		for r := 0; r < n; r++ {
+			if r == c || work[r][c] == 0 {
+				continue
+			}
+			f := work[r][c]
+			for i := range work[r] {
+				work[r][i] ^= gfMul[f][work[c][i]]
+			}
+		}
+	}
+	out := newMatrix(n, n)
+	for r := range out {
+		copy(out[r], work[r][n:])
+	}
+	return out, true
+}
+
+// mulAdd adds c*src to dst.
+func mulAdd(dst, src []byte, c byte) {
+	t := &gfMul[c]
+	for i, b := range src {
+		dst[i] ^= t[b]
+	}
+}
+
+// Code encodes and reconstructs a fixed number of data and parity
+// shards.
+type Code struct {
+	data   int
+	parity int
+	// Encoding matrix with data+parity rows and data columns. The
+	// top rows are the identity matrix, so data shards are stored
+	// as is.
+	matrix matrix
+}
+
+// New returns a code with the given number of data and parity
+// shards. There can be at most 256 shards in total.
+func New(data, parity int) (*Code, error) {
+	if data < 1 || parity < 0 || data+parity > 256 {
+		return nil, ErrShardCount
+	}
+	n := data + parity
+	// Any data rows of a Vandermonde matrix with distinct rows are
+	// invertible. Multiplying by the inverse of the top part keeps
+	// that property, while making the code systematic.
+	vm := newMatrix(n, data)
+	for r := range vm {
+		for c := range vm[r] {
+			vm[r][c] = gfPow(byte(r), c)
+		}
+	}
+	top, ok := vm[:data].invert()
+	if !ok {
+		// cannot happen with distinct rows
+		return nil, ErrShardCount
+	}
+	c := &Code{
+		data:   data,
+		parity: parity,
+		matrix: vm.mul(top),
+	}
+	return c, nil
+}
+
+// DataShards returns the number of data shards.
+func (c *Code) DataShards() int {
+	return c.data
+}
+
+// ParityShards returns the number of parity shards.
+func (c *Code) ParityShards() int {
+	return c.parity
+}
+
+func (c *Code) shardSize(shards [][]byte) (int, error) {
+	if len(shards) != c.data+c.parity {
+		return 0, ErrShardCount
+	}
+	size := -1
+	for _, s := range shards {
+		if s == nil {
+			continue
+		}
+		if size == -1 {
+			size = len(s)
+		} else if len(s) != size {
+			return 0, ErrShardSize
+		}
+	}
+	return size, nil
+}
+
+// Encode computes the parity shards from the data shards. shards
+// must hold data+parity slices; the first data ones must be the
+// same size. Parity shards are allocated if nil.
+func (c *Code) Encode(shards [][]byte) error {
+	if len(shards) != c.data+c.parity {
+		return ErrShardCount
+	}
+	size := len(shards[0])
+	for _, s := range shards[:c.data] {
+		if s == nil || len(s) != size {
+			return ErrShardSize
+		}
+	}
+	for i := c.data; i < len(shards); i++ {
+		if len(shards[i]) != size {
+			shards[i] = make([]byte, size)
+		} else {
+			for j := range shards[i] {
+				shards[i][j] = 0
+			}
+		}
+		c.compute(shards[i], c.matrix[i], shards[:c.data])
+	}
+	return nil
+}
+
+// compute fills out with the linear combination of data given by
+// row. out must be zeroed.
+func (c *Code) compute(out []byte, row []byte, data [][]byte) {
+	for j, coef := range row {
+		if coef == 0 {
+			continue
+		}
+		mulAdd(out, data[j], coef)
+	}
+}
+
+// Reconstruct fills in the missing shards, which are marked as nil.
+// At least data of the shards must be present, and they must be the
+// same size.
+//
+// Shards that are present are assumed to be correct; detecting
+// corruption is up to the caller.
+func (c *Code) Reconstruct(shards [][]byte) error {
+	size, err := c.shardSize(shards)
+	if err != nil {
+		return err
+	}
+	var rows []int
+	for i, s := range shards {
+		if s != nil {
+			rows = append(rows, i)
+		}
+	}
+	if len(rows) < c.data {
+		return ErrTooFewShards
+	}
+	if len(rows) == len(shards) {
+		return nil
+	}
+	rows = rows[:c.data]
+
+	sub := make(matrix, c.data)
+	present := make([][]byte, c.data)
+	for i, r := range rows {
+		sub[i] = c.matrix[r]
+		present[i] = shards[r]
+	}
+	dec, ok := sub.invert()
+	if !ok {
+		// cannot happen, any data rows are invertible
+		return ErrTooFewShards
+	}
+	for i := 0; i < c.data; i++ {
+		if shards[i] != nil {
+			continue
+		}
+		shards[i] = make([]byte, size)
+		c.compute(shards[i], dec[i], present)
+	}
+	for i := c.data; i < len(shards); i++ {
+		if shards[i] != nil {
+			continue
+		}
+		shards[i] = make([]byte, size)
+		c.compute(shards[i], c.matrix[i], shards[:c.data])
+	}
+	return nil
+}
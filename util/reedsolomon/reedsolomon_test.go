@@ -0,0 +1,94 @@
+package reedsolomon_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"bazil.org/bazil/util/reedsolomon"
+)
+
+func makeShards(t testing.TB, code *reedsolomon.Code, size int) [][]byte {
+	rnd := rand.New(rand.NewSource(42))
+	shards := make([][]byte, code.DataShards()+code.ParityShards())
+	for i := 0; i < code.DataShards(); i++ {
+		shards[i] = make([]byte, size)
+		rnd.Read(shards[i])
+	}
+	if err := code.Encode(shards); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	return shards
+}
+
+func TestReconstruct(t *testing.T) {
+	code, err := reedsolomon.New(4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := makeShards(t, code, 100)
+	n := len(orig)
+	// drop every combination of up to 3 shards
+	for mask := 0; mask < 1<<uint(n); mask++ {
+		missing := 0
+		shards := make([][]byte, n)
+		for i := range shards {
+			if mask&(1<<uint(i)) != 0 {
+				missing++
+				continue
+			}
+			shards[i] = append([]byte(nil), orig[i]...)
+		}
+		if missing > code.ParityShards() {
+			if err := code.Reconstruct(shards); err != reedsolomon.ErrTooFewShards {
+				t.Fatalf("mask %b: expected ErrTooFewShards, got %v", mask, err)
+			}
+			continue
+		}
+		if err := code.Reconstruct(shards); err != nil {
+			t.Fatalf("mask %b: Reconstruct: %v", mask, err)
+		}
+		for i := range shards {
+			if !bytes.Equal(shards[i], orig[i]) {
+				t.Fatalf("mask %b: shard %d reconstructed wrong", mask, i)
+			}
+		}
+	}
+}
+
+func TestNoParity(t *testing.T) {
+	code, err := reedsolomon.New(3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shards := makeShards(t, code, 10)
+	if err := code.Reconstruct(shards); err != nil {
+		t.Fatalf("Reconstruct: %v", err)
+	}
+	shards[1] = nil
+	if err := code.Reconstruct(shards); err != reedsolomon.ErrTooFewShards {
+		t.Fatalf("expected ErrTooFewShards, got %v", err)
+	}
+}
+
+func TestEncodeBadSize(t *testing.T) {
+	code, err := reedsolomon.New(2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shards := [][]byte{make([]byte, 3), make([]byte, 4), nil}
+	if err := code.Encode(shards); err != reedsolomon.ErrShardSize {
+		t.Errorf("expected ErrShardSize, got %v", err)
+	}
+	if err := code.Encode(shards[:2]); err != reedsolomon.ErrShardCount {
+		t.Errorf("expected ErrShardCount, got %v", err)
+	}
+}
+
+func TestNewBad(t *testing.T) {
+	for _, c := range [][2]int{{0, 1}, {1, -1}, {200, 57}} {
+		if _, err := reedsolomon.New(c[0], c[1]); err != reedsolomon.ErrShardCount {
+			t.Errorf("New(%d, %d): expected ErrShardCount, got %v", c[0], c[1], err)
+		}
+	}
+}